@@ -2,6 +2,8 @@ package config
 
 import (
 	"final-project/models"
+	"final-project/similarity"
+	"final-project/slug"
 	"final-project/utils"
 	"fmt"
 	"log"
@@ -31,10 +33,12 @@ func ConnectDatabase() *gorm.DB {
 	user := utils.GetEnv("DB_USER", "root")
 	password := utils.GetEnv("DB_PASSWORD", "password")
 	dbname := utils.GetEnv("DB_NAME", "db_phone_review")
+	// zona waktu koneksi database, nama IANA contoh Asia/Jakarta / UTC
+	timezone := utils.GetEnv("DB_TIMEZONE", "Asia/Jakarta")
 
 	switch dbProvider {
 	case "postgre":
-		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Jakarta", host, user, password, dbname, port)
+		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=%s", host, user, password, dbname, port, timezone)
 		dbPsg, err := gorm.Open(postgres.New(postgres.Config{
 			DSN:                  dsn,
 			PreferSimpleProtocol: true, // disables implicit prepared statement usage
@@ -55,21 +59,63 @@ func ConnectDatabase() *gorm.DB {
 		db = dbGorm
 	}
 
+	// kolom specification lama yg diganti nama
+	if err := renameLegacySpecColumns(db); err != nil {
+		log.Fatal(err.Error())
+	}
+
 	// Migrate the schema
 	err := db.AutoMigrate(
 		&models.Role{},
 		&models.User{},
 		&models.Profile{},
 		&models.Brand{},
+		&models.Category{},
+		&models.Tag{},
 		&models.Phone{},
+		&models.PhoneFeature{},
+		&models.PhoneVariant{},
+		&models.PhoneImage{},
+		&models.PhonePrice{},
+		&models.ExchangeRate{},
 		&models.Specification{},
+		&models.CameraModule{},
+		&models.SpecAttribute{},
+		&models.SpecAttributeValue{},
+		&models.ValueScoreComponent{},
 		&models.Review{},
+		&models.RatingAspect{},
+		&models.ReviewAspectRating{},
+		&models.ReviewVote{},
+		&models.ReviewPhoto{},
+		&models.ReviewProCon{},
 		&models.Comment{},
+		&models.ReviewRevision{},
+		&models.CommentRevision{},
+		&models.UserRecommendation{},
+		&models.SlugHistory{},
+		&models.ContentFilterRule{},
+		&models.ContentFilterWord{},
 	)
 
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 
+	// pindahkan data specification teks bebas lama ke kolom bersatuan
+	if err := migrateLegacySpecification(db); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	// isi slug phone & brand yg dibuat sebelum ada slug
+	if err := slug.Backfill(db); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	// hitung fitur kemiripan phone yg belum punya
+	if err := similarity.Backfill(db); err != nil {
+		log.Fatal(err.Error())
+	}
+
 	return db
 }
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"final-project/models"
+	"final-project/utils"
+
+	"gorm.io/gorm"
+)
+
+// kolom specification lama yg hanya diganti nama (satuan tetap GB)
+var legacySpecColumnRenames = [][2]string{
+	{"memory", "memory_gb"},
+	{"storage", "storage_gb"},
+}
+
+// renameLegacySpecColumns dijalankan sebelum AutoMigrate agar data memory &
+// storage lama tidak hilang karena AutoMigrate membuat kolom baru
+func renameLegacySpecColumns(db *gorm.DB) error {
+	m := db.Migrator()
+	if !m.HasTable(&models.Specification{}) {
+		return nil
+	}
+
+	for _, col := range legacySpecColumnRenames {
+		if m.HasColumn(&models.Specification{}, col[0]) && !m.HasColumn(&models.Specification{}, col[1]) {
+			if err := m.RenameColumn(&models.Specification{}, col[0], col[1]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type legacySpecification struct {
+	ID      uint
+	Network string
+	Battery string
+	Camera  uint
+}
+
+// migrateLegacySpecification dijalankan setelah AutoMigrate, mem-parsing kolom
+// teks bebas lama (battery, network) & camera tanpa satuan ke kolom baru lalu
+// menghapus kolom lama
+func migrateLegacySpecification(db *gorm.DB) error {
+	m := db.Migrator()
+	hasBattery := m.HasColumn(&models.Specification{}, "battery")
+	hasCamera := m.HasColumn(&models.Specification{}, "camera")
+	// kolom lama sudah dihapus, migrasi sudah pernah dijalankan
+	if !hasBattery && !hasCamera {
+		return nil
+	}
+
+	// battery_mah 0 berarti tidak diketahui
+	if err := db.Model(&models.Specification{}).Where("battery_mah = ?", 0).
+		UpdateColumn("battery_mah", nil).Error; err != nil {
+		return err
+	}
+
+	selects := []string{"id", "network"}
+	if hasBattery {
+		selects = append(selects, "battery")
+	}
+	if hasCamera {
+		selects = append(selects, "camera")
+	}
+
+	var rows []legacySpecification
+	if err := db.Table("specifications").Select(selects).Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			updates := map[string]any{}
+			if hasBattery {
+				// teks yg tidak bisa di-parse disimpan null (tidak diketahui)
+				updates["battery_mah"] = nil
+				if battery := utils.ParseBatteryMah(row.Battery); battery > 0 {
+					updates["battery_mah"] = battery
+				}
+				updates["charging_watt"] = utils.ParseChargingWatt(row.Battery)
+			}
+			// network yg tidak dikenali dibiarkan apa adanya
+			if gen := utils.ParseNetworkGeneration(row.Network); gen != "" {
+				updates["network"] = gen
+			}
+			if len(updates) > 0 {
+				if err := tx.Table("specifications").Where("id = ?", row.ID).Updates(updates).Error; err != nil {
+					return err
+				}
+			}
+
+			// camera lama = resolusi kamera utama belakang (MP)
+			if row.Camera > 0 {
+				if err := tx.Create(&models.CameraModule{
+					Position:        "rear",
+					Role:            "main",
+					ResolutionMP:    float64(row.Camera),
+					SpecificationID: row.ID,
+				}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		// kolom lama NOT NULL, harus dihapus agar insert specification baru tidak gagal
+		if hasBattery {
+			if err := tx.Migrator().DropColumn(&models.Specification{}, "battery"); err != nil {
+				return err
+			}
+		}
+		if hasCamera {
+			if err := tx.Migrator().DropColumn(&models.Specification{}, "camera"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
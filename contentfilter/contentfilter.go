@@ -0,0 +1,286 @@
+package contentfilter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"final-project/models"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// konten ditolak, data tidak disimpan
+	ActionReject = "reject"
+	// bagian yg cocok diganti *
+	ActionMask = "mask"
+	// konten disimpan dgn status pending & masuk antrian moderasi
+	ActionModerate = "moderate"
+
+	KindReview  = "review"
+	KindComment = "comment"
+)
+
+// Actions semua aksi rule yg valid
+var Actions = []string{ActionReject, ActionMask, ActionModerate}
+
+// urutan aksi terberat, dipakai jika beberapa rule cocok sekaligus
+var severity = map[string]int{ActionMask: 1, ActionModerate: 2, ActionReject: 3}
+
+var (
+	ErrUnknownRule   = errors.New("rule filter tidak dikenal")
+	ErrInvalidAction = errors.New("action harus reject, mask atau moderate")
+	ErrNotMaskable   = errors.New("rule ini tidak bisa memakai action mask")
+)
+
+// Input konten yg diperiksa
+type Input struct {
+	Kind string
+	// id data yg sedang diupdate, 0 untuk data baru
+	ID      uint
+	UserID  uint
+	Content string
+}
+
+// Span posisi (byte) bagian konten yg cocok dgn rule
+type Span struct {
+	Start int
+	End   int
+}
+
+// Rule satu pemeriksaan dalam pipeline, rule baru ditambahkan dgn Register
+type Rule struct {
+	Key           string
+	Description   string
+	DefaultAction string
+	// kode alasan moderasi (models.ModerationReasons) untuk action moderate
+	Reason string
+	// rule tanpa span (contoh duplicate) tidak bisa di-mask
+	Maskable bool
+	Check    func(db *gorm.DB, input Input) (matched bool, spans []Span, err error)
+}
+
+// RuleSetting pengaturan rule yg berlaku (default atau dari admin)
+type RuleSetting struct {
+	Key           string `json:"key"`
+	Description   string `json:"description"`
+	Enabled       bool   `json:"enabled"`
+	Action        string `json:"action"`
+	DefaultAction string `json:"default_action"`
+	Reason        string `json:"reason"`
+	Maskable      bool   `json:"maskable"`
+}
+
+// Match rule yg cocok dgn konten
+type Match struct {
+	Rule   string `json:"rule"`
+	Action string `json:"action"`
+}
+
+// Result hasil pipeline, Action kosong jika konten lolos semua rule
+type Result struct {
+	Content string  `json:"content"`
+	Action  string  `json:"action"`
+	Reason  string  `json:"reason"`
+	Matches []Match `json:"matches"`
+	// hash konten asli untuk deteksi duplikat, disimpan bersama data
+	ContentHash string `json:"-"`
+}
+
+var (
+	mu    sync.RWMutex
+	rules = []Rule{profanityRule, linkRule, phoneNumberRule, duplicateRule}
+)
+
+// Register tambahkan rule ke pipeline, rule dgn key yg sama diganti
+func Register(rule Rule) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i := range rules {
+		if rules[i].Key == rule.Key {
+			rules[i] = rule
+			return
+		}
+	}
+	rules = append(rules, rule)
+}
+
+func registeredRules() []Rule {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]Rule{}, rules...)
+}
+
+// Settings pengaturan semua rule, rule yg belum diatur admin memakai default
+func Settings(db *gorm.DB) ([]RuleSetting, error) {
+	var saved []models.ContentFilterRule
+	if err := db.Find(&saved).Error; err != nil {
+		return nil, err
+	}
+	byKey := map[string]models.ContentFilterRule{}
+	for _, rule := range saved {
+		byKey[rule.Key] = rule
+	}
+
+	var settings []RuleSetting
+	for _, rule := range registeredRules() {
+		setting := RuleSetting{
+			Key:           rule.Key,
+			Description:   rule.Description,
+			Enabled:       true,
+			Action:        rule.DefaultAction,
+			DefaultAction: rule.DefaultAction,
+			Reason:        rule.Reason,
+			Maskable:      rule.Maskable,
+		}
+		if row, ok := byKey[rule.Key]; ok {
+			setting.Enabled = row.Enabled
+			setting.Action = row.Action
+		}
+		settings = append(settings, setting)
+	}
+	return settings, nil
+}
+
+// UpdateRule ubah pengaturan rule, enabled nil / action kosong tidak diubah
+func UpdateRule(db *gorm.DB, key string, enabled *bool, action string) (RuleSetting, error) {
+	settings, err := Settings(db)
+	if err != nil {
+		return RuleSetting{}, err
+	}
+
+	for _, setting := range settings {
+		if setting.Key != key {
+			continue
+		}
+		if enabled != nil {
+			setting.Enabled = *enabled
+		}
+		if action != "" {
+			if _, ok := severity[action]; !ok {
+				return setting, ErrInvalidAction
+			}
+			if action == ActionMask && !setting.Maskable {
+				return setting, ErrNotMaskable
+			}
+			setting.Action = action
+		}
+
+		row := models.ContentFilterRule{Key: key}
+		err := db.Where(models.ContentFilterRule{Key: key}).
+			Assign(map[string]any{"enabled": setting.Enabled, "action": setting.Action}).
+			FirstOrCreate(&row).Error
+		return setting, err
+	}
+	return RuleSetting{}, ErrUnknownRule
+}
+
+// Run jalankan semua rule aktif terhadap konten
+func Run(db *gorm.DB, input Input) (Result, error) {
+	result := Result{Content: input.Content, ContentHash: Hash(input.Content)}
+
+	settings, err := Settings(db)
+	if err != nil {
+		return result, err
+	}
+	enabled := map[string]RuleSetting{}
+	for _, setting := range settings {
+		if setting.Enabled {
+			enabled[setting.Key] = setting
+		}
+	}
+
+	var masks []Span
+	for _, rule := range registeredRules() {
+		setting, ok := enabled[rule.Key]
+		if !ok {
+			continue
+		}
+		matched, spans, err := rule.Check(db, input)
+		if err != nil {
+			return result, err
+		}
+		if !matched {
+			continue
+		}
+
+		result.Matches = append(result.Matches, Match{Rule: rule.Key, Action: setting.Action})
+		if setting.Action == ActionMask {
+			masks = append(masks, spans...)
+		}
+		if severity[setting.Action] > severity[result.Action] {
+			result.Action = setting.Action
+			result.Reason = rule.Reason
+		}
+	}
+
+	result.Content = mask(input.Content, masks)
+	return result, nil
+}
+
+// Note catatan moderasi otomatis berisi rule yg cocok
+func (r Result) Note() string {
+	keys := make([]string, 0, len(r.Matches))
+	for _, match := range r.Matches {
+		keys = append(keys, match.Rule)
+	}
+	return "filter otomatis: " + strings.Join(keys, ", ")
+}
+
+// mask ganti tiap huruf pada span dgn *
+func mask(content string, spans []Span) string {
+	if len(spans) == 0 {
+		return content
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Start < spans[j].Start })
+
+	var out strings.Builder
+	last := 0
+	for _, span := range spans {
+		if span.End <= last {
+			continue
+		}
+		if span.Start < last {
+			span.Start = last
+		}
+		out.WriteString(content[last:span.Start])
+		for _, r := range content[span.Start:span.End] {
+			if unicode.IsSpace(r) {
+				out.WriteRune(r)
+			} else {
+				out.WriteByte('*')
+			}
+		}
+		last = span.End
+	}
+	out.WriteString(content[last:])
+	return out.String()
+}
+
+// minDuplicateLength konten pendek (contoh "setuju") wajar sama, tidak dianggap duplikat
+const minDuplicateLength = 20
+
+// normalize huruf kecil, tanda baca dihapus & spasi disatukan
+func normalize(content string) string {
+	fields := strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// Hash hash konten yg dinormalisasi, kosong untuk konten yg terlalu pendek
+// untuk dianggap duplikat
+func Hash(content string) string {
+	normalized := normalize(content)
+	if utf8.RuneCountInString(normalized) < minDuplicateLength {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,283 @@
+package contentfilter
+
+import (
+	"final-project/models"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+var profanityRule = Rule{
+	Key:           "profanity",
+	Description:   "kata kasar bahasa Indonesia & Inggris, termasuk leetspeak (4nj1ng), huruf berulang (fuuuck) & huruf yg dipisah (f u c k)",
+	DefaultAction: ActionMask,
+	Reason:        "abusive",
+	Maskable:      true,
+	Check:         checkProfanity,
+}
+
+var linkRule = Rule{
+	Key:           "link",
+	Description:   "url & nama domain, termasuk yg disamarkan (contoh toko dot com)",
+	DefaultAction: ActionModerate,
+	Reason:        "spam",
+	Maskable:      true,
+	Check:         checkPattern(linkPattern, nil),
+}
+
+var phoneNumberRule = Rule{
+	Key:           "phone_number",
+	Description:   "nomor telepon / whatsapp (10 - 15 digit)",
+	DefaultAction: ActionModerate,
+	Reason:        "spam",
+	Maskable:      true,
+	Check:         checkPhoneNumber,
+}
+
+var duplicateRule = Rule{
+	Key:           "duplicate",
+	Description:   "konten yg sama persis (setelah dinormalisasi) dgn review / komentar lain",
+	DefaultAction: ActionModerate,
+	Reason:        "duplicate",
+	Check:         checkDuplicate,
+}
+
+var (
+	linkPattern = regexp.MustCompile(`(?i)(?:https?://|www\.)\S+` +
+		`|\b[a-z0-9][a-z0-9-]*(?:\.[a-z0-9-]+)*\.(?:com|net|org|id|co|io|me|ly|gg|xyz|info|biz|link|site|online|shop|store|click|top)\b(?:/\S*)?` +
+		`|\b[a-z0-9-]+\s*(?:\(dot\)|\[dot\]|\s+dot\s+)\s*(?:com|net|org|id)\b`)
+	phoneNumberPattern = regexp.MustCompile(`\+?\d[\d\s().-]{7,}\d`)
+	// " - " memisahkan dua angka (contoh rentang harga), bukan bagian nomor telepon
+	phoneNumberSeparator = regexp.MustCompile(`\s+-\s*|\s*-\s+`)
+	// lebih dari satu kelompok ribuan (3.999.000) berarti harga
+	thousandsPattern = regexp.MustCompile(`\d\.\d{3}\.\d{3}(?:\D|$)`)
+)
+
+// checkPattern rule berbasis regex, valid (opsional) menyaring hasil regex
+func checkPattern(pattern *regexp.Regexp, valid func(string) bool) func(*gorm.DB, Input) (bool, []Span, error) {
+	return func(db *gorm.DB, input Input) (bool, []Span, error) {
+		var spans []Span
+		for _, loc := range pattern.FindAllStringIndex(input.Content, -1) {
+			if valid != nil && !valid(input.Content[loc[0]:loc[1]]) {
+				continue
+			}
+			spans = append(spans, Span{Start: loc[0], End: loc[1]})
+		}
+		return len(spans) > 0, spans, nil
+	}
+}
+
+// checkPhoneNumber nomor telepon dlm konten, hasil regex dipecah di setiap
+// " - " agar rentang harga (Rp 3.999.000 - 4.500.000) tidak terbaca sebagai
+// satu nomor telepon
+func checkPhoneNumber(db *gorm.DB, input Input) (bool, []Span, error) {
+	spans := findPhoneNumbers(input.Content)
+	return len(spans) > 0, spans, nil
+}
+
+func findPhoneNumbers(content string) []Span {
+	var spans []Span
+	for _, loc := range phoneNumberPattern.FindAllStringIndex(content, -1) {
+		match := content[loc[0]:loc[1]]
+		separators := append(phoneNumberSeparator.FindAllStringIndex(match, -1), []int{len(match), len(match)})
+
+		start := 0
+		for _, separator := range separators {
+			part := match[start:separator[0]]
+			for _, partLoc := range phoneNumberPattern.FindAllStringIndex(part, -1) {
+				if isPhoneNumber(part[partLoc[0]:partLoc[1]]) {
+					spans = append(spans, Span{Start: loc[0] + start + partLoc[0], End: loc[0] + start + partLoc[1]})
+				}
+			}
+			start = separator[1]
+		}
+	}
+	return spans
+}
+
+// isPhoneNumber harga (Rp 3.999.000) & angka spesifikasi tidak sepanjang nomor telepon
+func isPhoneNumber(text string) bool {
+	if thousandsPattern.MatchString(text) {
+		return false
+	}
+	digits := 0
+	for _, r := range text {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	return digits >= 10 && digits <= 15
+}
+
+func checkDuplicate(db *gorm.DB, input Input) (bool, []Span, error) {
+	hash := Hash(input.Content)
+	if hash == "" {
+		return false, nil, nil
+	}
+
+	for kind, model := range map[string]any{KindReview: &models.Review{}, KindComment: &models.Comment{}} {
+		query := db.Model(model).Where("content_hash = ?", hash)
+		if kind == input.Kind && input.ID != 0 {
+			query = query.Where("id <> ?", input.ID)
+		}
+		var count int64
+		if err := query.Count(&count).Error; err != nil {
+			return false, nil, err
+		}
+		if count > 0 {
+			return true, nil, nil
+		}
+	}
+	return false, nil, nil
+}
+
+type token struct {
+	Span
+	runes []rune
+}
+
+// wordMatcher kata persis & awalan kata (akhiran *) yg sudah dinormalisasi
+type wordMatcher struct {
+	exact    map[string]bool
+	prefixes []string
+}
+
+func newWordMatcher(words []string) wordMatcher {
+	matcher := wordMatcher{exact: map[string]bool{}}
+	for _, word := range words {
+		prefix := strings.HasSuffix(word, "*")
+		word = normalizeWord([]rune(strings.TrimSuffix(strings.TrimSpace(word), "*")))
+		if word == "" {
+			continue
+		}
+		if prefix {
+			matcher.prefixes = append(matcher.prefixes, word)
+		} else {
+			matcher.exact[word] = true
+		}
+	}
+	return matcher
+}
+
+func (m wordMatcher) match(word string) bool {
+	if word == "" {
+		return false
+	}
+	if m.exact[word] {
+		return true
+	}
+	for _, prefix := range m.prefixes {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkProfanity(db *gorm.DB, input Input) (bool, []Span, error) {
+	var custom []string
+	if err := db.Model(&models.ContentFilterWord{}).Pluck("word", &custom).Error; err != nil {
+		return false, nil, err
+	}
+	words := append([]string{}, custom...)
+	for _, list := range wordlists {
+		words = append(words, list...)
+	}
+	spans := findProfanity(newWordMatcher(words), input.Content)
+	return len(spans) > 0, spans, nil
+}
+
+// findProfanity posisi kata dalam konten yg cocok dgn matcher
+func findProfanity(matcher wordMatcher, content string) []Span {
+	var spans []Span
+	for _, tok := range candidateTokens(content) {
+		if matcher.match(normalizeWord(tok.runes)) || matcher.match(normalizeWord(trimSymbols(tok.runes))) {
+			spans = append(spans, tok.Span)
+		}
+	}
+	return spans
+}
+
+// candidateTokens kata dalam konten, ditambah gabungan huruf tunggal yg
+// dipisah spasi / tanda baca (f u c k, f.u.c.k)
+func candidateTokens(content string) []token {
+	var tokens []token
+	current := token{Span: Span{Start: -1}}
+	flush := func(end int) {
+		if current.Start >= 0 {
+			current.End = end
+			tokens = append(tokens, current)
+		}
+		current = token{Span: Span{Start: -1}}
+	}
+	for i, r := range content {
+		_, isLeet := leet[r]
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || isLeet {
+			if current.Start < 0 {
+				current.Start = i
+			}
+			current.runes = append(current.runes, r)
+			continue
+		}
+		flush(i)
+	}
+	flush(len(content))
+
+	candidates := append([]token{}, tokens...)
+	for i := 0; i < len(tokens); {
+		j := i
+		for j < len(tokens) && len(tokens[j].runes) == 1 {
+			j++
+		}
+		if j-i >= 3 {
+			joined := token{Span: Span{Start: tokens[i].Start, End: tokens[j-1].End}}
+			for _, tok := range tokens[i:j] {
+				joined.runes = append(joined.runes, tok.runes...)
+			}
+			candidates = append(candidates, joined)
+		}
+		if j == i {
+			j++
+		}
+		i = j
+	}
+	return candidates
+}
+
+// normalizeWord huruf kecil, leetspeak diganti huruf & huruf berulang disatukan.
+// kata tanpa huruf sama sekali (angka) diabaikan
+func normalizeWord(runes []rune) string {
+	var out []rune
+	hasLetter := false
+	for _, r := range runes {
+		r = unicode.ToLower(r)
+		if unicode.IsLetter(r) {
+			hasLetter = true
+		} else if mapped, ok := leet[r]; ok {
+			r = mapped
+		}
+		if len(out) > 0 && out[len(out)-1] == r {
+			continue
+		}
+		out = append(out, r)
+	}
+	if !hasLetter {
+		return ""
+	}
+	return string(out)
+}
+
+// trimSymbols hapus simbol leetspeak di awal / akhir kata yg sebenarnya tanda
+// baca (contoh "anjing!")
+func trimSymbols(runes []rune) []rune {
+	isSymbol := func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }
+	for len(runes) > 0 && isSymbol(runes[0]) {
+		runes = runes[1:]
+	}
+	for len(runes) > 0 && isSymbol(runes[len(runes)-1]) {
+		runes = runes[:len(runes)-1]
+	}
+	return runes
+}
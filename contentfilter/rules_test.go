@@ -0,0 +1,153 @@
+package contentfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindPhoneNumbers(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"rentang harga", "harganya Rp 3.999.000 - 4.500.000 worth it", nil},
+		{"rentang harga tanpa Rp", "kisaran 12.999.000 - 13.499.000", nil},
+		{"rentang harga tanpa spasi", "Rp3.999.000-Rp4.500.000", nil},
+		{"dua harga dipisah spasi", "turun dari 1.500.000 2.000.000", nil},
+		{"harga satu kelompok ribuan", "cuma 999.000 aja", nil},
+		{"angka spesifikasi", "RAM 8GB, storage 256GB, baterai 5000 mAh, 120Hz", nil},
+		{"nomor hp dgn strip", "wa aja 0812-3456-7890 ya", []string{"0812-3456-7890"}},
+		{"nomor hp internasional", "hubungi +62 812 3456 7890", []string{"+62 812 3456 7890"}},
+		{"nomor hp tanpa pemisah", "WA 081234567890", []string{"081234567890"}},
+		{"nomor telepon kantor", "telp (021) 555-1234", []string{"021) 555-1234"}},
+		{"nomor hp dgn titik", "0812.3456.7890", []string{"0812.3456.7890"}},
+		{"harga lalu nomor hp", "Rp 3.999.000 - 0812 3456 7890", []string{"0812 3456 7890"}},
+		{"terlalu panjang", "1234567890123456789", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, span := range findPhoneNumbers(tt.content) {
+				got = append(got, tt.content[span.Start:span.End])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findPhoneNumbers(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkPattern(t *testing.T) {
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{"beli di https://toko.example/hp", true},
+		{"cek www.tokohp.com", true},
+		{"tokohp.id murah", true},
+		{"tokohp dot com", true},
+		{"versi 2.0 lebih baik", false},
+		{"layar 6.7 inch", false},
+	}
+	for _, tt := range tests {
+		if got := linkPattern.MatchString(tt.content); got != tt.want {
+			t.Errorf("linkPattern.MatchString(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestFindProfanity(t *testing.T) {
+	words := []string{"kampret*"}
+	for _, list := range wordlists {
+		words = append(words, list...)
+	}
+	matcher := newWordMatcher(words)
+
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"kata kasar", "hp ini tolol banget", []string{"tolol"}},
+		{"huruf besar", "BANGSAT lemot", []string{"BANGSAT"}},
+		{"huruf berulang", "anjiiiing kameranya", []string{"anjiiiing"}},
+		{"leetspeak", "sh1t baterainya b0d0h", []string{"sh1t"}},
+		{"tanda baca di akhir", "anjing! panas banget", []string{"anjing!"}},
+		{"awalan kata", "fucking slow", []string{"fucking"}},
+		{"awalan kata tambahan admin", "kampretlah", []string{"kampretlah"}},
+		{"huruf dipisah spasi", "f u c k ini hp", []string{"f u c k"}},
+		{"huruf dipisah titik", "dasar t.o.l.o.l", []string{"t.o.l.o.l"}},
+		{"bagian kata lain", "pantai, kasur, asumsi", nil},
+		{"angka saja", "harga 4500000", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, span := range findProfanity(matcher, tt.content) {
+				got = append(got, tt.content[span.Start:span.End])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findProfanity(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeWord(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"Anjing", "anjing"},
+		{"b4ngs4t", "bangsat"},
+		{"$h!t", "shit"},
+		{"gooooblok", "goblok"},
+		{"5000", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeWord([]rune(tt.word)); got != tt.want {
+			t.Errorf("normalizeWord(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		spans   []Span
+		want    string
+	}{
+		{"tanpa span", "hp bagus", nil, "hp bagus"},
+		{"satu kata", "hp tolol", []Span{{Start: 3, End: 8}}, "hp *****"},
+		{"spasi tetap", "f u c k hp", []Span{{Start: 0, End: 7}}, "* * * * hp"},
+		{"span tumpang tindih", "abcdef", []Span{{Start: 2, End: 5}, {Start: 0, End: 3}}, "*****f"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mask(tt.content, tt.spans); got != tt.want {
+				t.Errorf("mask() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	long := "Kameranya bagus banget, baterai awet seharian!"
+	if Hash("setuju") != "" {
+		t.Error("konten pendek tidak boleh di-hash")
+	}
+	if Hash(long) == "" {
+		t.Fatal("konten panjang harus di-hash")
+	}
+	if Hash(long) != Hash("kameranya BAGUS banget  baterai awet seharian") {
+		t.Error("hash harus mengabaikan huruf besar, tanda baca & spasi")
+	}
+	if Hash(long) == Hash("Kameranya jelek banget, baterai boros seharian!") {
+		t.Error("konten berbeda tidak boleh punya hash sama")
+	}
+}
@@ -0,0 +1,31 @@
+package contentfilter
+
+const (
+	LanguageIndonesian = "id"
+	LanguageEnglish    = "en"
+)
+
+// Languages bahasa wordlist yg valid
+var Languages = []string{LanguageIndonesian, LanguageEnglish}
+
+// wordlist bawaan, akhiran * mencocokkan awalan kata. kata tambahan dikelola
+// admin di tabel content_filter_words
+var wordlists = map[string][]string{
+	LanguageIndonesian: {
+		"anjing", "anjir", "asu", "babi", "bajingan", "bangsat", "bego",
+		"brengsek", "goblok*", "jancok", "jancuk", "kampret", "keparat", "kontol",
+		"lonte", "memek", "ngentot*", "ngewe", "pantek", "pelacur", "pepek",
+		"perek", "sialan", "tai", "taik", "tolol",
+	},
+	LanguageEnglish: {
+		"asshole*", "bastard*", "bitch*", "bullshit", "cunt*", "dickhead",
+		"fuck*", "motherfuck*", "pussy", "shit*", "slut*", "twat", "wanker",
+		"whore*",
+	},
+}
+
+// leetspeak yg dinormalisasi ke huruf sebelum dicocokkan
+var leet = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '9': 'g',
+	'@': 'a', '$': 's', '!': 'i', '|': 'i', '+': 't',
+}
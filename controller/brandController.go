@@ -3,9 +3,11 @@ package controller
 import (
 	"final-project/lib"
 	"final-project/models"
+	"final-project/slug"
 	"final-project/utils"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,12 +19,16 @@ type brandInput struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
 	LogoUrl     string `json:"logo_url" binding:"required"`
+	// id brand induk (opsional)
+	ParentID *uint `json:"parent_id"`
 }
 
 type brandUpdate struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	LogoUrl     string `json:"logo_url"`
+	// 0 untuk melepas brand dari induknya
+	ParentID *uint `json:"parent_id"`
 }
 
 // Get all phone brands
@@ -55,7 +61,7 @@ func GetAllBrandData(c *gin.Context) {
 		query.Order("id ASC")
 	}
 
-	err := query.Select("id", "logo_url", "name", "description", "created_at", "updated_at").Find(&brands_data).Error
+	err := query.Select("id", "logo_url", "name", "slug", "description", "parent_id", "created_at", "updated_at").Find(&brands_data).Error
 	if err != nil {
 		emptydata := make([]string, 0)
 		c.JSON(http.StatusInternalServerError,
@@ -83,7 +89,7 @@ func GetAllBrandData(c *gin.Context) {
 // @Tags Brands
 // @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
 // @Security BearerToken
-// @Param Body body brandInput true "example JSON body to create a new Brand"
+// @Param Body body brandInput true "example JSON body to create a new Brand, parent_id is optional (e.g. Redmi under Xiaomi)"
 // @Produce json
 // @Success 200 {object} models.Brand
 // @Router /brands [post]
@@ -121,12 +127,27 @@ func CreateBrand(c *gin.Context) {
 		return
 	}
 
+	brandSlug, err := slug.Generate(db, slug.KindBrand, input.Name, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
 	brand_data := models.Brand{
 		Name:        input.Name,
+		Slug:        brandSlug,
 		Description: input.Description,
 		LogoURL:     input.LogoUrl,
 	}
 
+	if input.ParentID != nil && *input.ParentID != 0 {
+		if !isBrandParentValid(c, db, 0, *input.ParentID) {
+			return
+		}
+		brand_data.ParentID = input.ParentID
+	}
+
 	if err := db.Create(&brand_data).Error; err != nil {
 		c.JSON(http.StatusInternalServerError,
 			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
@@ -138,10 +159,12 @@ func CreateBrand(c *gin.Context) {
 
 // Get phones data by Brand data ID godoc
 // @Summary Get phones data by Brand id. (PUBLIC)
-// @Description Get all Phones data by brand id.
+// @Description Get all Phones data by brand id. draft phones are only listed for admin
 // @Tags Brands
 // @Produce json
-// @Param id path string true "Brand id"
+// @Param id path string true "Brand id or slug"
+// @Param status query string false "comma separated phone statuses"
+// @Param include_sub_brands query bool false "also list phones of all sub brands (e.g. Redmi & POCO phones for Xiaomi)"
 // @Success 200 {object} []models.Brand
 // @Router /brands/{id}/phones [get]
 func GetPhonesDataByBrandId(c *gin.Context) {
@@ -149,14 +172,49 @@ func GetPhonesDataByBrandId(c *gin.Context) {
 
 	db := c.MustGet("db").(*gorm.DB)
 
+	// validasi filter status sebelum query
+	if _, err := applyPhoneStatusFilter(c, db); err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+	phonesFilter := func(tx *gorm.DB) *gorm.DB {
+		tx, _ = applyPhoneStatusFilter(c, visiblePhones(c, tx))
+		return tx
+	}
+
+	includeSubBrands, _ := strconv.ParseBool(c.DefaultQuery("include_sub_brands", "false"))
+
+	query := db
+	if !includeSubBrands {
+		query = query.Preload("Phones", phonesFilter)
+	}
+
 	id := c.Param("id")
-	if err := db.Preload("Phones").Find(&brands, id).Error; err != nil {
+	if err := query.Find(&brands, id).Error; err != nil {
 		fmt.Println(err.Error())
 		c.JSON(http.StatusNotFound,
 			utils.ResponseJSON(lib.ErrMsgNotFound("brand"), http.StatusNotFound, nil))
 		return
 	}
 
+	// phone brand & seluruh sub brand-nya
+	if includeSubBrands && len(brands) > 0 {
+		brandIDs, err := brandWithSubBrandIDs(db, brands[0].ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+		if err := phonesFilter(db.Where("phones.brand_id IN ?", brandIDs)).
+			Order("phones.id ASC").
+			Find(&brands[0].Phones).Error; err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, brands))
 }
 
@@ -165,7 +223,7 @@ func GetPhonesDataByBrandId(c *gin.Context) {
 // @Description Get Brand data by ID.
 // @Tags Brands
 // @Produce json
-// @Param id path string true "Brand id"
+// @Param id path string true "Brand id or slug"
 // @Success 200 {object} []models.Brand
 // @Router /brands/{id} [get]
 func GetBrandById(c *gin.Context) {
@@ -174,7 +232,7 @@ func GetBrandById(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
 	brandID := c.Param("id")
 
-	if err := db.Select("id", "logo_url", "name", "description", "created_at", "updated_at").First(&brands_data, brandID).Error; err != nil {
+	if err := db.Select("id", "logo_url", "name", "slug", "description", "parent_id", "created_at", "updated_at").First(&brands_data, brandID).Error; err != nil {
 		c.JSON(http.StatusNotFound,
 			utils.ResponseJSON(lib.ErrMsgNotFound("brand"), http.StatusNotFound, nil))
 		return
@@ -198,7 +256,7 @@ func GetBrandById(c *gin.Context) {
 // @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
 // @Security BearerToken
 // @Param id path string true "Brand id"
-// @Param Body body brandInput true "tExample JSON body to update Brand data"
+// @Param Body body brandUpdate true "tExample JSON body to update Brand data, parent_id 0 removes the parent brand"
 // @Success 200 {object} models.Brand
 // @Router /brands/{id} [put]
 func UpdateBrand(c *gin.Context) {
@@ -250,15 +308,44 @@ func UpdateBrand(c *gin.Context) {
 		return
 	}
 
+	if input.ParentID != nil && *input.ParentID != 0 && !isBrandParentValid(c, db, brand.ID, *input.ParentID) {
+		return
+	}
+
+	// slug dibuat ulang jika nama diganti, slug lama tetap di-redirect
+	if input.Name != "" && input.Name != brand.Name {
+		newSlug, err := slug.Generate(db, slug.KindBrand, input.Name, brand.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+		if err := slug.Rename(db, slug.KindBrand, brand.ID, brand.Slug, newSlug); err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+		updated_data.Slug = newSlug
+	}
+
 	// update ke tabel
 	db.Model(&brand).Updates(updated_data)
 
+	// parent_id diupdate terpisah karena nilai 0 / null diabaikan Updates
+	if input.ParentID != nil {
+		var parentID *uint
+		if *input.ParentID != 0 {
+			parentID = input.ParentID
+		}
+		db.Model(&brand).Update("parent_id", parentID)
+	}
+
 	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("brand"), http.StatusOK, brand))
 }
 
 // Delete Brand by id  godoc
 // @Summary Delete Brand by id . (ADMIN ONLY)
-// @Description Delete a Brand by id
+// @Description Delete a Brand by id, its sub brands are moved to the deleted brand's parent
 // @Tags Brands
 // @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
 // @Security BearerToken
@@ -276,7 +363,19 @@ func DeleteBrandByID(c *gin.Context) {
 		return
 	}
 
-	if err := db.Delete(&brand_data).Error; err != nil {
+	// sub brand dipindah ke induk brand yg dihapus
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Brand{}).
+			Where("parent_id = ?", brand_data.ID).
+			Update("parent_id", brand_data.ParentID).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&brand_data).Error; err != nil {
+			return err
+		}
+		return slug.Forget(tx, slug.KindBrand, brand_data.ID)
+	})
+	if err != nil {
 		if strings.Contains(err.Error(), "violates foreign key constraint") {
 			errmsg := fmt.Sprintf("brand %s tidak bisa dihapus karena sudah terkait dengan data phone", brand_data.Name)
 			c.JSON(http.StatusBadRequest,
@@ -319,3 +418,40 @@ func isBrandDataInputValid(c *gin.Context, data brandInput) bool {
 
 	return true
 }
+
+// isBrandParentValid cek brand induk ada & tidak membuat hierarki berputar
+// (brand menjadi induk dari brand induknya sendiri)
+func isBrandParentValid(c *gin.Context, db *gorm.DB, brandID uint, parentID uint) bool {
+	if parentID == brandID {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("brand tidak bisa menjadi induk dirinya sendiri", http.StatusBadRequest, nil))
+		return false
+	}
+
+	var parent models.Brand
+	if err := db.Select("id", "parent_id").Where("id = ?", parentID).First(&parent).Error; err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.ErrMsgNotFound("parent brand"), http.StatusBadRequest, nil))
+		return false
+	}
+
+	if brandID == 0 {
+		return true
+	}
+
+	parents, err := brandParents(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return false
+	}
+	for id, depth := parentID, 0; id != 0 && depth <= len(parents); id, depth = parents[id], depth+1 {
+		if id == brandID {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON("brand induk tidak boleh merupakan sub brand dari brand ini", http.StatusBadRequest, nil))
+			return false
+		}
+	}
+
+	return true
+}
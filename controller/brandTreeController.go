@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type BrandStats struct {
+	PhoneCount  int64   `json:"phone_count"`
+	ReviewCount int64   `json:"review_count"`
+	AVGRating   float64 `json:"avg_rating"`
+	ratingSum   float64
+}
+
+type BrandTreeNode struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	LogoURL  string `json:"logo_url"`
+	ParentID *uint  `json:"parent_id"`
+	// statistik phone milik brand ini saja
+	Stats BrandStats `json:"stats"`
+	// statistik brand ini ditambah seluruh sub brand di bawahnya
+	TotalStats BrandStats      `json:"total_stats"`
+	SubBrands  []BrandTreeNode `json:"sub_brands"`
+}
+
+// Get brand tree godoc
+// @Summary Get brand hierarchy. (PUBLIC)
+// @Description Get all brands as a tree of parent brands and their sub brands (e.g. Xiaomi -> Redmi, POCO). stats only counts the brand's own phones, total_stats rolls up the brand and all of its sub brands, avg_rating is weighted by review count
+// @Tags Brands
+// @Produce json
+// @Success 200 {object} []BrandTreeNode
+// @Router /brands/tree [get]
+func GetBrandTree(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	roots, _, err := loadBrandTree(c, db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, roots))
+}
+
+// Get brand subtree godoc
+// @Summary Get a brand with its sub brands. (PUBLIC)
+// @Description Get a brand, its sub brands (recursively) and the rolled up stats of the whole subtree
+// @Tags Brands
+// @Produce json
+// @Param id path string true "Brand id or slug"
+// @Success 200 {object} BrandTreeNode
+// @Router /brands/{id}/tree [get]
+func GetBrandSubtree(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	_, nodes, err := loadBrandTree(c, db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	node, ok := nodes[uint(id)]
+	if !ok {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("brand"), http.StatusNotFound, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, node))
+}
+
+// loadBrandTree susun semua brand menjadi tree beserta statistiknya,
+// mengembalikan brand teratas & node tiap brand berdasarkan id
+func loadBrandTree(c *gin.Context, db *gorm.DB) ([]BrandTreeNode, map[uint]BrandTreeNode, error) {
+	var brands []models.Brand
+	if err := db.Select("id", "name", "logo_url", "parent_id").Order("name ASC").Find(&brands).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var rows []struct {
+		BrandID     uint
+		PhoneCount  int64
+		ReviewCount int64
+		RatingSum   float64
+	}
+	if err := visiblePhones(c, db.Table("phones")).
+		Select(`phones.brand_id,
+				COUNT(DISTINCT phones.id) as phone_count,
+				COUNT(reviews.id) as review_count,
+				COALESCE(SUM(reviews.rating), 0) as rating_sum`).
+		Joins("LEFT JOIN reviews on reviews.phone_id = phones.id AND reviews.status = ?", models.ReviewStatusApproved).
+		Group("phones.brand_id").
+		Scan(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+	stats := map[uint]BrandStats{}
+	for _, row := range rows {
+		stats[row.BrandID] = BrandStats{PhoneCount: row.PhoneCount, ReviewCount: row.ReviewCount, ratingSum: row.RatingSum}
+	}
+
+	exists := map[uint]bool{}
+	for _, brand := range brands {
+		exists[brand.ID] = true
+	}
+	children := map[uint][]models.Brand{}
+	roots := []models.Brand{}
+	for _, brand := range brands {
+		// brand dgn induk yg sudah tidak ada dianggap brand teratas
+		if brand.ParentID == nil || !exists[*brand.ParentID] {
+			roots = append(roots, brand)
+			continue
+		}
+		children[*brand.ParentID] = append(children[*brand.ParentID], brand)
+	}
+
+	nodes := map[uint]BrandTreeNode{}
+	var build func(brand models.Brand) BrandTreeNode
+	build = func(brand models.Brand) BrandTreeNode {
+		node := BrandTreeNode{
+			ID:         brand.ID,
+			Name:       brand.Name,
+			LogoURL:    brand.LogoURL,
+			ParentID:   brand.ParentID,
+			Stats:      stats[brand.ID],
+			TotalStats: stats[brand.ID],
+			SubBrands:  []BrandTreeNode{},
+		}
+		// tandai sebelum rekursi agar data hierarki yg berputar tidak diulang
+		nodes[brand.ID] = node
+		for _, child := range children[brand.ID] {
+			if _, visited := nodes[child.ID]; visited {
+				continue
+			}
+			sub := build(child)
+			node.SubBrands = append(node.SubBrands, sub)
+			node.TotalStats.PhoneCount += sub.TotalStats.PhoneCount
+			node.TotalStats.ReviewCount += sub.TotalStats.ReviewCount
+			node.TotalStats.ratingSum += sub.TotalStats.ratingSum
+		}
+		node.Stats.AVGRating = averageRating(node.Stats)
+		node.TotalStats.AVGRating = averageRating(node.TotalStats)
+		nodes[brand.ID] = node
+		return node
+	}
+
+	tree := []BrandTreeNode{}
+	for _, brand := range roots {
+		tree = append(tree, build(brand))
+	}
+	// brand dlm hierarki yg berputar tidak terjangkau dari brand teratas,
+	// brand pertama tiap putaran ditampilkan sebagai brand teratas
+	for _, brand := range brands {
+		if _, visited := nodes[brand.ID]; !visited {
+			log.Println("hierarki brand berputar pada brand", brand.ID, brand.Name)
+			tree = append(tree, build(brand))
+		}
+	}
+
+	return tree, nodes, nil
+}
+
+// brandParents id brand induk tiap brand, 0 jika tidak punya induk
+func brandParents(db *gorm.DB) (map[uint]uint, error) {
+	var brands []models.Brand
+	if err := db.Select("id", "parent_id").Find(&brands).Error; err != nil {
+		return nil, err
+	}
+
+	parents := map[uint]uint{}
+	for _, brand := range brands {
+		parents[brand.ID] = 0
+		if brand.ParentID != nil {
+			parents[brand.ID] = *brand.ParentID
+		}
+	}
+	return parents, nil
+}
+
+// brandWithSubBrandIDs id brand beserta seluruh sub brand di bawahnya
+func brandWithSubBrandIDs(db *gorm.DB, brandID uint) ([]uint, error) {
+	parents, err := brandParents(db)
+	if err != nil {
+		return nil, err
+	}
+	return subBrandIDs(parents, brandID), nil
+}
+
+// subBrandIDs id brand beserta seluruh sub brand di bawahnya dari id induk
+// tiap brand, brand dlm hierarki yg berputar hanya diambil sekali
+func subBrandIDs(parents map[uint]uint, brandID uint) []uint {
+	children := map[uint][]uint{}
+	for id, parentID := range parents {
+		if parentID != 0 {
+			children[parentID] = append(children[parentID], id)
+		}
+	}
+
+	ids := []uint{brandID}
+	visited := map[uint]bool{brandID: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !visited[child] {
+				visited[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
+
+func averageRating(stats BrandStats) float64 {
+	if stats.ReviewCount == 0 {
+		return 0
+	}
+	return math.Round(stats.ratingSum/float64(stats.ReviewCount)*100) / 100
+}
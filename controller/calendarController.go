@@ -0,0 +1,289 @@
+package controller
+
+import (
+	"final-project/models"
+	"final-project/utils"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	// rentang bawaan kalender (bulan ini + 2 bulan berikutnya)
+	defaultCalendarMonths = 3
+	// rentang maksimal kalender dalam bulan
+	maxCalendarMonths = 24
+	// jumlah bulan ke depan yg masuk feed .ics
+	icsFeedMonths = 12
+)
+
+// release_date sebelum tanggal ini dianggap belum diisi (phone belum punya jadwal rilis)
+var unscheduledReleaseDate = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type CalendarBrand struct {
+	BrandID   int                      `json:"brand_id"`
+	BrandName string                   `json:"brand_name"`
+	Phones    []PhonesCompleteResponse `json:"phones"`
+}
+
+type CalendarMonth struct {
+	// format YYYY-MM
+	Month  string          `json:"month"`
+	Total  int             `json:"total"`
+	Brands []CalendarBrand `json:"brands"`
+}
+
+type CalendarResponse struct {
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Months []CalendarMonth `json:"months"`
+	// phone announced / rumored yg belum punya tanggal rilis
+	Unscheduled []PhonesCompleteResponse `json:"unscheduled"`
+}
+
+// Get release calendar godoc
+// @Summary Get phone release calendar. (PUBLIC)
+// @Description Get phones released (or planned to be released) between from and to, grouped by release month and brand. announced / rumored phones without a release date are listed in unscheduled. default range is the current month and the next 2 months, max 24 months
+// @Tags Phones
+// @Produce json
+// @Param from query string false "start month / date, YYYY-MM or YYYY-MM-DD"
+// @Param to query string false "end month / date (inclusive), YYYY-MM or YYYY-MM-DD"
+// @Param brand_id query int false "only phones of this brand"
+// @Param status query string false "comma separated statuses, e.g. status=rumored,announced for upcoming phones only"
+// @Param region query string false "region code (e.g. ID, MY, SG, US), fills local_price"
+// @Param currency query string false "currency code (e.g. USD), fills local_price"
+// @Success 200 {object} CalendarResponse
+// @Router /phones/calendar [get]
+func GetReleaseCalendar(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, defaultCalendarMonths, 0)
+
+	if value := c.Query("from"); value != "" {
+		start, _, err := parseCalendarDate(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON("from harus berformat YYYY-MM atau YYYY-MM-DD", http.StatusBadRequest, nil))
+			return
+		}
+		from = start
+		to = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.Local).AddDate(0, defaultCalendarMonths, 0)
+	}
+	if value := c.Query("to"); value != "" {
+		_, end, err := parseCalendarDate(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON("to harus berformat YYYY-MM atau YYYY-MM-DD", http.StatusBadRequest, nil))
+			return
+		}
+		to = end
+	}
+
+	if !to.After(from) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("to tidak boleh sebelum from", http.StatusBadRequest, nil))
+		return
+	}
+	if to.After(from.AddDate(0, maxCalendarMonths, 0)) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(fmt.Sprintf("rentang kalender maksimal %d bulan", maxCalendarMonths), http.StatusBadRequest, nil))
+		return
+	}
+
+	region, currency, err := localPriceQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	query, err := calendarQuery(c, visiblePhones(c, phoneCompleteQuery(db)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	var phones []PhonesCompleteResponse
+	if err := query.Session(&gorm.Session{}).
+		Where("phones.release_date >= ? AND phones.release_date < ?", from, to).
+		Order("phones.release_date ASC").
+		Order("brands.name ASC").
+		Order("phones.model ASC").
+		Scan(&phones).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	unscheduled := []PhonesCompleteResponse{}
+	if err := query.Session(&gorm.Session{}).
+		Where("phones.release_date < ?", unscheduledReleaseDate).
+		Where("phones.status IN ?", []string{models.PhoneStatusRumored, models.PhoneStatusAnnounced}).
+		Order("brands.name ASC").
+		Order("phones.model ASC").
+		Scan(&unscheduled).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, list := range [][]PhonesCompleteResponse{phones, unscheduled} {
+		if err := applyLocalPrices(db, list, region, currency); err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+		if err := applyAspectRatings(db, list); err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, CalendarResponse{
+		From:        from.Format("2006-01-02"),
+		To:          to.AddDate(0, 0, -1).Format("2006-01-02"),
+		Months:      groupCalendar(phones),
+		Unscheduled: unscheduled,
+	}))
+}
+
+// Get release calendar feed godoc
+// @Summary Get upcoming phone launches as iCalendar feed. (PUBLIC)
+// @Description iCalendar (.ics) feed of phone launch dates from the start of the current month up to 12 months ahead, one all-day event per phone. the url can be subscribed from calendar apps (Google Calendar, Apple Calendar, Outlook)
+// @Tags Phones
+// @Produce text/calendar
+// @Param brand_id query int false "only phones of this brand"
+// @Param status query string false "comma separated statuses"
+// @Success 200 {string} string "iCalendar feed"
+// @Router /phones/calendar.ics [get]
+func GetReleaseCalendarFeed(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, icsFeedMonths+1, 0)
+
+	// feed dibaca aplikasi kalender tanpa token, phone draft tidak pernah ikut
+	query, err := calendarQuery(c, phoneCompleteQuery(db).Where("phones.status <> ?", models.PhoneStatusDraft))
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	var phones []PhonesCompleteResponse
+	if err := query.
+		Where("phones.release_date >= ? AND phones.release_date < ?", from, to).
+		Order("phones.release_date ASC").
+		Order("phones.id ASC").
+		Scan(&phones).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="phone-releases.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(releaseCalendarICal(phones, c.Request.Host)))
+}
+
+// releaseCalendarICal feed iCalendar satu event sehari penuh per phone, host
+// dipakai sebagai domain UID event
+func releaseCalendarICal(phones []PhonesCompleteResponse, host string) string {
+	var b strings.Builder
+	b.WriteString(utils.ICalLine("BEGIN", "VCALENDAR"))
+	b.WriteString(utils.ICalLine("VERSION", "2.0"))
+	b.WriteString(utils.ICalLine("PRODID", "-//Phone Review API//Release Calendar//EN"))
+	b.WriteString(utils.ICalLine("CALSCALE", "GREGORIAN"))
+	b.WriteString(utils.ICalLine("METHOD", "PUBLISH"))
+	b.WriteString(utils.ICalLine("X-WR-CALNAME", "Phone releases"))
+	for _, phone := range phones {
+		releaseDate := phone.ReleaseDate.In(time.Local)
+		description := fmt.Sprintf("Status: %s\nPrice: %.0f", phone.Status, phone.Price)
+
+		b.WriteString(utils.ICalLine("BEGIN", "VEVENT"))
+		b.WriteString(utils.ICalLine("UID", fmt.Sprintf("phone-%d@%s", phone.PhoneID, host)))
+		b.WriteString(utils.ICalLine("DTSTAMP", phone.UpdatedAt.UTC().Format("20060102T150405Z")))
+		b.WriteString(utils.ICalLine("DTSTART;VALUE=DATE", releaseDate.Format("20060102")))
+		b.WriteString(utils.ICalLine("DTEND;VALUE=DATE", releaseDate.AddDate(0, 0, 1).Format("20060102")))
+		b.WriteString(utils.ICalLine("SUMMARY", utils.ICalText(phone.FullName+" release")))
+		b.WriteString(utils.ICalLine("DESCRIPTION", utils.ICalText(description)))
+		b.WriteString(utils.ICalLine("END", "VEVENT"))
+	}
+	b.WriteString(utils.ICalLine("END", "VCALENDAR"))
+	return b.String()
+}
+
+// calendarQuery filter brand & status untuk kalender rilis
+func calendarQuery(c *gin.Context, query *gorm.DB) (*gorm.DB, error) {
+	if value := c.Query("brand_id"); value != "" {
+		brandID, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("brand_id harus berupa angka")
+		}
+		query = query.Where("phones.brand_id = ?", brandID)
+	}
+
+	return applyPhoneStatusFilter(c, query)
+}
+
+// parseCalendarDate parse YYYY-MM (satu bulan penuh) atau YYYY-MM-DD (satu hari),
+// mengembalikan awal & akhir (eksklusif) periode
+func parseCalendarDate(value string) (time.Time, time.Time, error) {
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date, date.AddDate(0, 0, 1), nil
+	}
+	date, err := time.ParseInLocation("2006-01", value, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return date, date.AddDate(0, 1, 0), nil
+}
+
+// groupCalendar kelompokkan phone (sudah urut tanggal rilis) per bulan lalu per brand
+func groupCalendar(phones []PhonesCompleteResponse) []CalendarMonth {
+	months := []CalendarMonth{}
+	for _, phone := range phones {
+		month := phone.ReleaseDate.In(time.Local).Format("2006-01")
+		if len(months) == 0 || months[len(months)-1].Month != month {
+			months = append(months, CalendarMonth{Month: month, Brands: []CalendarBrand{}})
+		}
+		current := &months[len(months)-1]
+		current.Total++
+
+		found := false
+		for i := range current.Brands {
+			if current.Brands[i].BrandID == phone.BrandID {
+				current.Brands[i].Phones = append(current.Brands[i].Phones, phone)
+				found = true
+				break
+			}
+		}
+		if !found {
+			current.Brands = append(current.Brands, CalendarBrand{
+				BrandID:   phone.BrandID,
+				BrandName: phone.BrandName,
+				Phones:    []PhonesCompleteResponse{phone},
+			})
+		}
+	}
+
+	// brand dalam satu bulan diurutkan berdasarkan nama
+	for i := range months {
+		brands := months[i].Brands
+		slices.SortStableFunc(brands, func(a, b CalendarBrand) int {
+			return strings.Compare(strings.ToLower(a.BrandName), strings.ToLower(b.BrandName))
+		})
+	}
+
+	return months
+}
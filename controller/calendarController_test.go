@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReleaseCalendarICal(t *testing.T) {
+	phones := []PhonesCompleteResponse{{
+		PhoneID:     7,
+		FullName:    "Samsung Galaxy S25, Ultra; 1TB",
+		Price:       21999000,
+		Status:      "announced",
+		ReleaseDate: time.Date(2025, time.January, 31, 0, 0, 0, 0, time.Local),
+		UpdatedAt:   time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC),
+	}}
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Phone Review API//Release Calendar//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Phone releases",
+		"BEGIN:VEVENT",
+		"UID:phone-7@api.example.com",
+		"DTSTAMP:20250102T030405Z",
+		"DTSTART;VALUE=DATE:20250131",
+		// event sehari penuh berakhir esok harinya (eksklusif)
+		"DTEND;VALUE=DATE:20250201",
+		`SUMMARY:Samsung Galaxy S25\, Ultra\; 1TB release`,
+		`DESCRIPTION:Status: announced\nPrice: 21999000`,
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	if got := releaseCalendarICal(phones, "api.example.com"); got != want {
+		t.Errorf("releaseCalendarICal() =\n%q\nwant\n%q", got, want)
+	}
+
+	empty := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Phone Review API//Release Calendar//EN\r\n" +
+		"CALSCALE:GREGORIAN\r\nMETHOD:PUBLISH\r\nX-WR-CALNAME:Phone releases\r\nEND:VCALENDAR\r\n"
+	if got := releaseCalendarICal(nil, "api.example.com"); got != empty {
+		t.Errorf("releaseCalendarICal(nil) = %q, want %q", got, empty)
+	}
+}
+
+func TestParseCalendarDate(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		value    string
+		from, to time.Time
+		wantErr  bool
+	}{
+		{"2024-02", day(2024, time.February, 1), day(2024, time.March, 1), false},
+		{"2024-12", day(2024, time.December, 1), day(2025, time.January, 1), false},
+		{"2024-02-29", day(2024, time.February, 29), day(2024, time.March, 1), false},
+		{"2024-13", time.Time{}, time.Time{}, true},
+		{"Februari 2024", time.Time{}, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		from, to, err := parseCalendarDate(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCalendarDate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !from.Equal(tt.from) || !to.Equal(tt.to) {
+			t.Errorf("parseCalendarDate(%q) = %v, %v, want %v, %v", tt.value, from, to, tt.from, tt.to)
+		}
+	}
+}
+
+func TestGroupCalendar(t *testing.T) {
+	release := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 0, 0, 0, 0, time.Local)
+	}
+	phones := []PhonesCompleteResponse{
+		{PhoneID: 1, BrandID: 2, BrandName: "xiaomi", ReleaseDate: release(time.March, 2)},
+		{PhoneID: 2, BrandID: 1, BrandName: "Samsung", ReleaseDate: release(time.March, 10)},
+		{PhoneID: 3, BrandID: 2, BrandName: "xiaomi", ReleaseDate: release(time.March, 20)},
+		{PhoneID: 4, BrandID: 1, BrandName: "Samsung", ReleaseDate: release(time.May, 1)},
+	}
+
+	type brand struct {
+		Name   string
+		Phones []int
+	}
+	type month struct {
+		Month  string
+		Total  int
+		Brands []brand
+	}
+	want := []month{
+		{"2024-03", 3, []brand{{"Samsung", []int{2}}, {"xiaomi", []int{1, 3}}}},
+		{"2024-05", 1, []brand{{"Samsung", []int{4}}}},
+	}
+
+	var got []month
+	for _, m := range groupCalendar(phones) {
+		gm := month{Month: m.Month, Total: m.Total}
+		for _, b := range m.Brands {
+			gb := brand{Name: b.BrandName}
+			for _, phone := range b.Phones {
+				gb.Phones = append(gb.Phones, phone.PhoneID)
+			}
+			gm.Brands = append(gm.Brands, gb)
+		}
+		got = append(got, gm)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groupCalendar() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,817 @@
+package controller
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"final-project/lib"
+	"final-project/models"
+	"final-project/slug"
+	"final-project/utils"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// batas ukuran file import (10 MB)
+const maxImportSize = 10 << 20
+
+var errImportRollback = errors.New("import dibatalkan")
+
+// CatalogRow satu baris katalog (brand + phone + specification) untuk import / export
+type CatalogRow struct {
+	BrandName        string              `json:"brand_name"`
+	BrandLogoURL     string              `json:"brand_logo_url"`
+	BrandDescription string              `json:"brand_description"`
+	Model            string              `json:"model"`
+	Price            uint                `json:"price"`
+	ReleaseDate      string              `json:"release_date"` // YYYY-MM-DD atau RFC3339
+	ImageURL         string              `json:"image_url"`
+	Specification    *specificationInput `json:"specification,omitempty"`
+}
+
+type ImportRowError struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+type ImportReport struct {
+	DryRun        bool             `json:"dry_run"`
+	TotalRows     int              `json:"total_rows"`
+	BrandsCreated int              `json:"brands_created"`
+	PhonesCreated int              `json:"phones_created"`
+	PhonesUpdated int              `json:"phones_updated"`
+	SpecsCreated  int              `json:"specs_created"`
+	SpecsUpdated  int              `json:"specs_updated"`
+	Errors        []ImportRowError `json:"errors"`
+	// phone yg dibuat / diupdate, fiturnya dihitung ulang setelah import
+	phoneIDs []uint
+}
+
+// kolom file csv, kolom specification memakai nama field json specificationInput
+var catalogCSVColumns = []string{
+	"brand_name", "brand_logo_url", "brand_description",
+	"model", "price", "release_date", "image_url",
+	"display_size_inch", "display_resolution_width_px", "display_resolution_height_px",
+	"display_refresh_rate_hz", "display_type",
+	"operating_system", "chipset", "memory_gb", "storage_gb",
+	"battery_mah", "charging_watt",
+	"height_mm", "width_mm", "thickness_mm", "weight_gram", "ip_rating",
+	"network", "wifi", "bluetooth", "nfc", "usb",
+	"additional_feature", "camera_modules",
+}
+
+// Import catalog godoc
+// @Summary Import brands, phones & specifications from CSV / JSON (ADMIN ONLY)
+// @Description Bulk import catalog rows. every row contains a brand, a phone and optionally its specification, phones are upserted by brand name + model (brands are created when they do not exist yet, brand_logo_url is required for new brands). the whole file is imported in one transaction, nothing is saved if any row is invalid. use dry_run=true to only validate the file. CSV columns are the same as the export, camera_modules in CSV is written as "position:role:resolution_mp[:optical_zoom]" separated by ";", e.g. "rear:main:50:1;front:main:12". JSON is an array of rows with a nested specification object
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Accept multipart/form-data
+// @Param file formData file true "catalog file (.csv or .json)"
+// @Param format query string false "csv / json, default from the file extension"
+// @Param dry_run query bool false "only validate the file, nothing is saved"
+// @Produce json
+// @Success 200 {object} ImportReport
+// @Router /admin/import [post]
+func ImportCatalog(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.MsgRequired("file"), http.StatusBadRequest, nil))
+		return
+	}
+	if file.Size > maxImportSize {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(fmt.Sprintf("ukuran file maksimal %d MB", maxImportSize>>20), http.StatusBadRequest, nil))
+		return
+	}
+
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	defer src.Close()
+
+	var rows []CatalogRow
+	var parseErrors []ImportRowError
+	switch format {
+	case "csv":
+		rows, parseErrors, err = parseCatalogCSV(src)
+	case "json":
+		err = json.NewDecoder(src).Decode(&rows)
+	default:
+		err = errors.New("format file harus csv atau json")
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("file tidak bisa dibaca: "+err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+	report := ImportReport{DryRun: dryRun, TotalRows: len(rows), Errors: []ImportRowError{}}
+	report.Errors = append(report.Errors, parseErrors...)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := importCatalogRows(tx, rows, &report); err != nil {
+			return err
+		}
+		// dry run & file dgn error tidak disimpan
+		if dryRun || len(report.Errors) > 0 {
+			return errImportRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRollback) {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	report.Errors = mergeImportRowErrors(report.Errors)
+
+	if len(report.Errors) > 0 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("terdapat data yg tidak valid, tidak ada data yg disimpan", http.StatusBadRequest, report))
+		return
+	}
+	if dryRun {
+		c.JSON(http.StatusOK, utils.ResponseJSON("dry run: semua data valid, tidak ada data yg disimpan", http.StatusOK, report))
+		return
+	}
+
+	refreshValueScores(db, report.phoneIDs...)
+	refreshPhoneFeatures(db, report.phoneIDs...)
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgAdded("katalog"), http.StatusOK, report))
+}
+
+// Export catalog godoc
+// @Summary Export all brands, phones & specifications as CSV / JSON (ADMIN ONLY)
+// @Description Export the whole catalog in the same format accepted by POST /admin/import
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param format query string false "csv / json, default json"
+// @Produce json
+// @Produce text/csv
+// @Success 200 {object} []CatalogRow
+// @Router /admin/export [get]
+func ExportCatalog(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	if format != "csv" && format != "json" {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("format harus csv atau json", http.StatusBadRequest, nil))
+		return
+	}
+
+	var brands []models.Brand
+	if err := db.Find(&brands).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	brandByID := map[uint]models.Brand{}
+	for _, brand := range brands {
+		brandByID[brand.ID] = brand
+	}
+
+	filename := "catalog-" + time.Now().Format("20060102-150405") + "." + format
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+
+	// data ditulis per batch agar katalog besar tidak dimuat sekaligus
+	var csvWriter *csv.Writer
+	first := true
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		csvWriter = csv.NewWriter(c.Writer)
+		csvWriter.Write(catalogCSVColumns)
+	} else {
+		c.Header("Content-Type", "application/json; charset=utf-8")
+		c.Writer.WriteString("[")
+	}
+
+	var phones []models.Phone
+	err := db.Preload("Specifications.CameraModules").Order("id ASC").
+		FindInBatches(&phones, 500, func(tx *gorm.DB, batch int) error {
+			for _, phone := range phones {
+				row := toCatalogRow(phone, brandByID[phone.BrandID])
+				if csvWriter != nil {
+					if err := csvWriter.Write(catalogRowToCSV(row)); err != nil {
+						return err
+					}
+					continue
+				}
+
+				data, err := json.Marshal(row)
+				if err != nil {
+					return err
+				}
+				if !first {
+					c.Writer.WriteString(",")
+				}
+				first = false
+				c.Writer.Write(data)
+			}
+			if csvWriter != nil {
+				csvWriter.Flush()
+				return csvWriter.Error()
+			}
+			return nil
+		}).Error
+	if err != nil {
+		// header sudah terkirim, error hanya bisa dicatat
+		c.Error(err)
+		return
+	}
+
+	if csvWriter == nil {
+		c.Writer.WriteString("]")
+	}
+}
+
+// importCatalogRows validasi & simpan semua baris, error per baris dicatat ke
+// report dan baris tsb dilewati. error database dikembalikan
+func importCatalogRows(tx *gorm.DB, rows []CatalogRow, report *ImportReport) error {
+	brandByName := map[string]*models.Brand{}
+	// brand baru yg logo-nya sudah diisi baris sebelumnya, tetap dicatat
+	// walaupun brand belum dibuat karena ada baris yg error
+	pendingBrands := map[string]bool{}
+	seen := map[string]int{}
+
+	for i, row := range rows {
+		rowNumber := i + 1
+		row.BrandName = strings.TrimSpace(row.BrandName)
+		row.Model = strings.TrimSpace(row.Model)
+
+		dataErr := validateCatalogRow(&row)
+
+		// phone yg sama tidak boleh muncul dua kali dalam satu file
+		key := strings.ToLower(row.BrandName) + "\x00" + strings.ToLower(row.Model)
+		if prev, exist := seen[key]; exist && row.BrandName != "" && row.Model != "" {
+			dataErr = append(dataErr, fmt.Sprintf("phone %s %s sudah ada di baris %d", row.BrandName, row.Model, prev))
+		} else {
+			seen[key] = rowNumber
+		}
+
+		var brand *models.Brand
+		if row.BrandName != "" {
+			brandKey := strings.ToLower(row.BrandName)
+			brand = brandByName[brandKey]
+			if brand == nil {
+				var existing models.Brand
+				err := tx.Where("LOWER(name) = ?", brandKey).First(&existing).Error
+				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+					return err
+				}
+				if err == nil {
+					brand = &existing
+					brandByName[brandKey] = brand
+				} else if row.BrandLogoURL != "" {
+					pendingBrands[brandKey] = true
+				} else if !pendingBrands[brandKey] {
+					dataErr = append(dataErr, "brand "+row.BrandName+" belum ada, brand_logo_url harus diisi")
+				}
+			}
+		}
+
+		if len(dataErr) > 0 {
+			report.Errors = append(report.Errors, ImportRowError{Row: rowNumber, Errors: dataErr})
+			continue
+		}
+		// baris valid tidak perlu disimpan jika sudah ada baris yg error
+		if len(report.Errors) > 0 {
+			continue
+		}
+
+		if brand == nil {
+			brandSlug, err := slug.Generate(tx, slug.KindBrand, row.BrandName, 0)
+			if err != nil {
+				return err
+			}
+			brand = &models.Brand{
+				Name:        row.BrandName,
+				Slug:        brandSlug,
+				LogoURL:     row.BrandLogoURL,
+				Description: row.BrandDescription,
+			}
+			if err := tx.Create(brand).Error; err != nil {
+				return err
+			}
+			brandByName[strings.ToLower(row.BrandName)] = brand
+			report.BrandsCreated++
+		}
+
+		releaseDate, _ := parseCatalogDate(row.ReleaseDate)
+
+		var phone models.Phone
+		err := tx.Where("brand_id = ? AND LOWER(model) = ?", brand.ID, strings.ToLower(row.Model)).First(&phone).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			phoneSlug, err := slug.Generate(tx, slug.KindPhone, brand.Name+" "+row.Model, 0)
+			if err != nil {
+				return err
+			}
+			phone = models.Phone{
+				Model:       row.Model,
+				Slug:        phoneSlug,
+				Price:       row.Price,
+				ImageURL:    row.ImageURL,
+				ReleaseDate: releaseDate,
+				BrandID:     brand.ID,
+			}
+			if err := tx.Create(&phone).Error; err != nil {
+				return err
+			}
+			report.PhonesCreated++
+		} else {
+			phone.Model = row.Model
+			phone.Price = row.Price
+			phone.ImageURL = row.ImageURL
+			phone.ReleaseDate = releaseDate
+			phone.UpdatedAt = time.Now()
+			if err := tx.Save(&phone).Error; err != nil {
+				return err
+			}
+			report.PhonesUpdated++
+		}
+
+		report.phoneIDs = append(report.phoneIDs, phone.ID)
+
+		if row.Specification == nil {
+			continue
+		}
+
+		spec := toSpecification(*row.Specification)
+		spec.PhoneID = phone.ID
+		validateSpecification(&spec) // normalisasi network
+
+		var existing models.Specification
+		err = tx.Where("phone_id = ?", phone.ID).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := tx.Create(&spec).Error; err != nil {
+				return err
+			}
+			report.SpecsCreated++
+			continue
+		}
+
+		// specification lama diganti seluruhnya termasuk camera modules
+		spec.ID = existing.ID
+		spec.CreatedAt = existing.CreatedAt
+		spec.UpdatedAt = time.Now()
+		if err := tx.Where("specification_id = ?", existing.ID).Delete(&models.CameraModule{}).Error; err != nil {
+			return err
+		}
+		for i := range spec.CameraModules {
+			spec.CameraModules[i].SpecificationID = existing.ID
+		}
+		if len(spec.CameraModules) > 0 {
+			if err := tx.Create(&spec.CameraModules).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Omit("CameraModules").Save(&spec).Error; err != nil {
+			return err
+		}
+		report.SpecsUpdated++
+	}
+
+	return nil
+}
+
+// validateCatalogRow validasi isi satu baris tanpa mengakses database
+func validateCatalogRow(row *CatalogRow) []string {
+	dataErr := []string{}
+
+	required := []string{}
+	if row.BrandName == "" {
+		required = append(required, "brand_name")
+	}
+	if row.Model == "" {
+		required = append(required, "model")
+	}
+	if row.Price == 0 {
+		required = append(required, "price")
+	}
+	if row.ReleaseDate == "" {
+		required = append(required, "release_date")
+	}
+	if row.ImageURL == "" {
+		required = append(required, "image_url")
+	}
+	if len(required) > 0 {
+		dataErr = append(dataErr, lib.MsgRequired(required...))
+	}
+
+	if row.ReleaseDate != "" {
+		if _, err := parseCatalogDate(row.ReleaseDate); err != nil {
+			dataErr = append(dataErr, "release_date harus berformat YYYY-MM-DD")
+		}
+	}
+	if row.ImageURL != "" && !utils.IsValidUrl(row.ImageURL) {
+		dataErr = append(dataErr, lib.MsgValidUrl("image_url"))
+	}
+	if row.BrandLogoURL != "" && !utils.IsValidUrl(row.BrandLogoURL) {
+		dataErr = append(dataErr, lib.MsgValidUrl("brand_logo_url"))
+	}
+
+	if row.Specification != nil {
+		spec := row.Specification
+
+		required := []string{}
+		if spec.OperatingSystem == "" {
+			required = append(required, "operating_system")
+		}
+		if spec.MemoryGB == 0 {
+			required = append(required, "memory_gb")
+		}
+		if spec.StorageGB == 0 {
+			required = append(required, "storage_gb")
+		}
+		if spec.Network == "" {
+			required = append(required, "network")
+		}
+		if len(spec.CameraModules) == 0 {
+			required = append(required, "camera_modules")
+		}
+		if len(required) > 0 {
+			dataErr = append(dataErr, lib.MsgRequired(required...))
+		}
+
+		for i, cam := range spec.CameraModules {
+			if cam.Position != "rear" && cam.Position != "front" {
+				dataErr = append(dataErr, fmt.Sprintf("camera_modules[%d].position harus rear atau front", i))
+			}
+			if !slices.Contains([]string{"main", "ultrawide", "telephoto", "macro", "depth"}, cam.Role) {
+				dataErr = append(dataErr, fmt.Sprintf("camera_modules[%d].role harus main, ultrawide, telephoto, macro atau depth", i))
+			}
+		}
+
+		converted := toSpecification(*spec)
+		dataErr = append(dataErr, validateSpecification(&converted)...)
+	}
+
+	return dataErr
+}
+
+// mergeImportRowErrors gabungkan error baris yg sama (error parsing csv &
+// error validasi) lalu urutkan berdasarkan nomor baris
+func mergeImportRowErrors(rowErrors []ImportRowError) []ImportRowError {
+	merged := []ImportRowError{}
+	byRow := map[int]int{}
+	for _, rowError := range rowErrors {
+		if i, exist := byRow[rowError.Row]; exist {
+			merged[i].Errors = append(merged[i].Errors, rowError.Errors...)
+			continue
+		}
+		byRow[rowError.Row] = len(merged)
+		merged = append(merged, rowError)
+	}
+	slices.SortStableFunc(merged, func(a, b ImportRowError) int { return a.Row - b.Row })
+	return merged
+}
+
+func parseCatalogDate(value string) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func toSpecification(input specificationInput) models.Specification {
+	return models.Specification{
+		DisplaySizeInch:           input.DisplaySizeInch,
+		DisplayResolutionWidthPx:  input.DisplayResolutionWidthPx,
+		DisplayResolutionHeightPx: input.DisplayResolutionHeightPx,
+		DisplayRefreshRateHz:      input.DisplayRefreshRateHz,
+		DisplayType:               input.DisplayType,
+		OperatingSystem:           input.OperatingSystem,
+		Chipset:                   input.Chipset,
+		MemoryGB:                  input.MemoryGB,
+		StorageGB:                 input.StorageGB,
+		BatteryMah:                input.BatteryMah,
+		ChargingWatt:              input.ChargingWatt,
+		HeightMm:                  input.HeightMm,
+		WidthMm:                   input.WidthMm,
+		ThicknessMm:               input.ThicknessMm,
+		WeightGram:                input.WeightGram,
+		IPRating:                  strings.ToUpper(input.IPRating),
+		Network:                   input.Network,
+		Wifi:                      input.Wifi,
+		Bluetooth:                 input.Bluetooth,
+		NFC:                       input.NFC,
+		USB:                       input.USB,
+		AdditionalFeature:         input.AdditionalFeature,
+		CameraModules:             toCameraModules(input.CameraModules),
+	}
+}
+
+func toCatalogRow(phone models.Phone, brand models.Brand) CatalogRow {
+	row := CatalogRow{
+		BrandName:        brand.Name,
+		BrandLogoURL:     brand.LogoURL,
+		BrandDescription: brand.Description,
+		Model:            phone.Model,
+		Price:            phone.Price,
+		ReleaseDate:      phone.ReleaseDate.Format("2006-01-02"),
+		ImageURL:         phone.ImageURL,
+	}
+
+	if len(phone.Specifications) > 0 {
+		spec := phone.Specifications[0]
+		cameras := make([]cameraModuleInput, 0, len(spec.CameraModules))
+		for _, cam := range spec.CameraModules {
+			cameras = append(cameras, cameraModuleInput{
+				Position:     cam.Position,
+				Role:         cam.Role,
+				ResolutionMP: cam.ResolutionMP,
+				OpticalZoom:  cam.OpticalZoom,
+			})
+		}
+		row.Specification = &specificationInput{
+			DisplaySizeInch:           spec.DisplaySizeInch,
+			DisplayResolutionWidthPx:  spec.DisplayResolutionWidthPx,
+			DisplayResolutionHeightPx: spec.DisplayResolutionHeightPx,
+			DisplayRefreshRateHz:      spec.DisplayRefreshRateHz,
+			DisplayType:               spec.DisplayType,
+			OperatingSystem:           spec.OperatingSystem,
+			Chipset:                   spec.Chipset,
+			MemoryGB:                  spec.MemoryGB,
+			StorageGB:                 spec.StorageGB,
+			BatteryMah:                spec.BatteryMah,
+			ChargingWatt:              spec.ChargingWatt,
+			HeightMm:                  spec.HeightMm,
+			WidthMm:                   spec.WidthMm,
+			ThicknessMm:               spec.ThicknessMm,
+			WeightGram:                spec.WeightGram,
+			IPRating:                  spec.IPRating,
+			Network:                   spec.Network,
+			Wifi:                      spec.Wifi,
+			Bluetooth:                 spec.Bluetooth,
+			NFC:                       spec.NFC,
+			USB:                       spec.USB,
+			AdditionalFeature:         spec.AdditionalFeature,
+			CameraModules:             cameras,
+		}
+	}
+
+	return row
+}
+
+// parseCatalogCSV membaca file csv dgn header, kolom yg tidak dikenal
+// diabaikan. error format angka dicatat per baris
+func parseCatalogCSV(r io.Reader) ([]CatalogRow, []ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{"brand_name", "model"} {
+		if _, ok := index[name]; !ok {
+			return nil, nil, fmt.Errorf("kolom %s tidak ditemukan", name)
+		}
+	}
+
+	rows := []CatalogRow{}
+	rowErrors := []ImportRowError{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		get := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row, dataErr := catalogRowFromCSV(get)
+		rows = append(rows, row)
+		if len(dataErr) > 0 {
+			rowErrors = append(rowErrors, ImportRowError{Row: len(rows), Errors: dataErr})
+		}
+	}
+
+	return rows, rowErrors, nil
+}
+
+func catalogRowFromCSV(get func(name string) string) (CatalogRow, []string) {
+	dataErr := []string{}
+
+	parseUint := func(name string) uint {
+		value := get(name)
+		if value == "" {
+			return 0
+		}
+		v, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			dataErr = append(dataErr, name+" harus berupa angka bulat")
+		}
+		return uint(v)
+	}
+	// kolom kosong berarti tidak diketahui (null)
+	parseOptionalUint := func(name string) *uint {
+		if get(name) == "" {
+			return nil
+		}
+		v := parseUint(name)
+		return &v
+	}
+	parseFloat := func(name string) float64 {
+		value := get(name)
+		if value == "" {
+			return 0
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			dataErr = append(dataErr, name+" harus berupa angka")
+		}
+		return v
+	}
+
+	row := CatalogRow{
+		BrandName:        get("brand_name"),
+		BrandLogoURL:     get("brand_logo_url"),
+		BrandDescription: get("brand_description"),
+		Model:            get("model"),
+		Price:            parseUint("price"),
+		ReleaseDate:      get("release_date"),
+		ImageURL:         get("image_url"),
+	}
+
+	// specification hanya dibaca jika salah satu kolomnya terisi
+	hasSpec := false
+	for _, name := range catalogCSVColumns[7:] {
+		if get(name) != "" {
+			hasSpec = true
+			break
+		}
+	}
+	if !hasSpec {
+		return row, dataErr
+	}
+
+	nfc := false
+	if value := get("nfc"); value != "" {
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			dataErr = append(dataErr, "nfc harus berisi true atau false")
+		}
+		nfc = v
+	}
+
+	cameras, err := parseCameraModules(get("camera_modules"))
+	if err != nil {
+		dataErr = append(dataErr, err.Error())
+	}
+
+	row.Specification = &specificationInput{
+		DisplaySizeInch:           parseFloat("display_size_inch"),
+		DisplayResolutionWidthPx:  parseUint("display_resolution_width_px"),
+		DisplayResolutionHeightPx: parseUint("display_resolution_height_px"),
+		DisplayRefreshRateHz:      parseUint("display_refresh_rate_hz"),
+		DisplayType:               get("display_type"),
+		OperatingSystem:           get("operating_system"),
+		Chipset:                   get("chipset"),
+		MemoryGB:                  parseUint("memory_gb"),
+		StorageGB:                 parseUint("storage_gb"),
+		BatteryMah:                parseOptionalUint("battery_mah"),
+		ChargingWatt:              parseUint("charging_watt"),
+		HeightMm:                  parseFloat("height_mm"),
+		WidthMm:                   parseFloat("width_mm"),
+		ThicknessMm:               parseFloat("thickness_mm"),
+		WeightGram:                parseFloat("weight_gram"),
+		IPRating:                  get("ip_rating"),
+		Network:                   get("network"),
+		Wifi:                      get("wifi"),
+		Bluetooth:                 get("bluetooth"),
+		NFC:                       nfc,
+		USB:                       get("usb"),
+		AdditionalFeature:         get("additional_feature"),
+		CameraModules:             cameras,
+	}
+
+	return row, dataErr
+}
+
+// parseCameraModules format "position:role:resolution_mp[:optical_zoom]" dipisah ";"
+func parseCameraModules(value string) ([]cameraModuleInput, error) {
+	cameras := []cameraModuleInput{}
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) < 3 || len(parts) > 4 {
+			return nil, fmt.Errorf("camera_modules %q harus berformat position:role:resolution_mp[:optical_zoom]", item)
+		}
+		resolution, err := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("resolution_mp pada camera_modules %q harus berupa angka", item)
+		}
+		camera := cameraModuleInput{
+			Position:     strings.ToLower(strings.TrimSpace(parts[0])),
+			Role:         strings.ToLower(strings.TrimSpace(parts[1])),
+			ResolutionMP: resolution,
+		}
+		if len(parts) == 4 {
+			zoom, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("optical_zoom pada camera_modules %q harus berupa angka", item)
+			}
+			camera.OpticalZoom = zoom
+		}
+		cameras = append(cameras, camera)
+	}
+	return cameras, nil
+}
+
+func catalogRowToCSV(row CatalogRow) []string {
+	record := []string{
+		row.BrandName, row.BrandLogoURL, row.BrandDescription,
+		row.Model, strconv.FormatUint(uint64(row.Price), 10), row.ReleaseDate, row.ImageURL,
+	}
+	if row.Specification == nil {
+		return append(record, make([]string, len(catalogCSVColumns)-len(record))...)
+	}
+
+	spec := row.Specification
+	// nilai 0 (tidak diisi) ditulis kosong
+	uintStr := func(v uint) string {
+		if v == 0 {
+			return ""
+		}
+		return strconv.FormatUint(uint64(v), 10)
+	}
+	optionalUintStr := func(v *uint) string {
+		if v == nil {
+			return ""
+		}
+		return uintStr(*v)
+	}
+	floatStr := func(v float64) string {
+		if v == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	cameras := make([]string, 0, len(spec.CameraModules))
+	for _, cam := range spec.CameraModules {
+		item := cam.Position + ":" + cam.Role + ":" + floatStr(cam.ResolutionMP)
+		if cam.OpticalZoom != 0 {
+			item += ":" + floatStr(cam.OpticalZoom)
+		}
+		cameras = append(cameras, item)
+	}
+
+	return append(record,
+		floatStr(spec.DisplaySizeInch), uintStr(spec.DisplayResolutionWidthPx), uintStr(spec.DisplayResolutionHeightPx),
+		uintStr(spec.DisplayRefreshRateHz), spec.DisplayType,
+		spec.OperatingSystem, spec.Chipset, uintStr(spec.MemoryGB), uintStr(spec.StorageGB),
+		optionalUintStr(spec.BatteryMah), uintStr(spec.ChargingWatt),
+		floatStr(spec.HeightMm), floatStr(spec.WidthMm), floatStr(spec.ThicknessMm), floatStr(spec.WeightGram), spec.IPRating,
+		spec.Network, spec.Wifi, spec.Bluetooth, strconv.FormatBool(spec.NFC), spec.USB,
+		spec.AdditionalFeature, strings.Join(cameras, ";"),
+	)
+}
@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCatalogCSV(t *testing.T) {
+	file := "\ufeffbrand_name,model,price,release_date,image_url,memory_gb,storage_gb,battery_mah,nfc,camera_modules,unknown\n" +
+		"Samsung,Galaxy A55,5999000,2024-03-11,https://img.example/a55.jpg,8,256,5000,true,rear:main:50;front:main:32,x\n" +
+		"Xiaomi,Redmi 13,1899000,2024-06-01,https://img.example/r13.jpg,,,,,,\n" +
+		"Oppo,A79,dua juta,2024-01-01,https://img.example/a79.jpg,8,abc,,ya,rear:main,\n"
+
+	rows, rowErrors, err := parseCatalogCSV(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("len(rows) = %d, want 3", len(rows))
+	}
+
+	first := rows[0]
+	if first.BrandName != "Samsung" || first.Model != "Galaxy A55" || first.Price != 5999000 || first.ReleaseDate != "2024-03-11" {
+		t.Errorf("rows[0] = %+v", first)
+	}
+	if first.Specification == nil {
+		t.Fatal("rows[0].Specification harus terisi")
+	}
+	spec := first.Specification
+	if spec.MemoryGB != 8 || spec.StorageGB != 256 || spec.BatteryMah == nil || *spec.BatteryMah != 5000 || !spec.NFC {
+		t.Errorf("rows[0].Specification = %+v", spec)
+	}
+	wantCameras := []cameraModuleInput{
+		{Position: "rear", Role: "main", ResolutionMP: 50},
+		{Position: "front", Role: "main", ResolutionMP: 32},
+	}
+	if !reflect.DeepEqual(spec.CameraModules, wantCameras) {
+		t.Errorf("rows[0].CameraModules = %+v, want %+v", spec.CameraModules, wantCameras)
+	}
+
+	// kolom specification kosong semua berarti tanpa specification
+	if rows[1].Specification != nil {
+		t.Errorf("rows[1].Specification = %+v, want nil", rows[1].Specification)
+	}
+
+	wantErrors := []ImportRowError{{Row: 3, Errors: []string{
+		"price harus berupa angka bulat",
+		"nfc harus berisi true atau false",
+		`camera_modules "rear:main" harus berformat position:role:resolution_mp[:optical_zoom]`,
+		"storage_gb harus berupa angka bulat",
+	}}}
+	if !reflect.DeepEqual(rowErrors, wantErrors) {
+		t.Errorf("rowErrors = %q, want %q", rowErrors, wantErrors)
+	}
+}
+
+func TestParseCatalogCSVMissingColumn(t *testing.T) {
+	if _, _, err := parseCatalogCSV(strings.NewReader("brand_name,price\nSamsung,1000\n")); err == nil {
+		t.Error("file tanpa kolom model harus ditolak")
+	}
+}
+
+func TestParseCameraModules(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []cameraModuleInput
+		wantErr bool
+	}{
+		{"", []cameraModuleInput{}, false},
+		{"REAR:Main:50", []cameraModuleInput{{Position: "rear", Role: "main", ResolutionMP: 50}}, false},
+		{"rear:telephoto:12:3; front:main:10.5;", []cameraModuleInput{
+			{Position: "rear", Role: "telephoto", ResolutionMP: 12, OpticalZoom: 3},
+			{Position: "front", Role: "main", ResolutionMP: 10.5},
+		}, false},
+		{"rear:main", nil, true},
+		{"rear:main:50:3:1", nil, true},
+		{"rear:main:besar", nil, true},
+		{"rear:telephoto:12:x", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseCameraModules(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCameraModules(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseCameraModules(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseCatalogDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"2024-03-11", "2024-03-11", false},
+		{"2024-03-11T10:00:00+07:00", "2024-03-11", false},
+		{"11-03-2024", "", true},
+		{"2024-02-30", "", true},
+	}
+	for _, tt := range tests {
+		got, err := parseCatalogDate(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCatalogDate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got.Format("2006-01-02") != tt.want {
+			t.Errorf("parseCatalogDate(%q) = %v, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestValidateCatalogRow(t *testing.T) {
+	battery := uint(5000)
+	validSpec := func() *specificationInput {
+		return &specificationInput{
+			OperatingSystem: "Android 14",
+			MemoryGB:        8,
+			StorageGB:       256,
+			BatteryMah:      &battery,
+			Network:         "5G",
+			CameraModules:   []cameraModuleInput{{Position: "rear", Role: "main", ResolutionMP: 50}},
+		}
+	}
+	validRow := func() CatalogRow {
+		return CatalogRow{
+			BrandName:   "Samsung",
+			Model:       "Galaxy A55",
+			Price:       5999000,
+			ReleaseDate: "2024-03-11",
+			ImageURL:    "https://img.example/a55.jpg",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(row *CatalogRow)
+		want   []string
+	}{
+		{"baris valid", func(row *CatalogRow) {}, []string{}},
+		{"baris valid dgn specification", func(row *CatalogRow) { row.Specification = validSpec() }, []string{}},
+		{
+			"kolom wajib kosong",
+			func(row *CatalogRow) { *row = CatalogRow{} },
+			[]string{"brand_name, model, price, release_date, image_url harus diisi"},
+		},
+		{
+			"format tanggal & url salah",
+			func(row *CatalogRow) {
+				row.ReleaseDate = "11/03/2024"
+				row.ImageURL = "a55.jpg"
+				row.BrandLogoURL = "logo"
+			},
+			[]string{
+				"release_date harus berformat YYYY-MM-DD",
+				"image_url harus merupakan url yg valid",
+				"brand_logo_url harus merupakan url yg valid",
+			},
+		},
+		{
+			"specification tidak lengkap",
+			func(row *CatalogRow) {
+				row.Specification = &specificationInput{Network: "5G"}
+			},
+			[]string{"operating_system, memory_gb, storage_gb, camera_modules harus diisi"},
+		},
+		{
+			"kamera & network tidak valid",
+			func(row *CatalogRow) {
+				row.Specification = validSpec()
+				row.Specification.Network = "wifi only"
+				row.Specification.CameraModules = []cameraModuleInput{{Position: "side", Role: "zoom", ResolutionMP: 50}}
+			},
+			[]string{
+				"camera_modules[0].position harus rear atau front",
+				"camera_modules[0].role harus main, ultrawide, telephoto, macro atau depth",
+				"network harus berisi 2G, 3G, 4G atau 5G",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := validRow()
+			tt.modify(&row)
+			if got := validateCatalogRow(&row); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateCatalogRow() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,568 @@
+package controller
+
+import (
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	maxTagLength    = 50
+	maxTagsPerPhone = 20
+)
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type categoryInput struct {
+	Name        string `json:"name" binding:"required"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+}
+
+type categoryUpdate struct {
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+}
+
+type phoneCategoriesInput struct {
+	// slug kategori, menggantikan semua kategori phone sebelumnya
+	Categories []string `json:"categories" binding:"required"`
+}
+
+type phoneTagsInput struct {
+	// nama tag, tag yg belum ada dibuat otomatis
+	Tags []string `json:"tags" binding:"required"`
+}
+
+// Get all categories godoc
+// @Summary Get all phone categories. (PUBLIC)
+// @Description Get a list of phone categories
+// @Tags Categories
+// @Produce json
+// @Success 200 {object} []models.Category
+// @Router /categories [get]
+func GetAllCategories(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var categories []models.Category
+	if err := db.Order("name ASC").Find(&categories).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, categories))
+}
+
+// Create category godoc
+// @Summary Create phone category (ADMIN ONLY)
+// @Description Create a new phone category, slug is generated from name when empty
+// @Tags Categories
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param Body body categoryInput true "example JSON body to create a category"
+// @Produce json
+// @Success 200 {object} models.Category
+// @Router /categories [post]
+func CreateCategory(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input categoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	category_data := models.Category{
+		Name:        strings.TrimSpace(input.Name),
+		Slug:        input.Slug,
+		Description: input.Description,
+	}
+	if category_data.Slug == "" {
+		category_data.Slug = utils.Slugify(category_data.Name)
+	}
+
+	if !isCategoryDataValid(c, db, category_data) {
+		return
+	}
+
+	if err := db.Create(&category_data).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgAdded("category"), http.StatusOK, category_data))
+}
+
+// Update category godoc
+// @Summary Update phone category (ADMIN ONLY)
+// @Description Update a phone category by id
+// @Tags Categories
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "Category id"
+// @Param Body body categoryUpdate true "example JSON body to update a category"
+// @Produce json
+// @Success 200 {object} models.Category
+// @Router /categories/{id} [put]
+func UpdateCategory(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input categoryUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	var category models.Category
+	if err := db.Where("id = ?", c.Param("id")).First(&category).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("category"), http.StatusNotFound, nil))
+		return
+	}
+
+	// Update yg diinput saja
+	if input.Name != "" {
+		category.Name = strings.TrimSpace(input.Name)
+	}
+	if input.Slug != "" {
+		category.Slug = input.Slug
+	}
+	if input.Description != "" {
+		category.Description = input.Description
+	}
+	category.UpdatedAt = time.Now()
+
+	if !isCategoryDataValid(c, db, category) {
+		return
+	}
+
+	if err := db.Save(&category).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON("Failed to update category", http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("category"), http.StatusOK, category))
+}
+
+// Delete category godoc
+// @Summary Delete phone category (ADMIN ONLY)
+// @Description Delete a phone category by id, phones in this category are not deleted
+// @Tags Categories
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Param id path string true "Category id"
+// @Success 200 {object} map[string]boolean
+// @Router /categories/{id} [delete]
+func DeleteCategory(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var category models.Category
+	if err := db.Where("id = ?", c.Param("id")).First(&category).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("category"), http.StatusNotFound, nil))
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM phone_categories WHERE category_id = ?", category.ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&category).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgDeleted("category"), http.StatusOK, nil))
+}
+
+// Get phones by category godoc
+// @Summary Get phones data by category slug. (PUBLIC)
+// @Description Get all phones in a category, can be narrowed down with the tag filter
+// @Tags Categories
+// @Produce json
+// @Param slug path string true "Category slug"
+// @Param tag query string false "comma separated tags, only phones having all of the tags"
+// @Param status query string false "comma separated phone statuses"
+// @Param sort query string false "asc / desc"
+// @Param region query string false "region code (e.g. ID, MY, SG, US), fills local_price"
+// @Param currency query string false "currency code (e.g. USD), fills local_price"
+// @Success 200 {object} []PhonesCompleteResponse
+// @Router /categories/{slug}/phones [get]
+func GetPhonesByCategory(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	region, currency, err := localPriceQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	var category models.Category
+	if err := db.Where("slug = ?", c.Param("slug")).First(&category).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("category"), http.StatusNotFound, nil))
+		return
+	}
+
+	query := visiblePhones(c, phoneCompleteQuery(db)).
+		Where("phones.id IN (?)", db.Table("phone_categories").Select("phone_id").Where("category_id = ?", category.ID))
+	query = applyPhoneTaxonomyFilter(c, query)
+
+	query, err = applyPhoneStatusFilter(c, query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	switch strings.ToLower(c.Query("sort")) {
+	case "desc":
+		query.Order("phone_id DESC")
+	default:
+		query.Order("phone_id ASC")
+	}
+
+	phones_data := []PhonesCompleteResponse{}
+	if err := query.Scan(&phones_data).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := applyLocalPrices(db, phones_data, region, currency); err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := applyAspectRatings(db, phones_data); err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, phones_data))
+}
+
+// Get all tags godoc
+// @Summary Get all phone tags. (PUBLIC)
+// @Description Get a list of phone tags with the number of phones using each tag
+// @Tags Categories
+// @Produce json
+// @Param search query string false "search keyword"
+// @Success 200 {object} []TagResponse
+// @Router /tags [get]
+func GetAllTags(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	query := db.Table("tags").
+		Select("tags.id, tags.name, COUNT(phone_tags.phone_id) as phone_count").
+		Joins("LEFT JOIN phone_tags on phone_tags.tag_id = tags.id").
+		Group("tags.id, tags.name")
+
+	if search := c.Query("search"); search != "" {
+		query = query.Where("tags.name LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(search)))
+	}
+
+	tags := []TagResponse{}
+	if err := query.Order("phone_count DESC, tags.name ASC").Scan(&tags).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, tags))
+}
+
+type TagResponse struct {
+	ID         uint   `json:"id"`
+	Name       string `json:"name"`
+	PhoneCount int    `json:"phone_count"`
+}
+
+// Delete tag godoc
+// @Summary Delete phone tag (ADMIN ONLY)
+// @Description Delete a tag by id and remove it from every phone
+// @Tags Categories
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Param id path string true "Tag id"
+// @Success 200 {object} map[string]boolean
+// @Router /tags/{id} [delete]
+func DeleteTag(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var tag models.Tag
+	if err := db.Where("id = ?", c.Param("id")).First(&tag).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("tag"), http.StatusNotFound, nil))
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM phone_tags WHERE tag_id = ?", tag.ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&tag).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgDeleted("tag"), http.StatusOK, nil))
+}
+
+// Set phone categories godoc
+// @Summary Set Phone categories (ADMIN ONLY)
+// @Description Replace the categories of a phone by category slug, send an empty list to remove all categories
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "Phone id"
+// @Param Body body phoneCategoriesInput true "example JSON body : {\"categories\": [\"flagship\", \"gaming\"]}"
+// @Produce json
+// @Success 200 {object} []models.Category
+// @Router /phones/{id}/categories [put]
+func SetPhoneCategories(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input phoneCategoriesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	var phone models.Phone
+	if err := db.Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	categories := []models.Category{}
+	if len(input.Categories) > 0 {
+		if err := db.Where("slug IN ?", input.Categories).Find(&categories).Error; err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+	}
+
+	// semua slug harus terdaftar
+	for _, slug := range input.Categories {
+		found := false
+		for _, category := range categories {
+			if category.Slug == slug {
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.JSON(http.StatusNotFound,
+				utils.ResponseJSON(lib.ErrMsgNotFound("category "+slug), http.StatusNotFound, nil))
+			return
+		}
+	}
+
+	if err := db.Model(&phone).Association("Categories").Replace(categories); err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("phone categories"), http.StatusOK, categories))
+}
+
+// Set phone tags godoc
+// @Summary Set Phone tags (ADMIN ONLY)
+// @Description Replace the tags of a phone, tags are free-form and saved in lowercase. tags that do not exist yet are created automatically, send an empty list to remove all tags
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "Phone id"
+// @Param Body body phoneTagsInput true "example JSON body : {\"tags\": [\"5g\", \"wireless charging\"]}"
+// @Produce json
+// @Success 200 {object} []models.Tag
+// @Router /phones/{id}/tags [put]
+func SetPhoneTags(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input phoneTagsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	var phone models.Phone
+	if err := db.Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	names := normalizeTags(input.Tags)
+	if len(names) > maxTagsPerPhone {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(fmt.Sprintf("maksimal %d tag per phone", maxTagsPerPhone), http.StatusBadRequest, nil))
+		return
+	}
+	for _, name := range names {
+		if len(name) > maxTagLength {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(fmt.Sprintf("tag %s melebihi %d karakter", name, maxTagLength), http.StatusBadRequest, nil))
+			return
+		}
+	}
+
+	tags := []models.Tag{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, name := range names {
+			tag := models.Tag{Name: name}
+			if err := tx.Where("name = ?", name).FirstOrCreate(&tag).Error; err != nil {
+				return err
+			}
+			tags = append(tags, tag)
+		}
+		return tx.Model(&phone).Association("Tags").Replace(tags)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("phone tags"), http.StatusOK, tags))
+}
+
+// applyPhoneTaxonomyFilter filter phone berdasarkan query category (cocok salah
+// satu slug) dan tag (harus punya semua tag), nilai dipisah koma
+func applyPhoneTaxonomyFilter(c *gin.Context, query *gorm.DB) *gorm.DB {
+	db := c.MustGet("db").(*gorm.DB)
+
+	if slugs := splitQueryList(c.Query("category")); len(slugs) > 0 {
+		sub := db.Table("phone_categories").
+			Select("phone_categories.phone_id").
+			Joins("JOIN categories on categories.id = phone_categories.category_id").
+			Where("categories.slug IN ?", slugs)
+		query = query.Where("phones.id IN (?)", sub)
+	}
+
+	if names := normalizeTags(splitQueryList(c.Query("tag"))); len(names) > 0 {
+		sub := db.Table("phone_tags").
+			Select("phone_tags.phone_id").
+			Joins("JOIN tags on tags.id = phone_tags.tag_id").
+			Where("tags.name IN ?", names).
+			Group("phone_tags.phone_id").
+			Having("COUNT(DISTINCT tags.id) = ?", len(names))
+		query = query.Where("phones.id IN (?)", sub)
+	}
+
+	return query
+}
+
+// getPhoneTaxonomy kategori & tag dari satu phone
+func getPhoneTaxonomy(db *gorm.DB, phoneID uint) ([]models.Category, []models.Tag, error) {
+	categories := []models.Category{}
+	if err := db.Joins("JOIN phone_categories on phone_categories.category_id = categories.id").
+		Where("phone_categories.phone_id = ?", phoneID).
+		Order("categories.name ASC").
+		Find(&categories).Error; err != nil {
+		return nil, nil, err
+	}
+
+	tags := []models.Tag{}
+	if err := db.Joins("JOIN phone_tags on phone_tags.tag_id = tags.id").
+		Where("phone_tags.phone_id = ?", phoneID).
+		Order("tags.name ASC").
+		Find(&tags).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return categories, tags, nil
+}
+
+// normalizeTags huruf kecil, spasi dirapikan, tanpa duplikat & tag kosong
+func normalizeTags(tags []string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		name := strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+func splitQueryList(value string) []string {
+	list := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func isCategoryDataValid(c *gin.Context, db *gorm.DB, data models.Category) bool {
+	if data.Name == "" {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.MsgRequired("name"), http.StatusBadRequest, nil))
+		return false
+	}
+
+	if !slugRegex.MatchString(data.Slug) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("slug harus huruf kecil, angka atau tanda hubung (contoh: mid-range)", http.StatusBadRequest, nil))
+		return false
+	}
+
+	// cek slug sudah dipakai kategori lain atau belum
+	var count int64
+	db.Model(&models.Category{}).Where("slug = ? AND id <> ?", data.Slug, data.ID).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.MsgAlreadyExist("slug"), http.StatusBadRequest, nil))
+		return false
+	}
+
+	return true
+}
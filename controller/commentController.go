@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"final-project/contentfilter"
 	"final-project/lib"
 	"final-project/models"
 	"final-project/utils"
@@ -56,7 +57,7 @@ func CreateComment(c *gin.Context) {
 	}
 
 	var rev []models.Review
-	if err := db.Where("id = ?", reviewID).First(&rev).Error; err != nil {
+	if err := approvedReviews(db).Where("id = ?", reviewID).First(&rev).Error; err != nil {
 		c.JSON(http.StatusInternalServerError,
 			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
 		return
@@ -76,20 +77,36 @@ func CreateComment(c *gin.Context) {
 		return
 	}
 
+	filtered, ok := filterContent(c, db, contentfilter.Input{Kind: contentfilter.KindComment, UserID: userID, Content: input.Content})
+	if !ok {
+		return
+	}
+
 	comment_data := models.Comment{
-		Content:  input.Content,
-		UserID:   userID,
-		ReviewID: uint(reviewID),
+		Content:     filtered.Content,
+		ContentHash: filtered.ContentHash,
+		UserID:      userID,
+		ReviewID:    uint(reviewID),
+	}
+	// komentar yg tertahan filter masuk antrian moderasi
+	if filtered.Action == contentfilter.ActionModerate {
+		comment_data.Status = models.ReviewStatusPending
+		comment_data.ModerationReason = filtered.Reason
+		comment_data.ModerationNote = filtered.Note()
 	}
 
 	db.Create(&comment_data)
 
-	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgAdded("comment"), http.StatusOK, comment_data))
+	msg := lib.MsgAdded("comment")
+	if comment_data.Status == models.ReviewStatusPending {
+		msg = "comment berhasil ditambahkan dan menunggu moderasi"
+	}
+	c.JSON(http.StatusOK, utils.ResponseJSON(msg, http.StatusOK, comment_data))
 }
 
 // Update Comment godoc
 // @Summary Update Comment on a review
-// @Description This route will update comment data, will only be able to update data related to the logged in user (user ID is taken from the JWT token)
+// @Description This route will update comment data, will only be able to update data related to the logged in user (user ID is taken from the JWT token). the previous content is kept as a revision (see /comments/{id}/revisions), a comment can not be changed anymore after COMMENT_EDIT_WINDOW_HOURS (0 = no limit)
 // @Tags Comments
 // @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
 // @Security BearerToken
@@ -138,24 +155,72 @@ func UpdateComment(c *gin.Context) {
 	}
 
 	// cek data comment ada atau tdk
-	if err := db.Where("id = ? AND user_id = ?", commentID, userID).Find(&rev).Error; err != nil {
+	rev = models.Comment{}
+	if err := db.Where("id = ? AND user_id = ?", commentID, userID).First(&rev).Error; err != nil {
 		msg := fmt.Sprintf("comment dgn ID (%s) dari user (%d) tidak ditemukan", commentID, userID)
 		c.JSON(http.StatusNotFound,
 			utils.ResponseJSON(msg, http.StatusNotFound, nil))
 		return
 	}
 
+	// konten terkunci setelah batas waktu edit
+	window := editWindow("COMMENT_EDIT_WINDOW_HOURS")
+	if isEditLocked(rev.CreatedAt, window) {
+		c.JSON(http.StatusForbidden,
+			utils.ResponseJSON(fmt.Sprintf("comment tidak bisa diubah lebih dari %g jam setelah dibuat", window.Hours()), http.StatusForbidden, nil))
+		return
+	}
+	previous := rev
+
+	filtered, ok := filterContent(c, db, contentfilter.Input{Kind: contentfilter.KindComment, ID: rev.ID, UserID: userID, Content: input.Content})
+	if !ok {
+		return
+	}
+
 	// Update yg diinput saja
 	if input.Content != "" {
-		rev.Content = input.Content
+		rev.Content = filtered.Content
 	}
 
-	var updated_data commentInput
-	updated_data.Content = rev.Content
+	updated_data := map[string]any{"content": rev.Content, "content_hash": filtered.ContentHash}
+	if filtered.Action == contentfilter.ActionModerate {
+		rev.Status = models.ReviewStatusPending
+		rev.ModerationReason = filtered.Reason
+		rev.ModerationNote = filtered.Note()
+		updated_data["status"] = rev.Status
+		updated_data["moderation_reason"] = rev.ModerationReason
+		updated_data["moderation_note"] = rev.ModerationNote
+	} else if rev.Status == models.ReviewStatusRejected || rev.Status == models.ReviewStatusHidden {
+		// komentar yg ditolak kembali ke antrian moderasi setelah diedit
+		rev.Status = models.ReviewStatusPending
+		updated_data["status"] = rev.Status
+	}
 
 	rev.UpdatedAt = time.Now()
 
-	if err := db.Model(&models.Comment{}).Where("user_id = ? AND id = ?", userID, commentID).Updates(&updated_data).Error; err != nil {
+	// versi lama disimpan jika konten berubah
+	edited := rev.Content != previous.Content
+	if edited {
+		rev.EditedAt = &rev.UpdatedAt
+		rev.RevisionCount++
+		updated_data["edited_at"] = rev.EditedAt
+		updated_data["revision_count"] = rev.RevisionCount
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if edited {
+			revision := models.CommentRevision{
+				CommentID: rev.ID,
+				Content:   previous.Content,
+				EditorID:  userID,
+			}
+			if err := tx.Create(&revision).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.Comment{}).Where("user_id = ? AND id = ?", userID, commentID).Updates(updated_data).Error
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError,
 			utils.ResponseJSON("Failed to update review", http.StatusInternalServerError, nil))
 		return
@@ -178,7 +243,7 @@ func GetCommentsDataByReviewId(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
 
 	id := c.Param("id")
-	if err := db.Preload("Comments.User").Preload("User").Find(&reviews, id).Error; err != nil {
+	if err := approvedReviews(db).Preload("Comments.User").Preload("User").Find(&reviews, id).Error; err != nil {
 		c.JSON(http.StatusInternalServerError,
 			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
 		return
@@ -201,10 +266,17 @@ func GetCommentsDataByReviewId(c *gin.Context) {
 			"updated_at": review.UpdatedAt,
 			"user_id":    review.UserID,
 			"phone_id":   review.PhoneID,
+			// penanda review pernah diedit
+			"edited_at":      review.EditedAt,
+			"revision_count": review.RevisionCount,
 		}
 
 		var comments []map[string]interface{}
 		for _, comment := range review.Comments {
+			// komentar yg belum / tidak disetujui tidak ditampilkan
+			if comment.Status != models.ReviewStatusApproved {
+				continue
+			}
 			c := map[string]interface{}{
 				"id":         comment.ID,
 				"content":    comment.Content,
@@ -213,6 +285,9 @@ func GetCommentsDataByReviewId(c *gin.Context) {
 				"updated_at": comment.UpdatedAt,
 				"user_id":    comment.UserID,
 				"review_id":  comment.ReviewID,
+				// penanda komentar pernah diedit
+				"edited_at":      comment.EditedAt,
+				"revision_count": comment.RevisionCount,
 			}
 			comments = append(comments, c)
 		}
@@ -0,0 +1,239 @@
+package controller
+
+import (
+	"errors"
+	"final-project/contentfilter"
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type contentFilterRuleInput struct {
+	Enabled *bool `json:"enabled"`
+	// reject, mask atau moderate
+	Action string `json:"action"`
+}
+
+type contentFilterWordInput struct {
+	// akhiran * untuk mencocokkan awalan kata
+	Word     string `json:"word" binding:"required"`
+	Language string `json:"language" binding:"required"`
+}
+
+type contentFilterTestInput struct {
+	Content string `json:"content" binding:"required"`
+}
+
+type ContentFilterConfig struct {
+	Rules       []contentfilter.RuleSetting `json:"rules"`
+	CustomWords []models.ContentFilterWord  `json:"custom_words"`
+}
+
+// Get content filter config godoc
+// @Summary Get content filter configuration. (ADMIN ONLY)
+// @Description Get the rules of the content filter applied to reviews & comments (profanity, link, phone_number, duplicate) with their action: reject (the content is refused), mask (matching parts are replaced with *) or moderate (the content is saved as pending in the moderation queue), plus the words added by admins on top of the built in Indonesian & English wordlists
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} ContentFilterConfig
+// @Router /admin/content-filter [get]
+func GetContentFilterConfig(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	settings, err := contentfilter.Settings(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	var words []models.ContentFilterWord
+	if err := db.Order("language ASC, word ASC").Find(&words).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, ContentFilterConfig{Rules: settings, CustomWords: words}))
+}
+
+// Update content filter rule godoc
+// @Summary Update a content filter rule. (ADMIN ONLY)
+// @Description Enable / disable a content filter rule or change its action (reject, mask or moderate). the duplicate rule can not use mask. changes apply immediately
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param key path string true "rule key"
+// @Param Body body contentFilterRuleInput true "the body to update a content filter rule"
+// @Produce json
+// @Success 200 {object} contentfilter.RuleSetting
+// @Router /admin/content-filter/rules/{key} [put]
+func UpdateContentFilterRule(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input contentFilterRuleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		c.JSON(http.StatusBadRequest, utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		return
+	}
+
+	setting, err := contentfilter.UpdateRule(db, c.Param("key"), input.Enabled, strings.ToLower(input.Action))
+	if errors.Is(err, contentfilter.ErrUnknownRule) {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("rule filter"), http.StatusNotFound, nil))
+		return
+	}
+	if errors.Is(err, contentfilter.ErrInvalidAction) || errors.Is(err, contentfilter.ErrNotMaskable) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("rule filter"), http.StatusOK, setting))
+}
+
+// Add content filter word godoc
+// @Summary Add a word to the profanity filter. (ADMIN ONLY)
+// @Description Add a word (language id or en) checked by the profanity rule in addition to the built in wordlists. end the word with * to also match words starting with it
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param Body body contentFilterWordInput true "the body to add a word"
+// @Produce json
+// @Success 200 {object} models.ContentFilterWord
+// @Router /admin/content-filter/words [post]
+func CreateContentFilterWord(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input contentFilterWordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		c.JSON(http.StatusBadRequest, utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		return
+	}
+
+	word := strings.ToLower(strings.TrimSpace(input.Word))
+	language := strings.ToLower(input.Language)
+	if strings.Trim(word, "*") == "" || strings.ContainsAny(word, " \t\n") {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("word harus satu kata", http.StatusBadRequest, nil))
+		return
+	}
+	if !slices.Contains(contentfilter.Languages, language) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("language harus "+strings.Join(contentfilter.Languages, " atau "), http.StatusBadRequest, nil))
+		return
+	}
+
+	var count int64
+	db.Model(&models.ContentFilterWord{}).Where("word = ? AND language = ?", word, language).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.MsgAlreadyExist("word"), http.StatusBadRequest, nil))
+		return
+	}
+
+	word_data := models.ContentFilterWord{Word: word, Language: language}
+	if err := db.Create(&word_data).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgAdded("word"), http.StatusOK, word_data))
+}
+
+// Delete content filter word godoc
+// @Summary Remove a word from the profanity filter. (ADMIN ONLY)
+// @Description Remove a word added by an admin, built in words can not be removed (disable the profanity rule instead)
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "word id"
+// @Produce json
+// @Success 200 {object} map[string]boolean
+// @Router /admin/content-filter/words/{id} [delete]
+func DeleteContentFilterWord(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var word models.ContentFilterWord
+	if err := db.Where("id = ?", c.Param("id")).First(&word).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("word"), http.StatusNotFound, nil))
+		return
+	}
+
+	if err := db.Delete(&word).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgDeleted("word"), http.StatusOK, nil))
+}
+
+// Test content filter godoc
+// @Summary Try the content filter on a text. (ADMIN ONLY)
+// @Description Run the content filter on a text without saving anything, returns the matching rules, the resulting action and the masked content. the duplicate rule compares with all saved reviews & comments
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param Body body contentFilterTestInput true "the text to check"
+// @Produce json
+// @Success 200 {object} contentfilter.Result
+// @Router /admin/content-filter/test [post]
+func TestContentFilter(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input contentFilterTestInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		c.JSON(http.StatusBadRequest, utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		return
+	}
+
+	result, err := contentfilter.Run(db, contentfilter.Input{Content: input.Content})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, result))
+}
+
+// filterContent jalankan filter konten, konten yg ditolak langsung dibalas 400
+func filterContent(c *gin.Context, db *gorm.DB, input contentfilter.Input) (contentfilter.Result, bool) {
+	result, err := contentfilter.Run(db, input)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return result, false
+	}
+
+	if result.Action == contentfilter.ActionReject {
+		var rules []string
+		for _, match := range result.Matches {
+			if match.Action == contentfilter.ActionReject {
+				rules = append(rules, match.Rule)
+			}
+		}
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("konten ditolak oleh filter: "+strings.Join(rules, ", "), http.StatusBadRequest, nil))
+		return result, false
+	}
+	return result, true
+}
@@ -21,7 +21,7 @@ import (
 func GetAllDataCount(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
 
-	var numUsers, numAdmins, numPhones, numBrands, numReviews int64
+	var numUsers, numAdmins, numPhones, numBrands, numReviews, numPendingReviews int64
 
 	if err := db.Model(&models.User{}).Where("role_id = ?", 1).Count(&numUsers).Error; err != nil {
 		c.JSON(http.StatusInternalServerError,
@@ -48,6 +48,11 @@ func GetAllDataCount(c *gin.Context) {
 			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
 		return
 	}
+	if err := db.Model(&models.Review{}).Where("status = ?", models.ReviewStatusPending).Count(&numPendingReviews).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
 
 	response := []map[string]any{
 		{
@@ -70,6 +75,10 @@ func GetAllDataCount(c *gin.Context) {
 			"name":        "reviews",
 			"num_of_data": int(numReviews),
 		},
+		{
+			"name":        "pending reviews",
+			"num_of_data": int(numPendingReviews),
+		},
 	}
 
 	c.JSON(http.StatusOK,
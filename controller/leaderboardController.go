@@ -0,0 +1,490 @@
+package controller
+
+import (
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	LeaderboardBayesian = "bayesian"
+	LeaderboardWilson   = "wilson"
+
+	defaultLeaderboardLimit = 10
+	maxLeaderboardLimit     = 100
+	// z-score wilson lower bound (tingkat kepercayaan 95%)
+	wilsonZ = 1.96
+)
+
+// PriceBand rentang harga dasar phone (IDR) untuk leaderboard per harga
+type PriceBand struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	MinPrice uint   `json:"min_price"`
+	// 0 berarti tanpa batas atas
+	MaxPrice uint `json:"max_price"`
+}
+
+var priceBands = []PriceBand{
+	{Key: "budget", Name: "Budget", MinPrice: 0, MaxPrice: 3000000},
+	{Key: "mid-range", Name: "Mid-range", MinPrice: 3000000, MaxPrice: 7000000},
+	{Key: "upper-mid-range", Name: "Upper mid-range", MinPrice: 7000000, MaxPrice: 12000000},
+	{Key: "flagship", Name: "Flagship", MinPrice: 12000000},
+}
+
+type LeaderboardEntry struct {
+	Rank        int       `json:"rank"`
+	PhoneID     uint      `json:"phone_id"`
+	Slug        string    `json:"slug"`
+	BrandID     uint      `json:"brand_id"`
+	BrandName   string    `json:"brand_name"`
+	PhoneModel  string    `json:"phone_model"`
+	PhoneImage  string    `json:"phone_image"`
+	Price       float64   `json:"price"`
+	ReleaseDate time.Time `json:"release_date"`
+	ReviewCount int64     `json:"review_count"`
+	// rata-rata rating mentah
+	AVGRating float64 `json:"avg_rating"`
+	// skor yg sudah disesuaikan dgn jumlah review (skala 1 - 5)
+	Score     float64 `json:"score"`
+	RatingSum float64 `json:"-"`
+}
+
+type LeaderboardGroup struct {
+	Key     string             `json:"key"`
+	Name    string             `json:"name"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+type LeaderboardResponse struct {
+	Method string `json:"method"`
+	// rata-rata & bobot prior yg dipakai metode bayesian
+	PriorMean   float64            `json:"prior_mean"`
+	PriorWeight float64            `json:"prior_weight"`
+	Entries     []LeaderboardEntry `json:"entries,omitempty"`
+	Groups      []LeaderboardGroup `json:"groups,omitempty"`
+}
+
+type leaderboardOptions struct {
+	Method      string
+	PriorMean   float64
+	PriorWeight float64
+	MinReviews  int64
+	Limit       int
+}
+
+// Get leaderboard godoc
+// @Summary Get phone leaderboard. (PUBLIC)
+// @Description Get the best rated phones ranked by a score adjusted for the number of reviews, so a phone with a single 5 star review does not outrank a phone with hundreds of 4.8 reviews. method bayesian (default): (prior_weight * prior_mean + sum of ratings) / (prior_weight + reviews). method wilson: lower bound of the 95% wilson interval of the rating scaled to 0 - 1, mapped back to 1 - 5. default prior comes from LEADERBOARD_PRIOR_MEAN (empty = average of all reviews) and LEADERBOARD_PRIOR_WEIGHT
+// @Tags Leaderboards
+// @Produce json
+// @Param method query string false "bayesian / wilson, default bayesian"
+// @Param prior_mean query number false "bayesian prior mean (1 - 5)"
+// @Param prior_weight query number false "bayesian prior weight (number of virtual reviews)"
+// @Param min_reviews query int false "minimum number of reviews, default 1"
+// @Param limit query int false "number of phones, default 10, max 100"
+// @Param brand_id query int false "only phones of this brand"
+// @Param category query string false "only phones in this category slug"
+// @Param price_band query string false "budget / mid-range / upper-mid-range / flagship"
+// @Param year query int false "only phones released in this year"
+// @Success 200 {object} LeaderboardResponse
+// @Router /leaderboards [get]
+func GetLeaderboard(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	options, ok := leaderboardQueryOptions(c, db)
+	if !ok {
+		return
+	}
+
+	query := leaderboardQuery(db)
+
+	if value := c.Query("brand_id"); value != "" {
+		brandID, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON("brand_id harus berupa angka", http.StatusBadRequest, nil))
+			return
+		}
+		query = query.Where("phones.brand_id = ?", brandID)
+	}
+	if value := c.Query("category"); value != "" {
+		query = query.Where("phones.id IN (?)", db.Table("phone_categories").
+			Select("phone_categories.phone_id").
+			Joins("JOIN categories on categories.id = phone_categories.category_id").
+			Where("categories.slug = ?", value))
+	}
+	if value := c.Query("price_band"); value != "" {
+		band, ok := findPriceBand(value)
+		if !ok {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON("price_band harus salah satu dari: "+priceBandKeys(), http.StatusBadRequest, nil))
+			return
+		}
+		query = applyPriceBand(query, band)
+	}
+	if value := c.Query("year"); value != "" {
+		year, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON("year harus berupa angka", http.StatusBadRequest, nil))
+			return
+		}
+		query = applyReleaseYear(query, year)
+	}
+
+	var entries []LeaderboardEntry
+	if err := query.Scan(&entries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, LeaderboardResponse{
+		Method:      options.Method,
+		PriorMean:   options.PriorMean,
+		PriorWeight: options.PriorWeight,
+		Entries:     rankLeaderboard(entries, options),
+	}))
+}
+
+// Get grouped leaderboard godoc
+// @Summary Get phone leaderboards per brand, category, price band or release year. (PUBLIC)
+// @Description Get the top phones of every brand, category, price band or release year, ranked with the same adjusted score as /leaderboards
+// @Tags Leaderboards
+// @Produce json
+// @Param dimension path string true "brands / categories / price-bands / years"
+// @Param method query string false "bayesian / wilson, default bayesian"
+// @Param prior_mean query number false "bayesian prior mean (1 - 5)"
+// @Param prior_weight query number false "bayesian prior weight (number of virtual reviews)"
+// @Param min_reviews query int false "minimum number of reviews, default 1"
+// @Param limit query int false "number of phones per group, default 10, max 100"
+// @Success 200 {object} LeaderboardResponse
+// @Router /leaderboards/{dimension} [get]
+func GetGroupedLeaderboard(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	dimension := c.Param("dimension")
+	if !slices.Contains([]string{"brands", "categories", "price-bands", "years"}, dimension) {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("leaderboard "+dimension), http.StatusNotFound, nil))
+		return
+	}
+
+	options, ok := leaderboardQueryOptions(c, db)
+	if !ok {
+		return
+	}
+
+	var entries []LeaderboardEntry
+	if err := leaderboardQuery(db).Scan(&entries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	groups := []LeaderboardGroup{}
+	members := map[string][]LeaderboardEntry{}
+
+	switch dimension {
+	case "brands":
+		for _, entry := range entries {
+			key := strconv.Itoa(int(entry.BrandID))
+			if _, exist := members[key]; !exist {
+				groups = append(groups, LeaderboardGroup{Key: key, Name: entry.BrandName})
+			}
+			members[key] = append(members[key], entry)
+		}
+		sort.SliceStable(groups, func(i, j int) bool {
+			return strings.ToLower(groups[i].Name) < strings.ToLower(groups[j].Name)
+		})
+	case "categories":
+		var categories []models.Category
+		if err := db.Order("name ASC").Find(&categories).Error; err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+		var links []struct {
+			PhoneID    uint
+			CategoryID uint
+		}
+		if err := db.Table("phone_categories").Select("phone_id, category_id").Scan(&links).Error; err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+		byPhone := map[uint]LeaderboardEntry{}
+		for _, entry := range entries {
+			byPhone[entry.PhoneID] = entry
+		}
+		for _, category := range categories {
+			groups = append(groups, LeaderboardGroup{Key: category.Slug, Name: category.Name})
+		}
+		slugByID := map[uint]string{}
+		for _, category := range categories {
+			slugByID[category.ID] = category.Slug
+		}
+		for _, link := range links {
+			if entry, ok := byPhone[link.PhoneID]; ok {
+				members[slugByID[link.CategoryID]] = append(members[slugByID[link.CategoryID]], entry)
+			}
+		}
+	case "price-bands":
+		for _, band := range priceBands {
+			groups = append(groups, LeaderboardGroup{Key: band.Key, Name: band.Name})
+		}
+		for _, entry := range entries {
+			for _, band := range priceBands {
+				if inPriceBand(entry.Price, band) {
+					members[band.Key] = append(members[band.Key], entry)
+					break
+				}
+			}
+		}
+	case "years":
+		for _, entry := range entries {
+			// phone tanpa tanggal rilis tidak masuk leaderboard per tahun
+			if entry.ReleaseDate.Before(unscheduledReleaseDate) {
+				continue
+			}
+			key := strconv.Itoa(entry.ReleaseDate.In(time.Local).Year())
+			if _, exist := members[key]; !exist {
+				groups = append(groups, LeaderboardGroup{Key: key, Name: key})
+			}
+			members[key] = append(members[key], entry)
+		}
+		// tahun terbaru lebih dulu
+		sort.SliceStable(groups, func(i, j int) bool { return groups[i].Key > groups[j].Key })
+	}
+
+	result := []LeaderboardGroup{}
+	for _, group := range groups {
+		group.Entries = rankLeaderboard(members[group.Key], options)
+		if len(group.Entries) > 0 {
+			result = append(result, group)
+		}
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, LeaderboardResponse{
+		Method:      options.Method,
+		PriorMean:   options.PriorMean,
+		PriorWeight: options.PriorWeight,
+		Groups:      result,
+	}))
+}
+
+// leaderboardQuery jumlah review & total rating tiap phone yg sudah dipublish
+func leaderboardQuery(db *gorm.DB) *gorm.DB {
+	return db.Table("phones").
+		Select(`phones.id as phone_id,
+				phones.slug,
+				brands.id as brand_id,
+				brands.name as brand_name,
+				phones.model as phone_model,
+				phones.image_url as phone_image,
+				phones.price,
+				phones.release_date,
+				COUNT(reviews.id) as review_count,
+				COALESCE(SUM(reviews.rating), 0) as rating_sum`).
+		Joins("JOIN brands on brands.id = phones.brand_id").
+		Joins("LEFT JOIN reviews on reviews.phone_id = phones.id AND reviews.status = ?", models.ReviewStatusApproved).
+		Where("phones.status <> ?", models.PhoneStatusDraft).
+		Group("phones.id, phones.slug, brands.id, brands.name, phones.model, phones.image_url, phones.price, phones.release_date")
+}
+
+// leaderboardQueryOptions baca metode & prior dari query, default prior dari env
+func leaderboardQueryOptions(c *gin.Context, db *gorm.DB) (leaderboardOptions, bool) {
+	options := leaderboardOptions{
+		Method:     strings.ToLower(c.DefaultQuery("method", LeaderboardBayesian)),
+		MinReviews: 1,
+		Limit:      defaultLeaderboardLimit,
+	}
+	if options.Method != LeaderboardBayesian && options.Method != LeaderboardWilson {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("method harus bayesian atau wilson", http.StatusBadRequest, nil))
+		return options, false
+	}
+
+	parse := func(key, fallback string, min, max float64) (float64, bool) {
+		value := c.DefaultQuery(key, fallback)
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || number < min || number > max || math.IsNaN(number) {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(fmt.Sprintf("%s harus berupa angka %g - %g", key, min, max), http.StatusBadRequest, nil))
+			return 0, false
+		}
+		return number, true
+	}
+
+	defaultMean, defaultWeight, err := defaultLeaderboardPrior(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return options, false
+	}
+
+	weight, ok := parse("prior_weight", strconv.FormatFloat(defaultWeight, 'f', -1, 64), 0, 1e6)
+	if !ok {
+		return options, false
+	}
+	options.PriorWeight = weight
+
+	mean, ok := parse("prior_mean", strconv.FormatFloat(defaultMean, 'f', -1, 64), 1, 5)
+	if !ok {
+		return options, false
+	}
+	options.PriorMean = math.Round(mean*100) / 100
+
+	minReviews, ok := parse("min_reviews", "1", 0, math.MaxInt32)
+	if !ok {
+		return options, false
+	}
+	options.MinReviews = int64(minReviews)
+
+	limit, ok := parse("limit", strconv.Itoa(defaultLeaderboardLimit), 1, maxLeaderboardLimit)
+	if !ok {
+		return options, false
+	}
+	options.Limit = int(limit)
+
+	return options, true
+}
+
+// defaultLeaderboardPrior prior bayesian dari env LEADERBOARD_PRIOR_MEAN
+// (kosong = rata-rata semua review) & LEADERBOARD_PRIOR_WEIGHT, nilai env yg
+// tidak valid diganti nilai default
+func defaultLeaderboardPrior(db *gorm.DB) (float64, float64, error) {
+	weight, err := strconv.ParseFloat(utils.GetEnv("LEADERBOARD_PRIOR_WEIGHT", "10"), 64)
+	if err != nil || weight < 0 || weight > 1e6 || math.IsNaN(weight) {
+		weight = 10
+	}
+
+	mean, err := strconv.ParseFloat(utils.GetEnv("LEADERBOARD_PRIOR_MEAN", ""), 64)
+	if err != nil || mean < 1 || mean > 5 || math.IsNaN(mean) {
+		// default prior mean rata-rata semua review
+		var average struct{ Value float64 }
+		if err := approvedReviews(db.Model(&models.Review{})).Select("COALESCE(AVG(rating), 3) as value").Scan(&average).Error; err != nil {
+			return 0, 0, err
+		}
+		mean = average.Value
+	}
+	return mean, weight, nil
+}
+
+// rankLeaderboard hitung skor, urutkan & ambil phone teratas
+func rankLeaderboard(entries []LeaderboardEntry, options leaderboardOptions) []LeaderboardEntry {
+	ranked := []LeaderboardEntry{}
+	for _, entry := range entries {
+		if entry.ReviewCount < options.MinReviews {
+			continue
+		}
+		if entry.ReviewCount > 0 {
+			entry.AVGRating = math.Round(entry.RatingSum/float64(entry.ReviewCount)*100) / 100
+		}
+		switch options.Method {
+		case LeaderboardWilson:
+			entry.Score = wilsonScore(entry.RatingSum, entry.ReviewCount)
+		default:
+			entry.Score = bayesianScore(entry.RatingSum, entry.ReviewCount, options.PriorMean, options.PriorWeight)
+		}
+		entry.Score = math.Round(entry.Score*10000) / 10000
+		ranked = append(ranked, entry)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		if ranked[i].ReviewCount != ranked[j].ReviewCount {
+			return ranked[i].ReviewCount > ranked[j].ReviewCount
+		}
+		return ranked[i].PhoneID < ranked[j].PhoneID
+	})
+	if len(ranked) > options.Limit {
+		ranked = ranked[:options.Limit]
+	}
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+	}
+
+	return ranked
+}
+
+// bayesianScore rata-rata rating yg ditarik ke prior mean, makin sedikit
+// review makin dekat ke prior mean
+func bayesianScore(ratingSum float64, reviews int64, priorMean, priorWeight float64) float64 {
+	if float64(reviews)+priorWeight == 0 {
+		return 0
+	}
+	return (priorWeight*priorMean + ratingSum) / (priorWeight + float64(reviews))
+}
+
+// wilsonScore batas bawah interval wilson dari rating yg dinormalisasi ke 0 - 1,
+// dikembalikan ke skala 1 - 5
+func wilsonScore(ratingSum float64, reviews int64) float64 {
+	if reviews == 0 {
+		return 0
+	}
+	n := float64(reviews)
+	// rating 1 - 5 dianggap proporsi positif 0 - 1
+	return 1 + 4*wilsonLowerBound((ratingSum-n)/4, n)
+}
+
+// wilsonLowerBound batas bawah interval wilson proporsi positive dari total
+// (0 - 1), dipakai leaderboard & helpful score review
+func wilsonLowerBound(positive, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	p := positive / total
+	z2 := wilsonZ * wilsonZ
+	lower := (p + z2/(2*total) - wilsonZ*math.Sqrt((p*(1-p)+z2/(4*total))/total)) / (1 + z2/total)
+	return math.Max(lower, 0)
+}
+
+func findPriceBand(key string) (PriceBand, bool) {
+	for _, band := range priceBands {
+		if band.Key == key {
+			return band, true
+		}
+	}
+	return PriceBand{}, false
+}
+
+func priceBandKeys() string {
+	keys := make([]string, len(priceBands))
+	for i, band := range priceBands {
+		keys[i] = band.Key
+	}
+	return strings.Join(keys, ", ")
+}
+
+func inPriceBand(price float64, band PriceBand) bool {
+	return price >= float64(band.MinPrice) && (band.MaxPrice == 0 || price < float64(band.MaxPrice))
+}
+
+func applyPriceBand(query *gorm.DB, band PriceBand) *gorm.DB {
+	query = query.Where("phones.price >= ?", band.MinPrice)
+	if band.MaxPrice != 0 {
+		query = query.Where("phones.price < ?", band.MaxPrice)
+	}
+	return query
+}
+
+// applyReleaseYear filter phone yg rilis di tahun tsb
+func applyReleaseYear(query *gorm.DB, year int) *gorm.DB {
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, time.Local)
+	return query.Where("phones.release_date >= ? AND phones.release_date < ?", start, start.AddDate(1, 0, 0))
+}
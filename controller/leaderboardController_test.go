@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBayesianScore(t *testing.T) {
+	tests := []struct {
+		name        string
+		ratingSum   float64
+		reviews     int64
+		priorMean   float64
+		priorWeight float64
+		want        float64
+	}{
+		{"tanpa review sama dgn prior", 0, 0, 3.5, 10, 3.5},
+		{"tanpa prior sama dgn rata-rata", 45, 10, 3, 0, 4.5},
+		{"satu review 5 ditarik ke prior", 5, 1, 3, 9, 3.2},
+		{"banyak review mendekati rata-rata", 4600, 1000, 3, 10, (30 + 4600) / 1010.0},
+		{"tanpa review & tanpa prior", 0, 0, 3, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bayesianScore(tt.ratingSum, tt.reviews, tt.priorMean, tt.priorWeight)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("bayesianScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWilsonLowerBound(t *testing.T) {
+	z2 := wilsonZ * wilsonZ
+	tests := []struct {
+		name     string
+		positive float64
+		total    float64
+		want     float64
+	}{
+		{"tanpa data", 0, 0, 0},
+		{"semua negatif", 0, 10, 0},
+		// p = 1: (1 + z²/2n - z·z/2n) / (1 + z²/n) = 1 / (1 + z²/n)
+		{"satu positif", 1, 1, 1 / (1 + z2)},
+		{"seratus positif", 100, 100, 1 / (1 + z2/100)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wilsonLowerBound(tt.positive, tt.total)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("wilsonLowerBound(%v, %v) = %v, want %v", tt.positive, tt.total, got, tt.want)
+			}
+		})
+	}
+
+	// proporsi sama dgn data lebih banyak lebih dipercaya
+	if wilsonLowerBound(9, 10) >= wilsonLowerBound(90, 100) {
+		t.Error("wilsonLowerBound(9, 10) harus lebih kecil dari wilsonLowerBound(90, 100)")
+	}
+}
+
+func TestWilsonScore(t *testing.T) {
+	if got := wilsonScore(0, 0); got != 0 {
+		t.Errorf("wilsonScore tanpa review = %v, want 0", got)
+	}
+	// semua rating 1 tetap di batas bawah skala
+	if got := wilsonScore(10, 10); got != 1 {
+		t.Errorf("wilsonScore semua rating 1 = %v, want 1", got)
+	}
+	// satu review 5 tidak mengalahkan ratusan review rata-rata 4.6
+	single, many := wilsonScore(5, 1), wilsonScore(4.6*300, 300)
+	if single >= many {
+		t.Errorf("wilsonScore satu review 5 (%v) >= 300 review 4.6 (%v)", single, many)
+	}
+	if many > 4.6 || many < 4.4 {
+		t.Errorf("wilsonScore 300 review 4.6 = %v, want mendekati 4.6", many)
+	}
+}
+
+func TestRankLeaderboard(t *testing.T) {
+	entries := []LeaderboardEntry{
+		{PhoneID: 1, ReviewCount: 1, RatingSum: 5},
+		{PhoneID: 2, ReviewCount: 300, RatingSum: 4.6 * 300},
+		{PhoneID: 3, ReviewCount: 0},
+		{PhoneID: 4, ReviewCount: 20, RatingSum: 60},
+		{PhoneID: 5, ReviewCount: 300, RatingSum: 4.6 * 300},
+	}
+
+	// skor sama diurutkan id, phone tanpa review dilewati (min_reviews 1).
+	// bayesian menarik satu review 5 ke prior 3.5, wilson lebih keras
+	// terhadap phone dgn sedikit review
+	tests := map[string][]uint{
+		LeaderboardBayesian: {2, 5, 1},
+		LeaderboardWilson:   {2, 5, 4},
+	}
+	for method, want := range tests {
+		t.Run(method, func(t *testing.T) {
+			ranked := rankLeaderboard(entries, leaderboardOptions{
+				Method:      method,
+				PriorMean:   3.5,
+				PriorWeight: 10,
+				MinReviews:  1,
+				Limit:       3,
+			})
+			ids := []uint{}
+			for i, entry := range ranked {
+				ids = append(ids, entry.PhoneID)
+				if entry.Rank != i+1 {
+					t.Errorf("rank %d = %d", i, entry.Rank)
+				}
+			}
+			for i := range want {
+				if i >= len(ids) || ids[i] != want[i] {
+					t.Fatalf("urutan = %v, want %v", ids, want)
+				}
+			}
+			if ranked[0].AVGRating != 4.6 {
+				t.Errorf("avg_rating = %v, want 4.6", ranked[0].AVGRating)
+			}
+		})
+	}
+}
+
+func TestInPriceBand(t *testing.T) {
+	tests := []struct {
+		price float64
+		key   string
+		want  bool
+	}{
+		{2999999, "budget", true},
+		{3000000, "budget", false},
+		{3000000, "mid-range", true},
+		{12000000, "upper-mid-range", false},
+		{50000000, "flagship", true},
+	}
+	for _, tt := range tests {
+		band, ok := findPriceBand(tt.key)
+		if !ok {
+			t.Fatalf("price band %s tidak ditemukan", tt.key)
+		}
+		if got := inPriceBand(tt.price, band); got != tt.want {
+			t.Errorf("inPriceBand(%v, %s) = %v, want %v", tt.price, tt.key, got, tt.want)
+		}
+	}
+	if _, ok := findPriceBand("unknown"); ok {
+		t.Error("findPriceBand(unknown) harus tidak ditemukan")
+	}
+}
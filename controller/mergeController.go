@@ -0,0 +1,579 @@
+package controller
+
+import (
+	"errors"
+	"final-project/lib"
+	"final-project/models"
+	"final-project/slug"
+	"final-project/utils"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	MergeKeepTarget  = "keep_target"
+	MergeKeepSource  = "keep_source"
+	MergeKeepNewest  = "keep_newest"
+	MergeKeepHighest = "keep_highest"
+)
+
+// MergeReviewStrategies cara memilih review yg dipertahankan jika user yg sama
+// sudah mereview phone source & target
+var MergeReviewStrategies = []string{MergeKeepTarget, MergeKeepSource, MergeKeepNewest, MergeKeepHighest}
+
+type phoneMergeInput struct {
+	SourceID uint `json:"source_id" binding:"required"`
+	TargetID uint `json:"target_id" binding:"required"`
+	// keep_target (default) / keep_source / keep_newest / keep_highest
+	ReviewStrategy string `json:"review_strategy"`
+}
+
+type brandMergeInput struct {
+	SourceID uint `json:"source_id" binding:"required"`
+	TargetID uint `json:"target_id" binding:"required"`
+}
+
+type PhoneMergeReport struct {
+	SourceID       uint   `json:"source_id"`
+	TargetID       uint   `json:"target_id"`
+	ReviewStrategy string `json:"review_strategy"`
+	ReviewsMoved   int    `json:"reviews_moved"`
+	// review yg kalah karena user yg sama sudah mereview phone lain
+	ReviewsDropped        int `json:"reviews_dropped"`
+	CommentsMoved         int `json:"comments_moved"`
+	CommentsDropped       int `json:"comments_dropped"`
+	SpecificationsMoved   int `json:"specifications_moved"`
+	SpecificationsDropped int `json:"specifications_dropped"`
+	ImagesMoved           int `json:"images_moved"`
+	VariantsMoved         int `json:"variants_moved"`
+	// variant source yg sama persis dgn variant target digabung ke variant target
+	VariantsMerged    int      `json:"variants_merged"`
+	PricesMoved       int      `json:"prices_moved"`
+	PricesDropped     int      `json:"prices_dropped"`
+	AttributesMoved   int      `json:"attributes_moved"`
+	AttributesDropped int      `json:"attributes_dropped"`
+	CategoriesAdded   int      `json:"categories_added"`
+	TagsAdded         int      `json:"tags_added"`
+	Redirects         []string `json:"redirects"`
+	// file foto review yg kalah, dihapus dari storage setelah transaksi berhasil
+	removedPhotoURLs []string
+}
+
+type BrandMergeReport struct {
+	SourceID        uint     `json:"source_id"`
+	TargetID        uint     `json:"target_id"`
+	PhonesMoved     int      `json:"phones_moved"`
+	SubBrandsMoved  int      `json:"sub_brands_moved"`
+	ReviewsMoved    int64    `json:"reviews_moved"`
+	PhoneSlugsMoved []string `json:"phone_slugs_moved"`
+	Redirects       []string `json:"redirects"`
+}
+
+var errMergeSameData = errors.New("source_id dan target_id tidak boleh sama")
+
+// Merge phones godoc
+// @Summary Merge a duplicate phone into another phone (ADMIN ONLY)
+// @Description Move reviews (with their comments), specifications, images, variants, regional prices, attributes, categories & tags from the source phone to the target phone in one transaction, then delete the source phone. review_strategy picks the review kept when the same user reviewed both phones: keep_target (default), keep_source, keep_newest or keep_highest (rating). specifications are only moved when the target has none, prices & attributes the target already has are kept. the old id & slug of the source phone are redirected to the target phone
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param Body body phoneMergeInput true "the body to merge phones"
+// @Produce json
+// @Success 200 {object} PhoneMergeReport
+// @Router /admin/phones/merge [post]
+func MergePhones(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input phoneMergeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		c.JSON(http.StatusBadRequest, utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		return
+	}
+	if input.SourceID == input.TargetID {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(errMergeSameData.Error(), http.StatusBadRequest, nil))
+		return
+	}
+	if input.ReviewStrategy == "" {
+		input.ReviewStrategy = MergeKeepTarget
+	}
+	if !slices.Contains(MergeReviewStrategies, input.ReviewStrategy) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("review_strategy harus keep_target, keep_source, keep_newest atau keep_highest", http.StatusBadRequest, nil))
+		return
+	}
+
+	var source, target models.Phone
+	if err := db.Where("id = ?", input.SourceID).First(&source).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone source"), http.StatusNotFound, nil))
+		return
+	}
+	if err := db.Where("id = ?", input.TargetID).First(&target).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone target"), http.StatusNotFound, nil))
+		return
+	}
+
+	report := PhoneMergeReport{SourceID: source.ID, TargetID: target.ID, ReviewStrategy: input.ReviewStrategy}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return mergePhone(tx, source, target, input.ReviewStrategy, &report)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	refreshValueScores(db, target.ID)
+	// specification source bisa pindah ke target
+	refreshPhoneFeatures(db, target.ID)
+	removeReviewPhotoFiles(c, report.removedPhotoURLs)
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("phone berhasil digabung", http.StatusOK, report))
+}
+
+// Merge brands godoc
+// @Summary Merge a duplicate brand into another brand (ADMIN ONLY)
+// @Description Move all phones (with their reviews) and sub brands from the source brand to the target brand in one transaction, then delete the source brand. phone slugs are regenerated with the target brand name, old phone slugs keep redirecting. the old id & slug of the source brand are redirected to the target brand. duplicate phones inside the merged brand can then be merged with /admin/phones/merge
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param Body body brandMergeInput true "the body to merge brands"
+// @Produce json
+// @Success 200 {object} BrandMergeReport
+// @Router /admin/brands/merge [post]
+func MergeBrands(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input brandMergeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		c.JSON(http.StatusBadRequest, utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		return
+	}
+	if input.SourceID == input.TargetID {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(errMergeSameData.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	var source, target models.Brand
+	if err := db.Where("id = ?", input.SourceID).First(&source).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("brand source"), http.StatusNotFound, nil))
+		return
+	}
+	if err := db.Where("id = ?", input.TargetID).First(&target).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("brand target"), http.StatusNotFound, nil))
+		return
+	}
+
+	report := BrandMergeReport{SourceID: source.ID, TargetID: target.ID, PhoneSlugsMoved: []string{}}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return mergeBrand(tx, source, target, &report)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("brand berhasil digabung", http.StatusOK, report))
+}
+
+func mergePhone(tx *gorm.DB, source, target models.Phone, strategy string, report *PhoneMergeReport) error {
+	if err := mergePhoneVariants(tx, source.ID, target.ID, report); err != nil {
+		return err
+	}
+	if err := mergePhoneReviews(tx, source.ID, target.ID, strategy, report); err != nil {
+		return err
+	}
+
+	// specification hanya dipindah jika target belum punya
+	var specCount int64
+	if err := tx.Model(&models.Specification{}).Where("phone_id = ?", target.ID).Count(&specCount).Error; err != nil {
+		return err
+	}
+	if specCount == 0 {
+		result := tx.Model(&models.Specification{}).Where("phone_id = ?", source.ID).Update("phone_id", target.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		report.SpecificationsMoved = int(result.RowsAffected)
+	} else {
+		if err := tx.Model(&models.Specification{}).Where("phone_id = ?", source.ID).Count(&specCount).Error; err != nil {
+			return err
+		}
+		report.SpecificationsDropped = int(specCount)
+	}
+
+	if err := mergePhoneImages(tx, source, target, report); err != nil {
+		return err
+	}
+
+	// harga region & atribut yg sudah dimiliki target dipertahankan
+	var prices []models.PhonePrice
+	if err := tx.Where("phone_id = ?", source.ID).Find(&prices).Error; err != nil {
+		return err
+	}
+	for _, price := range prices {
+		var count int64
+		if err := tx.Model(&models.PhonePrice{}).Where("phone_id = ? AND region = ?", target.ID, price.Region).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			report.PricesDropped++
+			continue
+		}
+		if err := tx.Model(&price).Update("phone_id", target.ID).Error; err != nil {
+			return err
+		}
+		report.PricesMoved++
+	}
+
+	var attributes []models.SpecAttributeValue
+	if err := tx.Where("phone_id = ?", source.ID).Find(&attributes).Error; err != nil {
+		return err
+	}
+	for _, attribute := range attributes {
+		var count int64
+		if err := tx.Model(&models.SpecAttributeValue{}).Where("phone_id = ? AND attribute_id = ?", target.ID, attribute.AttributeID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			report.AttributesDropped++
+			continue
+		}
+		if err := tx.Model(&attribute).Update("phone_id", target.ID).Error; err != nil {
+			return err
+		}
+		report.AttributesMoved++
+	}
+
+	var sourcePhone models.Phone
+	if err := tx.Preload("Categories").Preload("Tags").First(&sourcePhone, source.ID).Error; err != nil {
+		return err
+	}
+	var targetPhone models.Phone
+	if err := tx.Preload("Categories").Preload("Tags").First(&targetPhone, target.ID).Error; err != nil {
+		return err
+	}
+	categories := []models.Category{}
+	for _, category := range sourcePhone.Categories {
+		if !slices.ContainsFunc(targetPhone.Categories, func(item models.Category) bool { return item.ID == category.ID }) {
+			categories = append(categories, category)
+		}
+	}
+	tags := []models.Tag{}
+	for _, tag := range sourcePhone.Tags {
+		if !slices.ContainsFunc(targetPhone.Tags, func(item models.Tag) bool { return item.ID == tag.ID }) {
+			tags = append(tags, tag)
+		}
+	}
+	if len(categories) > 0 {
+		if err := tx.Model(&targetPhone).Association("Categories").Append(&categories); err != nil {
+			return err
+		}
+	}
+	if len(tags) > 0 {
+		if err := tx.Model(&targetPhone).Association("Tags").Append(&tags); err != nil {
+			return err
+		}
+	}
+	report.CategoriesAdded, report.TagsAdded = len(categories), len(tags)
+
+	// rekomendasi dihitung ulang oleh worker
+	if err := tx.Where("phone_id = ?", source.ID).Delete(&models.UserRecommendation{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM phone_categories WHERE phone_id = ?", source.ID).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM phone_tags WHERE phone_id = ?", source.ID).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(&source).Error; err != nil {
+		return err
+	}
+
+	if err := slug.Merge(tx, slug.KindPhone, source.ID, source.Slug, target.ID); err != nil {
+		return err
+	}
+	report.Redirects = mergeRedirects(source.ID, source.Slug)
+	return nil
+}
+
+// mergePhoneVariants pindahkan variant source, variant yg sama persis dgn variant
+// target digabung & review-nya diarahkan ke variant target
+func mergePhoneVariants(tx *gorm.DB, sourceID, targetID uint, report *PhoneMergeReport) error {
+	var variants []models.PhoneVariant
+	if err := tx.Where("phone_id = ?", sourceID).Find(&variants).Error; err != nil {
+		return err
+	}
+
+	for _, variant := range variants {
+		var same models.PhoneVariant
+		err := tx.Where("phone_id = ? AND memory = ? AND storage = ? AND color = ?",
+			targetID, variant.Memory, variant.Storage, variant.Color).First(&same).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := tx.Model(&variant).Update("phone_id", targetID).Error; err != nil {
+				return err
+			}
+			report.VariantsMoved++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Review{}).Where("variant_id = ?", variant.ID).Update("variant_id", same.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&variant).Error; err != nil {
+			return err
+		}
+		report.VariantsMerged++
+	}
+	return nil
+}
+
+// mergePhoneReviews pindahkan review source, jika user yg sama sudah mereview
+// target hanya satu review yg dipertahankan sesuai strategy
+func mergePhoneReviews(tx *gorm.DB, sourceID, targetID uint, strategy string, report *PhoneMergeReport) error {
+	var reviews []models.Review
+	if err := tx.Where("phone_id = ?", sourceID).Find(&reviews).Error; err != nil {
+		return err
+	}
+
+	for _, review := range reviews {
+		var existing models.Review
+		err := tx.Where("phone_id = ? AND user_id = ?", targetID, review.UserID).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err == nil {
+			drop := review
+			if keepSourceReview(review, existing, strategy) {
+				drop = existing
+			}
+			photoURLs, err := reviewPhotoURLs(tx, tx.Model(&models.Review{}).Select("id").Where("id = ?", drop.ID))
+			if err != nil {
+				return err
+			}
+			comments, err := deleteMergedReview(tx, drop)
+			if err != nil {
+				return err
+			}
+			report.removedPhotoURLs = append(report.removedPhotoURLs, photoURLs...)
+			report.ReviewsDropped++
+			report.CommentsDropped += comments
+			if drop.ID == review.ID {
+				continue
+			}
+		}
+
+		if err := tx.Model(&review).Update("phone_id", targetID).Error; err != nil {
+			return err
+		}
+		var comments int64
+		if err := tx.Model(&models.Comment{}).Where("review_id = ?", review.ID).Count(&comments).Error; err != nil {
+			return err
+		}
+		report.ReviewsMoved++
+		report.CommentsMoved += int(comments)
+	}
+	return nil
+}
+
+func keepSourceReview(source, target models.Review, strategy string) bool {
+	switch strategy {
+	case MergeKeepSource:
+		return true
+	case MergeKeepNewest:
+		return source.UpdatedAt.After(target.UpdatedAt)
+	case MergeKeepHighest:
+		return source.Rating > target.Rating
+	default:
+		return false
+	}
+}
+
+// deleteMergedReview hapus review yg kalah beserta comment-nya, mengembalikan jumlah comment
+func deleteMergedReview(tx *gorm.DB, review models.Review) (int, error) {
+	comments := tx.Model(&models.Comment{}).Select("id").Where("review_id = ?", review.ID)
+	if err := tx.Where("comment_id IN (?)", comments).Delete(&models.CommentRevision{}).Error; err != nil {
+		return 0, err
+	}
+	result := tx.Where("review_id = ?", review.ID).Delete(&models.Comment{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if err := tx.Where("review_id = ?", review.ID).Delete(&models.ReviewAspectRating{}).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Where("review_id = ?", review.ID).Delete(&models.ReviewVote{}).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Where("review_id = ?", review.ID).Delete(&models.ReviewPhoto{}).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Where("review_id = ?", review.ID).Delete(&models.ReviewRevision{}).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Where("review_id = ?", review.ID).Delete(&models.ReviewProCon{}).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Delete(&review).Error; err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected), nil
+}
+
+// mergePhoneImages pindahkan gallery source ke urutan setelah gallery target,
+// gambar utama source ikut disimpan sebagai gallery
+func mergePhoneImages(tx *gorm.DB, source, target models.Phone, report *PhoneMergeReport) error {
+	targetImages, err := getPhoneImages(tx, target.ID)
+	if err != nil {
+		return err
+	}
+	sourceImages, err := getPhoneImages(tx, source.ID)
+	if err != nil {
+		return err
+	}
+
+	position := uint(len(targetImages))
+	hasCover := slices.ContainsFunc(targetImages, func(image models.PhoneImage) bool { return image.IsCover })
+	for _, image := range sourceImages {
+		position++
+		columns := map[string]any{"phone_id": target.ID, "position": position}
+		if hasCover {
+			columns["is_cover"] = false
+		}
+		hasCover = hasCover || image.IsCover
+		if err := tx.Model(&image).UpdateColumns(columns).Error; err != nil {
+			return err
+		}
+		report.ImagesMoved++
+	}
+
+	used := source.ImageURL == "" || source.ImageURL == target.ImageURL
+	for _, image := range append(targetImages, sourceImages...) {
+		used = used || image.URL == source.ImageURL
+	}
+	if !used {
+		position++
+		image := models.PhoneImage{URL: source.ImageURL, Kind: "other", Position: position, PhoneID: target.ID}
+		if err := tx.Create(&image).Error; err != nil {
+			return err
+		}
+		report.ImagesMoved++
+	}
+	return nil
+}
+
+// mergedBrandParents id induk tiap brand setelah source digabung ke target.
+// target yg merupakan sub brand source (langsung maupun lebih dalam) dinaikkan
+// ke induk source agar tidak terjadi hierarki berputar, sub brand langsung
+// source dipindah ke target
+func mergedBrandParents(parents map[uint]uint, sourceID, targetID uint) map[uint]uint {
+	merged := make(map[uint]uint, len(parents))
+	for id, parentID := range parents {
+		if id != sourceID {
+			merged[id] = parentID
+		}
+	}
+	if slices.Contains(subBrandIDs(parents, sourceID), targetID) {
+		merged[targetID] = parents[sourceID]
+	}
+	for id, parentID := range merged {
+		if parentID == sourceID {
+			merged[id] = targetID
+		}
+	}
+	return merged
+}
+
+func mergeBrand(tx *gorm.DB, source, target models.Brand, report *BrandMergeReport) error {
+	parents, err := brandParents(tx)
+	if err != nil {
+		return err
+	}
+	merged := mergedBrandParents(parents, source.ID, target.ID)
+	for id, parentID := range merged {
+		if parentID == parents[id] {
+			continue
+		}
+		var value *uint
+		if parentID != 0 {
+			value = &parentID
+		}
+		if err := tx.Model(&models.Brand{}).Where("id = ?", id).Update("parent_id", value).Error; err != nil {
+			return err
+		}
+		if id != target.ID {
+			report.SubBrandsMoved++
+		}
+	}
+
+	var phones []models.Phone
+	if err := tx.Select("id", "model", "slug").Where("brand_id = ?", source.ID).Find(&phones).Error; err != nil {
+		return err
+	}
+	for _, phone := range phones {
+		newSlug, err := slug.Generate(tx, slug.KindPhone, target.Name+" "+phone.Model, phone.ID)
+		if err != nil {
+			return err
+		}
+		if err := slug.Rename(tx, slug.KindPhone, phone.ID, phone.Slug, newSlug); err != nil {
+			return err
+		}
+		if err := tx.Model(&phone).UpdateColumns(map[string]any{"brand_id": target.ID, "slug": newSlug}).Error; err != nil {
+			return err
+		}
+		if newSlug != phone.Slug {
+			report.PhoneSlugsMoved = append(report.PhoneSlugsMoved, phone.Slug+" -> "+newSlug)
+		}
+	}
+	report.PhonesMoved = len(phones)
+
+	if len(phones) > 0 {
+		if err := tx.Model(&models.Review{}).
+			Where("phone_id IN ?", phoneIDs(phones)).
+			Count(&report.ReviewsMoved).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Delete(&source).Error; err != nil {
+		return err
+	}
+	if err := slug.Merge(tx, slug.KindBrand, source.ID, source.Slug, target.ID); err != nil {
+		return err
+	}
+	report.Redirects = mergeRedirects(source.ID, source.Slug)
+	return nil
+}
+
+func phoneIDs(phones []models.Phone) []uint {
+	ids := make([]uint, 0, len(phones))
+	for _, phone := range phones {
+		ids = append(ids, phone.ID)
+	}
+	return ids
+}
+
+func mergeRedirects(id uint, oldSlug string) []string {
+	redirects := []string{strconv.Itoa(int(id))}
+	if oldSlug != "" {
+		redirects = append(redirects, oldSlug)
+	}
+	return redirects
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergedBrandParents(t *testing.T) {
+	// brand 1 = source, 2 = target
+	tests := []struct {
+		name    string
+		parents map[uint]uint
+		want    map[uint]uint
+	}{
+		{
+			name:    "brand tanpa hubungan",
+			parents: map[uint]uint{1: 0, 2: 0, 3: 1, 4: 2},
+			want:    map[uint]uint{2: 0, 3: 2, 4: 2},
+		},
+		{
+			name:    "target sub brand langsung source",
+			parents: map[uint]uint{9: 0, 1: 9, 2: 1, 3: 1},
+			want:    map[uint]uint{9: 0, 2: 9, 3: 2},
+		},
+		{
+			// source -> 3 -> target, 3 tidak boleh menjadi induk & anak target sekaligus
+			name:    "target cucu source",
+			parents: map[uint]uint{1: 0, 3: 1, 2: 3, 4: 2},
+			want:    map[uint]uint{3: 2, 2: 0, 4: 2},
+		},
+		{
+			name:    "source sub brand target",
+			parents: map[uint]uint{2: 0, 1: 2, 3: 1},
+			want:    map[uint]uint{2: 0, 3: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergedBrandParents(tt.parents, 1, 2)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("mergedBrandParents() = %v, want %v", got, tt.want)
+			}
+			// semua brand harus terjangkau dari brand teratas (tidak berputar)
+			reached := 0
+			for id, parentID := range got {
+				if parentID == 0 {
+					reached += len(subBrandIDs(got, id))
+				}
+			}
+			if reached != len(got) {
+				t.Fatalf("hierarki berputar: %v", got)
+			}
+		})
+	}
+}
+
+func TestSubBrandIDs(t *testing.T) {
+	parents := map[uint]uint{1: 0, 2: 1, 3: 2, 4: 0, 5: 6, 6: 5}
+
+	if got := subBrandIDs(parents, 1); !reflect.DeepEqual(got, []uint{1, 2, 3}) {
+		t.Errorf("subBrandIDs(1) = %v", got)
+	}
+	if got := subBrandIDs(parents, 4); !reflect.DeepEqual(got, []uint{4}) {
+		t.Errorf("subBrandIDs(4) = %v", got)
+	}
+	// hierarki berputar tidak diulang terus
+	if got := subBrandIDs(parents, 5); !reflect.DeepEqual(got, []uint{5, 6}) {
+		t.Errorf("subBrandIDs(5) = %v", got)
+	}
+}
@@ -0,0 +1,370 @@
+package controller
+
+import (
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"final-project/utils/token"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	// semua review baru menunggu moderasi (kecuali review admin)
+	ReviewModerationPre = "pre"
+	// semua review langsung tampil, admin menyembunyikan setelahnya
+	ReviewModerationPost = "post"
+	// akun baru pre-moderation, akun terpercaya post-moderation
+	ReviewModerationTrust = "trust"
+)
+
+// ModerationConfig aturan moderasi review dari env
+type ModerationConfig struct {
+	Mode string `json:"mode"`
+	// umur akun minimal (hari) agar review langsung tampil pada mode trust
+	TrustedMinAccountDays int `json:"trusted_min_account_days"`
+	// jumlah review approved minimal agar review langsung tampil pada mode trust
+	TrustedMinApproved int      `json:"trusted_min_approved"`
+	Reasons            []string `json:"reasons"`
+}
+
+type moderationInput struct {
+	// kode alasan, lihat /admin/moderation/config
+	Reason string `json:"reason" binding:"required"`
+	Note   string `json:"note"`
+}
+
+type ModerationReviewsResponse struct {
+	models.Review
+	Username   string `json:"username"`
+	PhoneModel string `json:"phone_model"`
+}
+
+// LoadModerationConfig baca REVIEW_MODERATION, REVIEW_TRUSTED_MIN_ACCOUNT_DAYS &
+// REVIEW_TRUSTED_MIN_APPROVED, nilai yg tidak valid memakai default
+func LoadModerationConfig() ModerationConfig {
+	config := ModerationConfig{
+		Mode:                  strings.ToLower(utils.GetEnv("REVIEW_MODERATION", ReviewModerationTrust)),
+		TrustedMinAccountDays: 7,
+		TrustedMinApproved:    1,
+		Reasons:               models.ModerationReasons,
+	}
+	if !slices.Contains([]string{ReviewModerationPre, ReviewModerationPost, ReviewModerationTrust}, config.Mode) {
+		config.Mode = ReviewModerationTrust
+	}
+	if days, err := strconv.Atoi(utils.GetEnv("REVIEW_TRUSTED_MIN_ACCOUNT_DAYS", "7")); err == nil && days >= 0 {
+		config.TrustedMinAccountDays = days
+	}
+	if count, err := strconv.Atoi(utils.GetEnv("REVIEW_TRUSTED_MIN_APPROVED", "1")); err == nil && count >= 0 {
+		config.TrustedMinApproved = count
+	}
+	return config
+}
+
+// Get moderation config godoc
+// @Summary Get review moderation configuration. (ADMIN ONLY)
+// @Description Get the review moderation mode (REVIEW_MODERATION): pre (every new review waits for approval), post (every review is published, admins hide them afterwards) or trust (default, reviews of trusted accounts are published, others wait for approval). an account is trusted when it is older than REVIEW_TRUSTED_MIN_ACCOUNT_DAYS and has at least REVIEW_TRUSTED_MIN_APPROVED approved reviews. reviews of admins are always published. also lists the valid reason codes to reject / hide a review
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} ModerationConfig
+// @Router /admin/moderation/config [get]
+func GetModerationConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, LoadModerationConfig()))
+}
+
+// Get moderation queue godoc
+// @Summary Get the review moderation queue. (ADMIN ONLY)
+// @Description Get reviews by moderation status, oldest first. status defaults to pending
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param status query string false "pending (default) / approved / rejected / hidden"
+// @Param phone_id query string false "filter by phone id"
+// @Param user_id query string false "filter by reviewer id"
+// @Param reason query string false "filter by moderation reason code"
+// @Produce json
+// @Success 200 {object} []ModerationReviewsResponse
+// @Router /admin/moderation/reviews [get]
+func GetModerationQueue(c *gin.Context) {
+	var data []ModerationReviewsResponse
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	status := c.DefaultQuery("status", models.ReviewStatusPending)
+	if !slices.Contains(models.ReviewStatuses, status) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("status harus "+strings.Join(models.ReviewStatuses, ", "), http.StatusBadRequest, nil))
+		return
+	}
+
+	query := db.Table("reviews").
+		Select("reviews.*, users.username as username, phones.model as phone_model").
+		Joins("join users on reviews.user_id = users.id").
+		Joins("join phones on reviews.phone_id = phones.id").
+		Where("reviews.status = ?", status)
+
+	for key, column := range map[string]string{"phone_id": "reviews.phone_id", "user_id": "reviews.user_id"} {
+		value := c.Query(key)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(key+" harus berupa angka", http.StatusBadRequest, nil))
+			return
+		}
+		query = query.Where(column+" = ?", id)
+	}
+	if reason := c.Query("reason"); reason != "" {
+		query = query.Where("reviews.moderation_reason = ?", reason)
+	}
+
+	if err := query.Order("reviews.created_at ASC").Order("reviews.id ASC").Scan(&data).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	reviewIDs := make([]uint, 0, len(data))
+	for _, review := range data {
+		reviewIDs = append(reviewIDs, review.ID)
+	}
+	photos, err := getReviewPhotos(db, reviewIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	for i := range data {
+		data[i].Photos = photos[data[i].ID]
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, data))
+}
+
+// Approve review godoc
+// @Summary Approve a review. (ADMIN ONLY)
+// @Description Publish a pending, rejected or hidden review, the review is counted in the phone rating again
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "review id"
+// @Produce json
+// @Success 200 {object} models.Review
+// @Router /admin/moderation/reviews/{id}/approve [post]
+func ApproveReview(c *gin.Context) {
+	moderateReview(c, models.ReviewStatusApproved, moderationInput{})
+}
+
+// Reject review godoc
+// @Summary Reject a review. (ADMIN ONLY)
+// @Description Reject a review with a reason code (see /admin/moderation/config), the review is no longer public nor counted in the phone rating. editing the review sends it back to the queue
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "review id"
+// @Param Body body moderationInput true "the reason to reject the review"
+// @Produce json
+// @Success 200 {object} models.Review
+// @Router /admin/moderation/reviews/{id}/reject [post]
+func RejectReview(c *gin.Context) {
+	input, ok := bindModerationInput(c)
+	if !ok {
+		return
+	}
+	moderateReview(c, models.ReviewStatusRejected, input)
+}
+
+// Hide review godoc
+// @Summary Hide a published review. (ADMIN ONLY)
+// @Description Hide a review with a reason code (see /admin/moderation/config), used for post moderation. the review is no longer public nor counted in the phone rating
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "review id"
+// @Param Body body moderationInput true "the reason to hide the review"
+// @Produce json
+// @Success 200 {object} models.Review
+// @Router /admin/moderation/reviews/{id}/hide [post]
+func HideReview(c *gin.Context) {
+	input, ok := bindModerationInput(c)
+	if !ok {
+		return
+	}
+	moderateReview(c, models.ReviewStatusHidden, input)
+}
+
+// Get comment moderation queue godoc
+// @Summary Get the comment moderation queue. (ADMIN ONLY)
+// @Description Get comments by moderation status, oldest first. status defaults to pending, comments become pending when the content filter sends them to moderation
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param status query string false "pending (default) / approved / rejected / hidden"
+// @Param reason query string false "filter by moderation reason code"
+// @Produce json
+// @Success 200 {object} []models.Comment
+// @Router /admin/moderation/comments [get]
+func GetCommentModerationQueue(c *gin.Context) {
+	var comments []models.Comment
+
+	db := c.MustGet("db").(*gorm.DB)
+
+	status := c.DefaultQuery("status", models.ReviewStatusPending)
+	if !slices.Contains(models.ReviewStatuses, status) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("status harus "+strings.Join(models.ReviewStatuses, ", "), http.StatusBadRequest, nil))
+		return
+	}
+
+	query := db.Preload("User").Where("status = ?", status)
+	if reason := c.Query("reason"); reason != "" {
+		query = query.Where("moderation_reason = ?", reason)
+	}
+
+	if err := query.Order("created_at ASC").Order("id ASC").Find(&comments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, comments))
+}
+
+// Approve comment godoc
+// @Summary Approve a comment. (ADMIN ONLY)
+// @Description Publish a pending, rejected or hidden comment
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "comment id"
+// @Produce json
+// @Success 200 {object} models.Comment
+// @Router /admin/moderation/comments/{id}/approve [post]
+func ApproveComment(c *gin.Context) {
+	var comment models.Comment
+	moderate(c, &comment, "comment", models.ReviewStatusApproved, moderationInput{})
+}
+
+// Reject comment godoc
+// @Summary Reject a comment. (ADMIN ONLY)
+// @Description Reject a comment with a reason code (see /admin/moderation/config), the comment is no longer public. editing the comment sends it back to the queue
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "comment id"
+// @Param Body body moderationInput true "the reason to reject the comment"
+// @Produce json
+// @Success 200 {object} models.Comment
+// @Router /admin/moderation/comments/{id}/reject [post]
+func RejectComment(c *gin.Context) {
+	input, ok := bindModerationInput(c)
+	if !ok {
+		return
+	}
+	var comment models.Comment
+	moderate(c, &comment, "comment", models.ReviewStatusRejected, input)
+}
+
+func bindModerationInput(c *gin.Context) (moderationInput, bool) {
+	var input moderationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		c.JSON(http.StatusBadRequest, utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		return input, false
+	}
+	if !slices.Contains(models.ModerationReasons, input.Reason) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("reason harus "+strings.Join(models.ModerationReasons, ", "), http.StatusBadRequest, nil))
+		return input, false
+	}
+	return input, true
+}
+
+// moderateReview ubah status review & catat admin yg memoderasi
+func moderateReview(c *gin.Context, status string, input moderationInput) {
+	var review models.Review
+	moderate(c, &review, "review", status, input)
+}
+
+// moderate ubah status data (review / comment) dgn id dari param
+func moderate(c *gin.Context, data any, name string, status string, input moderationInput) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	adminID, err := token.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := db.Where("id = ?", c.Param("id")).First(data).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound(name), http.StatusNotFound, nil))
+		return
+	}
+
+	if err := db.Model(data).Updates(map[string]any{
+		"status":            status,
+		"moderation_reason": input.Reason,
+		"moderation_note":   input.Note,
+		"moderated_at":      time.Now(),
+		"moderated_by":      adminID,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := db.Where("id = ?", c.Param("id")).First(data).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("status "+name), http.StatusOK, data))
+}
+
+// initialReviewStatus status review baru / yg diedit sesuai aturan moderasi
+func initialReviewStatus(c *gin.Context, db *gorm.DB, userID uint) (string, error) {
+	config := LoadModerationConfig()
+	if config.Mode == ReviewModerationPost || isCatalogAdmin(c) {
+		return models.ReviewStatusApproved, nil
+	}
+	if config.Mode == ReviewModerationPre {
+		return models.ReviewStatusPending, nil
+	}
+
+	var user models.User
+	if err := db.Where("id = ?", userID).First(&user).Error; err != nil {
+		return "", err
+	}
+	if time.Since(user.CreatedAt) < time.Duration(config.TrustedMinAccountDays)*24*time.Hour {
+		return models.ReviewStatusPending, nil
+	}
+
+	var approved int64
+	if err := db.Model(&models.Review{}).
+		Where("user_id = ? AND status = ?", userID, models.ReviewStatusApproved).
+		Count(&approved).Error; err != nil {
+		return "", err
+	}
+	if approved < int64(config.TrustedMinApproved) {
+		return models.ReviewStatusPending, nil
+	}
+	return models.ReviewStatusApproved, nil
+}
+
+// approvedReviews review yg tampil di publik & dihitung di rating
+func approvedReviews(query *gorm.DB) *gorm.DB {
+	return query.Where("reviews.status = ?", models.ReviewStatusApproved)
+}
@@ -3,9 +3,15 @@ package controller
 import (
 	"final-project/lib"
 	"final-project/models"
+	"final-project/scheduler"
+	"final-project/slug"
+	"final-project/storage"
 	"final-project/utils"
+	"final-project/utils/token"
 	"fmt"
+	"log"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -20,35 +26,74 @@ type phoneInput struct {
 	ImageURL    string    `json:"image_url" bind:"required"`
 	ReleaseDate time.Time `json:"release_date"`
 	BrandID     uint      `json:"brand_id" bind:"required"`
+	// default released, phone draft hanya terlihat oleh admin
+	Status string `json:"status" binding:"omitempty,oneof=draft rumored announced released discontinued"`
+	// jika diisi waktu yg akan datang, phone disimpan sebagai draft dan
+	// otomatis dipublish dgn status di atas saat publish_at tercapai
+	PublishAt *time.Time `json:"publish_at"`
 }
 
 type phoneUpdate struct {
-	Model       string    `json:"model"`
-	Price       uint      `json:"price"`
-	ImageURL    string    `json:"image_url" bind:"url"`
-	ReleaseDate time.Time `json:"release_date"`
-	BrandID     uint      `json:"brand_id"`
+	Model       string     `json:"model"`
+	Price       uint       `json:"price"`
+	ImageURL    string     `json:"image_url" bind:"url"`
+	ReleaseDate time.Time  `json:"release_date"`
+	BrandID     uint       `json:"brand_id"`
+	Status      string     `json:"status" binding:"omitempty,oneof=draft rumored announced released discontinued"`
+	PublishAt   *time.Time `json:"publish_at"`
 }
 
 type PhonesCompleteResponse struct {
-	PhoneID     int       `json:"phone_id"`
-	BrandID     int       `json:"brand_id"`
-	BrandName   string    `json:"brand_name"`
-	PhoneImage  string    `json:"phone_image"`
-	PhoneModel  string    `json:"phone_model"`
-	FullName    string    `json:"full_name"`
-	AVGRating   float64   `json:"avg_rating"`
-	Price       float64   `json:"price"`
-	ReleaseDate time.Time `json:"release_date"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	PhoneID     int        `json:"phone_id"`
+	BrandID     int        `json:"brand_id"`
+	BrandName   string     `json:"brand_name"`
+	PhoneImage  string     `json:"phone_image"`
+	PhoneModel  string     `json:"phone_model"`
+	Slug        string     `json:"slug"`
+	FullName    string     `json:"full_name"`
+	AVGRating   float64    `json:"avg_rating"`
+	Price       float64    `json:"price"`
+	ReleaseDate time.Time  `json:"release_date"`
+	Status      string     `json:"status"`
+	PublishAt   *time.Time `json:"publish_at"`
+	SpecScore   *float64   `json:"spec_score"`
+	ValueScore  *float64   `json:"value_score"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	// hanya diisi di detail phone (GetPhoneById)
+	Gallery    []models.PhoneImage `gorm:"-" json:"gallery,omitempty"`
+	Categories []models.Category   `gorm:"-" json:"categories,omitempty"`
+	Tags       []models.Tag        `gorm:"-" json:"tags,omitempty"`
+	// hanya diisi jika query region / currency dikirim
+	LocalPrice *LocalPrice `gorm:"-" json:"local_price,omitempty"`
+	// rata-rata rating per aspek (key aspek -> rata-rata), hanya aspek yg sudah dirating
+	AspectRatings map[string]float64 `gorm:"-" json:"aspect_ratings,omitempty"`
 }
 
 // Get all phone data
 // @Summary Get all Phones data. (PUBLIC)
-// @Description Get a list of Phone.
+// @Description Get a list of Phone. draft phones are only listed for admin (send the admin token)
 // @Tags Phones
 // @Produce json
+// @Param search query string false "search keyword"
+// @Param sort query string false "asc / desc"
+// @Param sort_by query string false "id (default) / value_score / aspect:<key> (average rating of a rating aspect, e.g. aspect:camera), phones without the score are listed last"
+// @Param min_value_score query number false "only phones with value_score (spec score per 1.000.000 price) at least this"
+// @Param max_value_score query number false "only phones with value_score at most this"
+// @Param memory query int false "only phones with a variant of this memory (GB)"
+// @Param storage query int false "only phones with a variant of this storage (GB)"
+// @Param color query string false "only phones with a variant of this color"
+// @Param min_price query int false "only phones with a variant priced at least this"
+// @Param max_price query int false "only phones with a variant priced at most this"
+// @Param available query bool false "only phones with an available / unavailable variant"
+// @Param status query string false "comma separated statuses (rumored, announced, released, discontinued, draft for admin only), e.g. status=rumored,announced for upcoming phones"
+// @Param category query string false "comma separated category slugs, only phones in any of the categories"
+// @Param tag query string false "comma separated tags, only phones having all of the tags"
+// @Param attr[key] query string false "only phones whose attribute <key> equals this value, e.g. attr[esim]=true"
+// @Param attr_min[key] query number false "only phones whose number attribute <key> is at least this value"
+// @Param attr_max[key] query number false "only phones whose number attribute <key> is at most this value"
+// @Param region query string false "region code (e.g. ID, MY, SG, US), fills local_price with the regional price or the converted base price"
+// @Param currency query string false "currency code (e.g. USD), fills local_price converted to this currency"
 // @Success 200 {object} []models.Phone
 // @Router /phones [get]
 func GetAllPhoneData(c *gin.Context) {
@@ -59,34 +104,90 @@ func GetAllPhoneData(c *gin.Context) {
 	searchKeyword := c.Query("search")
 	sort := c.Query("sort")
 
-	query := db.Model(&models.Phone{})
+	// harga lokal sesuai region / currency
+	region, currency, err := localPriceQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	query := visiblePhones(c, phoneCompleteQuery(db))
 
 	if searchKeyword != "" {
 		q := fmt.Sprintf("%%%s%%", searchKeyword)
 		query.Where("name LIKE ?", q)
 	}
 
-	switch strings.ToLower(sort) {
-	case "desc":
-		query.Order("phone_id DESC")
+	// filter berdasarkan variant (cocok jika salah satu variant memenuhi)
+	query = applyPhoneVariantFilter(c, query)
+
+	// filter berdasarkan kategori & tag
+	query = applyPhoneTaxonomyFilter(c, query)
+
+	// filter berdasarkan status phone
+	query, err = applyPhoneStatusFilter(c, query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	// filter berdasarkan atribut specification tambahan
+	query, err = applyPhoneAttributeFilter(c, query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	// filter berdasarkan value score
+	query, err = applyValueScoreFilter(c, query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	direction := "ASC"
+	if strings.ToLower(sort) == "desc" {
+		direction = "DESC"
+	}
+	switch strings.ToLower(c.Query("sort_by")) {
+	case "", "id":
+		query.Order("phone_id " + direction)
+	case "value_score":
+		// phone tanpa value score selalu di akhir
+		query.Order("phones.value_score IS NULL").Order("phones.value_score " + direction).Order("phone_id ASC")
 	default:
-		query.Order("phone_id ASC")
+		key, ok := strings.CutPrefix(strings.ToLower(c.Query("sort_by")), "aspect:")
+		if !ok {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON("sort_by harus id, value_score atau aspect:<key>", http.StatusBadRequest, nil))
+			return
+		}
+		query, err = applyAspectSort(db, query, key, direction)
+		if err != nil {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+			return
+		}
 	}
 
 	var phones_data []PhonesCompleteResponse
-	if err := query.Table("phones").
-		Select(`brands.name as brand_name, 
-					phones.id as phone_id,
-					brands.id as brand_id,
-					phones.image_url as phone_image, 
-					phones.model as phone_model, 
-					brands.name || ' ' || phones.model as full_name, 
-					COALESCE(ROUND(AVG(reviews.rating), 2), 0) as avg_rating,
-					phones.price, phones.release_date, phones.created_at, phones.updated_at`).
-		Joins("LEFT JOIN reviews on phones.id = reviews.phone_id").
-		Joins("JOIN brands on brands.id = phones.brand_id").
-		Group("brands.name, phones.id, brands.id, phones.image_url, phones.model, phones.price, phones.release_date, phones.created_at, phones.updated_at").
-		Scan(&phones_data).Error; err != nil {
+	if err := query.Scan(&phones_data).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := applyLocalPrices(db, phones_data, region, currency); err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := applyAspectRatings(db, phones_data); err != nil {
 		c.JSON(http.StatusInternalServerError,
 			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
 		return
@@ -111,7 +212,7 @@ func GetAllPhoneData(c *gin.Context) {
 // @Tags Phones
 // @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
 // @Security BearerToken
-// @Param Body body phoneInput true "example JSON body to create a new Phone, sample release_data format = 2023-09-22T00:00:00Z"
+// @Param Body body phoneInput true "example JSON body to create a new Phone, sample release_data format = 2023-09-22T00:00:00Z. status default released, send a future publish_at to schedule publishing"
 // @Produce json
 // @Success 200 {object} models.Phone
 // @Router /phones [post]
@@ -144,13 +245,24 @@ func CreatePhoneData(c *gin.Context) {
 		return
 	}
 
+	phoneSlug, err := slug.Generate(db, slug.KindPhone, brand.Name+" "+input.Model, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
 	phone_data := models.Phone{
 		Model:       input.Model,
+		Slug:        phoneSlug,
 		ReleaseDate: input.ReleaseDate,
 		Price:       input.Price,
 		ImageURL:    input.ImageURL,
 		BrandID:     input.BrandID,
 	}
+	if !setPhonePublishing(c, &phone_data, input.Status, input.PublishAt) {
+		return
+	}
 
 	// Add error handling for db.Create
 	if err := db.Create(&phone_data).Error; err != nil {
@@ -159,34 +271,34 @@ func CreatePhoneData(c *gin.Context) {
 		return
 	}
 
+	refreshPhoneFeatures(db, phone_data.ID)
+
 	c.JSON(http.StatusOK,
 		utils.ResponseJSON(lib.MsgAdded("phone"), http.StatusOK, phone_data))
 }
 
 // GetPhoneById godoc
 // @Summary Get Phone. (PUBLIC)
-// @Description Get a Phone by id.
+// @Description Get a Phone by id or slug (e.g. samsung-galaxy-s24-ultra), including its ordered image gallery, categories and tags. an old slug of a renamed phone is redirected (301) to the current slug
 // @Tags Phones
 // @Produce json
-// @Param id path string true "phone id"
+// @Param id path string true "phone id or slug"
+// @Param region query string false "region code (e.g. ID, MY, SG, US), fills local_price with the regional price or the converted base price"
+// @Param currency query string false "currency code (e.g. USD), fills local_price converted to this currency"
 // @Success 200 {object} models.Phone
 // @Router /phones/{id} [get]
 func GetPhoneById(c *gin.Context) {
 	var phone []PhonesCompleteResponse
 	db := c.MustGet("db").(*gorm.DB)
 
-	if err := db.Table("phones").
-		Select(`brands.name as brand_name, 
-				phones.id as phone_id,
-				brands.id as brand_id,
-				phones.image_url as phone_image, 
-				phones.model as phone_model, 
-				brands.name || ' ' || phones.model as full_name, 
-				COALESCE(ROUND(AVG(reviews.rating), 2), 0) as avg_rating,
-				phones.price, phones.release_date, phones.created_at, phones.updated_at`).
-		Joins("LEFT JOIN reviews on phones.id = reviews.phone_id").
-		Joins("JOIN brands on brands.id = phones.brand_id").
-		Group("brands.name, phones.id, brands.id, phones.image_url, phones.model, phones.price, phones.release_date, phones.created_at, phones.updated_at").
+	region, currency, err := localPriceQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	if err := visiblePhones(c, phoneCompleteQuery(db)).
 		Where("phones.id = ?", c.Param("id")).
 		Scan(&phone).Error; err != nil {
 		c.JSON(http.StatusInternalServerError,
@@ -200,6 +312,35 @@ func GetPhoneById(c *gin.Context) {
 		return
 	}
 
+	gallery, err := getPhoneImages(db, uint(phone[0].PhoneID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	phone[0].Gallery = gallery
+
+	categories, tags, err := getPhoneTaxonomy(db, uint(phone[0].PhoneID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	phone[0].Categories = categories
+	phone[0].Tags = tags
+
+	if err := applyLocalPrices(db, phone, region, currency); err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := applyAspectRatings(db, phone); err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
 	c.JSON(http.StatusOK,
 		utils.ResponseJSON("", http.StatusOK, phone))
 }
@@ -212,7 +353,7 @@ func GetPhoneById(c *gin.Context) {
 // @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
 // @Security BearerToken
 // @Param id path string true "Phone id"
-// @Param Body body phoneInput true "Example JSON body to update Phone data, sample release_data format = 2023-09-22T00:00:00Z"
+// @Param Body body phoneUpdate true "Example JSON body to update Phone data, sample release_data format = 2023-09-22T00:00:00Z. send a future publish_at to schedule publishing, send status without publish_at to cancel a schedule"
 // @Success 200 {object} models.Phone
 // @Router /phones/{id} [put]
 func UpdatePhoneData(c *gin.Context) {
@@ -266,8 +407,64 @@ func UpdatePhoneData(c *gin.Context) {
 	updated_data.BrandID = brand.ID
 	updated_data.UpdatedAt = time.Now()
 
-	// update ke tabel
-	db.Model(&phone).Updates(updated_data)
+	// status & jadwal publish divalidasi sebelum ada data yg diubah
+	publishingChanged := input.Status != "" || input.PublishAt != nil
+	if publishingChanged {
+		status := input.Status
+		if status == "" {
+			status = phone.Status
+			if phone.ScheduledStatus != "" {
+				status = phone.ScheduledStatus
+			}
+		}
+		if !setPhonePublishing(c, &phone, status, input.PublishAt) {
+			return
+		}
+	}
+
+	priceChanged := updated_data.Price != 0 && updated_data.Price != phone.Price
+
+	// slug, status & data phone diubah dalam satu transaksi
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// slug dibuat ulang jika model / brand diganti, slug lama tetap di-redirect
+		if (input.Model != "" && input.Model != phone.Model) || (input.BrandID != 0 && input.BrandID != phone.BrandID) {
+			if brand.ID == 0 {
+				tx.Where("id = ?", phone.BrandID).First(&brand)
+			}
+			model := phone.Model
+			if input.Model != "" {
+				model = input.Model
+			}
+
+			newSlug, err := slug.Generate(tx, slug.KindPhone, brand.Name+" "+model, phone.ID)
+			if err != nil {
+				return err
+			}
+			if err := slug.Rename(tx, slug.KindPhone, phone.ID, phone.Slug, newSlug); err != nil {
+				return err
+			}
+			updated_data.Slug = newSlug
+		}
+
+		if publishingChanged {
+			if err := tx.Model(&phone).Select("status", "publish_at", "scheduled_status").Updates(&phone).Error; err != nil {
+				return err
+			}
+		}
+
+		// update ke tabel
+		return tx.Model(&phone).Updates(updated_data).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	if priceChanged {
+		refreshValueScores(db, phone.ID)
+		refreshPhoneFeatures(db, phone.ID)
+	}
 
 	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("phone"), http.StatusOK, phone))
 }
@@ -291,11 +488,118 @@ func DeletePhoneData(c *gin.Context) {
 		return
 	}
 
+	// file gambar yg diupload ikut dihapus dari storage
+	images, _ := getPhoneImages(db, phone_data.ID)
+	photoURLs, _ := reviewPhotoURLs(db, db.Model(&models.Review{}).Select("id").Where("phone_id = ?", phone_data.ID))
+
 	db.Delete(&phone_data)
+	slug.Forget(db, slug.KindPhone, phone_data.ID)
+	// nilai tertinggi tiap komponen bisa berubah
+	refreshValueScores(db)
+
+	store := c.MustGet("storage").(storage.Storage)
+	urls := []string{phone_data.ImageURL}
+	for _, image := range images {
+		urls = append(urls, image.URL)
+	}
+	for _, url := range urls {
+		if err := storage.DeleteImage(c.Request.Context(), store, url); err != nil {
+			log.Println("gagal menghapus file image:", err.Error())
+		}
+	}
+	removeReviewPhotoFiles(c, photoURLs)
 
 	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgDeleted("phone"), http.StatusOK, nil))
 }
 
+// phoneCompleteQuery query dasar data phone lengkap dengan brand & rata-rata rating
+func phoneCompleteQuery(db *gorm.DB) *gorm.DB {
+	return db.Table("phones").
+		Select(`brands.name as brand_name, 
+				phones.id as phone_id,
+				brands.id as brand_id,
+				phones.image_url as phone_image, 
+				phones.model as phone_model, 
+				phones.slug,
+				brands.name || ' ' || phones.model as full_name, 
+				COALESCE(ROUND(AVG(reviews.rating), 2), 0) as avg_rating,
+				phones.price, phones.release_date, phones.status, phones.publish_at,
+				phones.spec_score, phones.value_score, phones.created_at, phones.updated_at`).
+		// hanya review approved yg dihitung di rating
+		Joins("LEFT JOIN reviews on phones.id = reviews.phone_id AND reviews.status = ?", models.ReviewStatusApproved).
+		Joins("JOIN brands on brands.id = phones.brand_id").
+		Group("brands.name, phones.id, brands.id, phones.image_url, phones.model, phones.slug, phones.price, phones.release_date, phones.status, phones.publish_at, phones.spec_score, phones.value_score, phones.created_at, phones.updated_at")
+}
+
+// setPhonePublishing isi status & jadwal publish phone. publish_at di masa depan
+// membuat phone menjadi draft sampai dipublish oleh cron job dgn status yg diminta
+func setPhonePublishing(c *gin.Context, phone *models.Phone, status string, publishAt *time.Time) bool {
+	if status == "" {
+		status = models.PhoneStatusReleased
+	}
+
+	if publishAt != nil && publishAt.After(time.Now()) {
+		if status == models.PhoneStatusDraft {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON("status untuk phone terjadwal tidak boleh draft", http.StatusBadRequest, nil))
+			return false
+		}
+		phone.Status = models.PhoneStatusDraft
+		phone.ScheduledStatus = status
+		phone.PublishAt = publishAt
+		return true
+	}
+
+	phone.Status = status
+	phone.ScheduledStatus = ""
+	phone.PublishAt = publishAt
+	return true
+}
+
+// isCatalogAdmin true jika request membawa token milik admin, dipakai route
+// publik yg menampilkan data tambahan untuk admin (contoh phone draft)
+func isCatalogAdmin(c *gin.Context) bool {
+	if value, exists := c.Get("is_catalog_admin"); exists {
+		return value.(bool)
+	}
+
+	isAdmin := false
+	if userID, err := token.ExtractTokenID(c); err == nil && userID != 0 {
+		db := c.MustGet("db").(*gorm.DB)
+		var count int64
+		db.Table("users").
+			Joins("JOIN roles on roles.id = users.role_id").
+			Where("users.id = ? AND LOWER(roles.name) = ?", userID, "admin").
+			Count(&count)
+		isAdmin = count > 0
+	}
+
+	c.Set("is_catalog_admin", isAdmin)
+	return isAdmin
+}
+
+// visiblePhones sembunyikan phone draft kecuali untuk admin
+func visiblePhones(c *gin.Context, query *gorm.DB) *gorm.DB {
+	if isCatalogAdmin(c) {
+		return query
+	}
+	return query.Where("phones.status <> ?", models.PhoneStatusDraft)
+}
+
+// applyPhoneStatusFilter filter query status (dipisah koma)
+func applyPhoneStatusFilter(c *gin.Context, query *gorm.DB) (*gorm.DB, error) {
+	statuses := splitQueryList(strings.ToLower(c.Query("status")))
+	if len(statuses) == 0 {
+		return query, nil
+	}
+	for _, status := range statuses {
+		if !slices.Contains(models.PhoneStatuses, status) {
+			return nil, fmt.Errorf("status harus salah satu dari: %s", strings.Join(models.PhoneStatuses, ", "))
+		}
+	}
+	return query.Where("phones.status IN ?", statuses), nil
+}
+
 func isPhoneInputDataValid(c *gin.Context, data phoneInput) bool {
 	dataErr := []string{}
 
@@ -339,7 +643,7 @@ type Phones struct {
 // @Description Get Phone specifiction data by phone id. if phone's specification data empty, the spec data will not be displayed
 // @Tags Phones
 // @Produce json
-// @Param id path string true "Phone id"
+// @Param id path string true "Phone id or slug"
 // @Success 200 {object} []models.Phone
 // @Router /phones/{id}/specification [get]
 func GetPhonesSpecByPhoneId(c *gin.Context) {
@@ -348,7 +652,7 @@ func GetPhonesSpecByPhoneId(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
 
 	id := c.Param("id")
-	if err := db.Preload("Specifications").
+	if err := visiblePhones(c, db).Preload("Specifications.CameraModules").
 		Select("phones.*, brands.logo_url as brand_logo, brands.name as brand_name").
 		Joins("join brands on phones.brand_id = brands.id").
 		Where("phones.id = ?", id).
@@ -361,3 +665,26 @@ func GetPhonesSpecByPhoneId(c *gin.Context) {
 
 	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, phone))
 }
+
+// Publish scheduled phones godoc
+// @Summary Publish scheduled phones whose publish_at has passed. (ADMIN ONLY)
+// @Description Change the status of scheduled draft phones to their requested status once publish_at has passed. called every minute by the cron job on GET /cron/publish with the header "Authorization: Bearer <CRON_SECRET>" (see vercel.json)
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} map[string]int64
+// @Router /admin/phones/publish-scheduled [post]
+// @Router /cron/publish [get]
+func PublishScheduledPhones(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	published, err := scheduler.PublishDuePhones(db, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(fmt.Sprintf("%d phone terjadwal berhasil dipublish", published), http.StatusOK, map[string]int64{"published": published}))
+}
@@ -0,0 +1,368 @@
+package controller
+
+import (
+	"final-project/lib"
+	"final-project/models"
+	"final-project/storage"
+	"final-project/utils"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var phoneImageKinds = []string{"front", "back", "color", "lifestyle", "other"}
+
+type phoneImageUpdate struct {
+	Caption *string `json:"caption"`
+	Kind    string  `json:"kind" binding:"omitempty,oneof=front back color lifestyle other"`
+	IsCover bool    `json:"is_cover"`
+}
+
+type phoneImageOrderInput struct {
+	ImageIDs []uint `json:"image_ids" binding:"required"`
+}
+
+// Get phone gallery godoc
+// @Summary Get image gallery by Phone id. (PUBLIC)
+// @Description Get the ordered image gallery of a phone
+// @Tags Phones
+// @Produce json
+// @Param id path string true "Phone id or slug"
+// @Success 200 {object} []models.PhoneImage
+// @Router /phones/{id}/images [get]
+func GetPhoneImages(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var phone models.Phone
+	if err := visiblePhones(c, db).Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	images, err := getPhoneImages(db, phone.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, images))
+}
+
+// Add phone gallery image godoc
+// @Summary Add image to Phone gallery (ADMIN ONLY)
+// @Description Add an image to the end of the phone gallery, either upload a file (field "image") or send an external url (field "url"). the first image of a gallery automatically becomes the cover, the cover image is also used as the phone image_url
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Accept multipart/form-data
+// @Param id path string true "Phone id"
+// @Param image formData file false "image file"
+// @Param url formData string false "external image url, used when no file is uploaded"
+// @Param caption formData string false "image caption"
+// @Param kind formData string false "front / back / color / lifestyle / other"
+// @Param is_cover formData bool false "set this image as the gallery cover"
+// @Produce json
+// @Success 200 {object} models.PhoneImage
+// @Router /phones/{id}/images [post]
+func CreatePhoneImage(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var phone models.Phone
+	if err := db.Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	kind := c.DefaultPostForm("kind", "other")
+	if !slices.Contains(phoneImageKinds, kind) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("kind harus salah satu dari: front, back, color, lifestyle, other", http.StatusBadRequest, nil))
+		return
+	}
+	isCover, _ := strconv.ParseBool(c.PostForm("is_cover"))
+
+	image_data := models.PhoneImage{
+		Caption: c.PostForm("caption"),
+		Kind:    kind,
+		PhoneID: phone.ID,
+	}
+
+	// file upload diutamakan, jika tidak ada pakai url dari luar
+	if _, err := c.FormFile("image"); err == nil {
+		uploaded, ok := saveUploadedImage(c, "phones/"+strconv.Itoa(int(phone.ID))+"/gallery")
+		if !ok {
+			return
+		}
+		image_data.URL = uploaded.URL
+		image_data.Thumbnails = uploaded.Thumbnails
+	} else {
+		url := c.PostForm("url")
+		if url == "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(lib.MsgRequired("image atau url"), http.StatusBadRequest, nil))
+			return
+		}
+		if !utils.IsValidUrl(url) {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(lib.MsgValidUrl("url"), http.StatusBadRequest, nil))
+			return
+		}
+		image_data.URL = url
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.PhoneImage{}).Where("phone_id = ?", phone.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		image_data.Position = uint(count) + 1
+
+		if err := tx.Create(&image_data).Error; err != nil {
+			return err
+		}
+
+		if isCover || count == 0 {
+			return setPhoneCoverImage(tx, &image_data)
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgAdded("phone image"), http.StatusOK, image_data))
+}
+
+// Update phone gallery image godoc
+// @Summary Update Phone gallery image (ADMIN ONLY)
+// @Description Update caption / kind of a gallery image or make it the cover image
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "Phone id"
+// @Param image_id path string true "Image id"
+// @Param Body body phoneImageUpdate true "example JSON body to update a gallery image"
+// @Produce json
+// @Success 200 {object} models.PhoneImage
+// @Router /phones/{id}/images/{image_id} [put]
+func UpdatePhoneImage(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input phoneImageUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	var image models.PhoneImage
+	if err := db.Where("id = ? AND phone_id = ?", c.Param("image_id"), c.Param("id")).First(&image).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone image"), http.StatusNotFound, nil))
+		return
+	}
+
+	// Update yg diinput saja
+	if input.Caption != nil {
+		image.Caption = *input.Caption
+	}
+	if input.Kind != "" {
+		image.Kind = input.Kind
+	}
+	image.UpdatedAt = time.Now()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&image).Error; err != nil {
+			return err
+		}
+		if input.IsCover && !image.IsCover {
+			return setPhoneCoverImage(tx, &image)
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON("Failed to update phone image", http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("phone image"), http.StatusOK, image))
+}
+
+// Reorder phone gallery godoc
+// @Summary Reorder Phone gallery (ADMIN ONLY)
+// @Description Set the gallery order, image_ids must contain every image id of the phone exactly once
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "Phone id"
+// @Param Body body phoneImageOrderInput true "example JSON body : {\"image_ids\": [3, 1, 2]}"
+// @Produce json
+// @Success 200 {object} []models.PhoneImage
+// @Router /phones/{id}/images/order [put]
+func ReorderPhoneImages(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input phoneImageOrderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	var phone models.Phone
+	if err := db.Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	images, err := getPhoneImages(db, phone.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	// image_ids harus berisi semua image phone ini, masing-masing satu kali
+	valid := len(input.ImageIDs) == len(images)
+	for _, image := range images {
+		if !slices.Contains(input.ImageIDs, image.ID) {
+			valid = false
+		}
+	}
+	if !valid {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("image_ids harus berisi semua id image phone ini tanpa duplikat", http.StatusBadRequest, nil))
+		return
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range input.ImageIDs {
+			if err := tx.Model(&models.PhoneImage{}).Where("id = ?", id).
+				Update("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	images, err = getPhoneImages(db, phone.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("phone gallery"), http.StatusOK, images))
+}
+
+// Delete phone gallery image godoc
+// @Summary Delete Phone gallery image (ADMIN ONLY)
+// @Description Delete an image from the phone gallery, uploaded files are removed from storage. if the cover is deleted the first remaining image becomes the cover
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Param id path string true "Phone id"
+// @Param image_id path string true "Image id"
+// @Success 200 {object} map[string]boolean
+// @Router /phones/{id}/images/{image_id} [delete]
+func DeletePhoneImage(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var image models.PhoneImage
+	if err := db.Where("id = ? AND phone_id = ?", c.Param("image_id"), c.Param("id")).First(&image).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone image"), http.StatusNotFound, nil))
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&image).Error; err != nil {
+			return err
+		}
+
+		// rapatkan urutan image setelah image yg dihapus
+		if err := tx.Model(&models.PhoneImage{}).
+			Where("phone_id = ? AND position > ?", image.PhoneID, image.Position).
+			Update("position", gorm.Expr("position - 1")).Error; err != nil {
+			return err
+		}
+
+		if !image.IsCover {
+			return nil
+		}
+		var next models.PhoneImage
+		err := tx.Where("phone_id = ?", image.PhoneID).Order("position ASC").First(&next).Error
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return setPhoneCoverImage(tx, &next)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	// file tetap disimpan jika masih dipakai sebagai image_url phone atau gallery
+	// lain (file upload yg sama disimpan dgn url yg sama)
+	var usedByPhone, usedByGallery int64
+	db.Model(&models.Phone{}).Where("image_url = ?", image.URL).Count(&usedByPhone)
+	db.Model(&models.PhoneImage{}).Where("url = ? AND id <> ?", image.URL, image.ID).Count(&usedByGallery)
+	if usedByPhone == 0 && usedByGallery == 0 {
+		store := c.MustGet("storage").(storage.Storage)
+		if err := storage.DeleteImage(c.Request.Context(), store, image.URL); err != nil {
+			log.Println("gagal menghapus file image:", err.Error())
+		}
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgDeleted("phone image"), http.StatusOK, nil))
+}
+
+// setPhoneCoverImage menjadikan image sebagai cover gallery & image_url phone
+func setPhoneCoverImage(tx *gorm.DB, image *models.PhoneImage) error {
+	if err := tx.Model(&models.PhoneImage{}).Where("phone_id = ?", image.PhoneID).
+		Update("is_cover", false).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&models.PhoneImage{}).Where("id = ?", image.ID).
+		Update("is_cover", true).Error; err != nil {
+		return err
+	}
+	image.IsCover = true
+
+	return tx.Model(&models.Phone{}).Where("id = ?", image.PhoneID).
+		Updates(map[string]any{"image_url": image.URL, "updated_at": time.Now()}).Error
+}
+
+func getPhoneImages(db *gorm.DB, phoneID uint) ([]models.PhoneImage, error) {
+	images := []models.PhoneImage{}
+	err := db.Where("phone_id = ?", phoneID).Order("position ASC, id ASC").Find(&images).Error
+	return images, err
+}
@@ -0,0 +1,281 @@
+package controller
+
+import (
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type phoneVariantInput struct {
+	Memory      uint   `json:"memory" binding:"required"`
+	Storage     uint   `json:"storage" binding:"required"`
+	Color       string `json:"color" binding:"required"`
+	Price       uint   `json:"price" binding:"required"`
+	IsAvailable *bool  `json:"is_available"`
+}
+
+type phoneVariantUpdate struct {
+	Memory      uint   `json:"memory"`
+	Storage     uint   `json:"storage"`
+	Color       string `json:"color"`
+	Price       uint   `json:"price"`
+	IsAvailable *bool  `json:"is_available"`
+}
+
+// Get phone variants by phone ID godoc
+// @Summary Get variants data by Phone id. (PUBLIC)
+// @Description Get all memory/storage/color variants of a phone, ordered by price
+// @Tags Phones
+// @Produce json
+// @Param id path string true "Phone id or slug"
+// @Success 200 {object} []models.PhoneVariant
+// @Router /phones/{id}/variants [get]
+func GetPhoneVariants(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var phone models.Phone
+	if err := visiblePhones(c, db).Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	var variants []models.PhoneVariant
+	if err := db.Where("phone_id = ?", phone.ID).
+		Order("price ASC, memory ASC, storage ASC").
+		Find(&variants).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, variants))
+}
+
+// Create phone variant godoc
+// @Summary Create Variant for phone (ADMIN ONLY)
+// @Description Creating a memory/storage/color variant with its own price for a phone, only admin can access this route
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "Phone id"
+// @Param Body body phoneVariantInput true "example JSON body to create a variant for phone, memory & storage in GB"
+// @Produce json
+// @Success 200 {object} models.PhoneVariant
+// @Router /phones/{id}/variants [post]
+func CreatePhoneVariant(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	// Validate input
+	var input phoneVariantInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	phoneID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	// cek data phone ada berdasarkan ID
+	var phone models.Phone
+	if err := db.Where("id = ?", phoneID).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	// satu kombinasi memory/storage/color hanya boleh ada satu per phone
+	if isPhoneVariantExist(db, phone.ID, 0, input.Memory, input.Storage, input.Color) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.MsgAlreadyExist("variant"), http.StatusBadRequest, nil))
+		return
+	}
+
+	variant_data := models.PhoneVariant{
+		Memory:      input.Memory,
+		Storage:     input.Storage,
+		Color:       input.Color,
+		Price:       input.Price,
+		IsAvailable: true,
+		PhoneID:     phone.ID,
+	}
+	if input.IsAvailable != nil {
+		variant_data.IsAvailable = *input.IsAvailable
+	}
+
+	if err := db.Create(&variant_data).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgAdded("variant"), http.StatusOK, variant_data))
+}
+
+// Update phone variant godoc
+// @Summary Update Variant for phone (ADMIN ONLY)
+// @Description Updating a phone variant data, only admin can access this route
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "Phone id"
+// @Param variant_id path string true "Variant id"
+// @Param Body body phoneVariantUpdate true "example JSON body to update a variant for phone"
+// @Produce json
+// @Success 200 {object} models.PhoneVariant
+// @Router /phones/{id}/variants/{variant_id} [put]
+func UpdatePhoneVariant(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	// Validate input
+	var input phoneVariantUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	var variant models.PhoneVariant
+	if err := db.Where("id = ? AND phone_id = ?", c.Param("variant_id"), c.Param("id")).First(&variant).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("variant"), http.StatusNotFound, nil))
+		return
+	}
+
+	// Update yg diinput saja
+	if input.Memory != 0 {
+		variant.Memory = input.Memory
+	}
+	if input.Storage != 0 {
+		variant.Storage = input.Storage
+	}
+	if input.Color != "" {
+		variant.Color = input.Color
+	}
+	if input.Price != 0 {
+		variant.Price = input.Price
+	}
+	if input.IsAvailable != nil {
+		variant.IsAvailable = *input.IsAvailable
+	}
+
+	if isPhoneVariantExist(db, variant.PhoneID, variant.ID, variant.Memory, variant.Storage, variant.Color) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.MsgAlreadyExist("variant"), http.StatusBadRequest, nil))
+		return
+	}
+
+	variant.UpdatedAt = time.Now()
+
+	if err := db.Save(&variant).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON("Failed to update variant", http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("variant"), http.StatusOK, variant))
+}
+
+// Delete phone variant godoc
+// @Summary Delete Variant for phone (ADMIN ONLY)
+// @Description Delete a phone variant by id, reviews tied to this variant will keep the review but lose the variant, only admin can access this route
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Param id path string true "Phone id"
+// @Param variant_id path string true "Variant id"
+// @Success 200 {object} map[string]boolean
+// @Router /phones/{id}/variants/{variant_id} [delete]
+func DeletePhoneVariant(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var variant models.PhoneVariant
+	if err := db.Where("id = ? AND phone_id = ?", c.Param("variant_id"), c.Param("id")).First(&variant).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("variant"), http.StatusNotFound, nil))
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// lepas relasi review ke variant yg dihapus
+		if err := tx.Model(&models.Review{}).Where("variant_id = ?", variant.ID).
+			Update("variant_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&variant).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgDeleted("variant"), http.StatusOK, nil))
+}
+
+func isPhoneVariantExist(db *gorm.DB, phoneID, exceptID, memory, storage uint, color string) bool {
+	var count int64
+	db.Model(&models.PhoneVariant{}).
+		Where("phone_id = ? AND id <> ? AND memory = ? AND storage = ? AND LOWER(color) = LOWER(?)",
+			phoneID, exceptID, memory, storage, color).
+		Count(&count)
+	return count > 0
+}
+
+// applyPhoneVariantFilter membatasi query phones ke phone yg memiliki
+// minimal satu variant yg cocok dengan semua filter variant yg diisi
+func applyPhoneVariantFilter(c *gin.Context, query *gorm.DB) *gorm.DB {
+	db := c.MustGet("db").(*gorm.DB)
+
+	sub := db.Model(&models.PhoneVariant{}).Select("phone_id")
+	filtered := false
+
+	if memory, err := strconv.Atoi(c.Query("memory")); err == nil {
+		sub = sub.Where("memory = ?", memory)
+		filtered = true
+	}
+	if storage, err := strconv.Atoi(c.Query("storage")); err == nil {
+		sub = sub.Where("storage = ?", storage)
+		filtered = true
+	}
+	if color := c.Query("color"); color != "" {
+		sub = sub.Where("LOWER(color) = LOWER(?)", color)
+		filtered = true
+	}
+	if minPrice, err := strconv.Atoi(c.Query("min_price")); err == nil {
+		sub = sub.Where("price >= ?", minPrice)
+		filtered = true
+	}
+	if maxPrice, err := strconv.Atoi(c.Query("max_price")); err == nil {
+		sub = sub.Where("price <= ?", maxPrice)
+		filtered = true
+	}
+	if available, err := strconv.ParseBool(c.Query("available")); err == nil {
+		sub = sub.Where("is_available = ?", available)
+		filtered = true
+	}
+
+	if !filtered {
+		return query
+	}
+
+	return query.Where("phones.id IN (?)", sub)
+}
@@ -0,0 +1,457 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type phonePriceInput struct {
+	// default mata uang region
+	Currency string `json:"currency"`
+	// harga dalam major unit, boleh angka atau string desimal, contoh 1299.99 / "1299.99"
+	Amount json.Number `json:"amount" binding:"required"`
+}
+
+type exchangeRateInput struct {
+	// nilai 1 major unit currency dalam IDR, contoh USD 15750.5
+	Rate float64 `json:"rate" binding:"required,gt=0"`
+}
+
+// LocalPrice harga phone sesuai query region / currency
+type LocalPrice struct {
+	utils.Money
+	Region string `json:"region,omitempty"`
+	// true jika harga hasil konversi kurs (region belum punya harga sendiri / beda mata uang)
+	Converted bool `json:"converted"`
+}
+
+type PhonePriceResponse struct {
+	Region    string       `json:"region"`
+	Price     utils.Money  `json:"price"`
+	Converted *utils.Money `json:"converted,omitempty"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+type PhonePricesResponse struct {
+	PhoneID   uint                 `json:"phone_id"`
+	BasePrice utils.Money          `json:"base_price"`
+	Prices    []PhonePriceResponse `json:"prices"`
+}
+
+// Get phone prices godoc
+// @Summary Get regional prices of a Phone. (PUBLIC)
+// @Description Get the base price (IDR) and every regional price of a phone. amount is in the currency minor unit (e.g. cents), display is the decimal value. send currency to also get every price converted with the exchange rate table
+// @Tags Phones
+// @Produce json
+// @Param id path string true "Phone id or slug"
+// @Param currency query string false "ISO 4217 currency code to convert the prices to, e.g. USD"
+// @Success 200 {object} PhonePricesResponse
+// @Router /phones/{id}/prices [get]
+func GetPhonePrices(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	_, currency, err := localPriceQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	var phone models.Phone
+	if err := visiblePhones(c, db).Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	var prices []models.PhonePrice
+	if err := db.Where("phone_id = ?", phone.ID).Order("region ASC").Find(&prices).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	rates, err := loadExchangeRates(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	result := PhonePricesResponse{
+		PhoneID:   phone.ID,
+		BasePrice: basePrice(float64(phone.Price)),
+		Prices:    []PhonePriceResponse{},
+	}
+	for _, price := range prices {
+		item := PhonePriceResponse{
+			Region:    price.Region,
+			Price:     utils.NewMoney(price.Currency, price.Amount),
+			UpdatedAt: price.UpdatedAt,
+		}
+		if currency != "" {
+			if converted, ok := rates.convert(item.Price, currency); ok {
+				item.Converted = &converted
+			}
+		}
+		result.Prices = append(result.Prices, item)
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, result))
+}
+
+// Set phone price godoc
+// @Summary Create or update regional price of a Phone. (ADMIN ONLY)
+// @Description Set the price of a phone in a region (ISO 3166-1 alpha-2, e.g. ID, MY, SG, US). currency defaults to the region currency, amount is the decimal value in major unit and may not have more decimals than the currency minor unit
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "Phone id"
+// @Param region path string true "region code, e.g. US"
+// @Param Body body phonePriceInput true "example JSON body to set a regional price, e.g. {\"currency\": \"USD\", \"amount\": \"1299.99\"}"
+// @Produce json
+// @Success 200 {object} models.PhonePrice
+// @Router /phones/{id}/prices/{region} [put]
+func SetPhonePrice(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input phonePriceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	region := strings.ToUpper(c.Param("region"))
+	regionCurrency, ok := utils.RegionCurrencies[region]
+	if !ok {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("region harus salah satu dari: "+codeList(utils.RegionCurrencies), http.StatusBadRequest, nil))
+		return
+	}
+
+	currency := strings.ToUpper(input.Currency)
+	if currency == "" {
+		currency = regionCurrency
+	}
+	if _, ok := utils.CurrencyMinorUnits[currency]; !ok {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("currency harus salah satu dari: "+codeList(utils.CurrencyMinorUnits), http.StatusBadRequest, nil))
+		return
+	}
+	amount, err := utils.ParseMinorUnits(input.Amount.String(), currency)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+	if amount == 0 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.MsgRequired("amount"), http.StatusBadRequest, nil))
+		return
+	}
+
+	var phone models.Phone
+	if err := db.Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	var price models.PhonePrice
+	err = db.Where("phone_id = ? AND region = ?", phone.ID, region).First(&price).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	isNew := price.ID == 0
+
+	price.PhoneID = phone.ID
+	price.Region = region
+	price.Currency = currency
+	price.Amount = amount
+	if err := db.Save(&price).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	message := lib.MsgUpdated("price")
+	if isNew {
+		message = lib.MsgAdded("price")
+	}
+	c.JSON(http.StatusOK, utils.ResponseJSON(message, http.StatusOK, price))
+}
+
+// Delete phone price godoc
+// @Summary Delete regional price of a Phone. (ADMIN ONLY)
+// @Description Delete the price of a phone in a region, the region falls back to the converted base price
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "Phone id"
+// @Param region path string true "region code, e.g. US"
+// @Produce json
+// @Success 200 {object} map[string]boolean
+// @Router /phones/{id}/prices/{region} [delete]
+func DeletePhonePrice(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var price models.PhonePrice
+	if err := db.Where("phone_id = ? AND region = ?", c.Param("id"), strings.ToUpper(c.Param("region"))).
+		First(&price).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("price"), http.StatusNotFound, nil))
+		return
+	}
+
+	if err := db.Delete(&price).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgDeleted("price"), http.StatusOK, true))
+}
+
+// Get exchange rates godoc
+// @Summary Get all exchange rates. (PUBLIC)
+// @Description Get exchange rates used to convert prices, rate is the value of 1 unit of the currency in IDR
+// @Tags Exchange Rates
+// @Produce json
+// @Success 200 {object} []models.ExchangeRate
+// @Router /exchange-rates [get]
+func GetExchangeRates(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var rates []models.ExchangeRate
+	if err := db.Order("currency ASC").Find(&rates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, rates))
+}
+
+// Set exchange rate godoc
+// @Summary Create or update exchange rate. (ADMIN ONLY)
+// @Description Set the exchange rate of a currency (ISO 4217), rate is the value of 1 unit of the currency in IDR
+// @Tags Exchange Rates
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param currency path string true "currency code, e.g. USD"
+// @Param Body body exchangeRateInput true "example JSON body to set an exchange rate"
+// @Produce json
+// @Success 200 {object} models.ExchangeRate
+// @Router /exchange-rates/{currency} [put]
+func SetExchangeRate(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input exchangeRateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	currency := strings.ToUpper(c.Param("currency"))
+	if _, ok := utils.CurrencyMinorUnits[currency]; !ok {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("currency harus salah satu dari: "+codeList(utils.CurrencyMinorUnits), http.StatusBadRequest, nil))
+		return
+	}
+	if currency == utils.BaseCurrency {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(fmt.Sprintf("kurs %s selalu 1", utils.BaseCurrency), http.StatusBadRequest, nil))
+		return
+	}
+
+	var rate models.ExchangeRate
+	err := db.Where("currency = ?", currency).First(&rate).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	isNew := rate.ID == 0
+
+	rate.Currency = currency
+	rate.Rate = input.Rate
+	if err := db.Save(&rate).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	message := lib.MsgUpdated("exchange rate")
+	if isNew {
+		message = lib.MsgAdded("exchange rate")
+	}
+	c.JSON(http.StatusOK, utils.ResponseJSON(message, http.StatusOK, rate))
+}
+
+// Delete exchange rate godoc
+// @Summary Delete exchange rate. (ADMIN ONLY)
+// @Description Delete the exchange rate of a currency, prices can no longer be converted to / from it
+// @Tags Exchange Rates
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param currency path string true "currency code, e.g. USD"
+// @Produce json
+// @Success 200 {object} map[string]boolean
+// @Router /exchange-rates/{currency} [delete]
+func DeleteExchangeRate(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var rate models.ExchangeRate
+	if err := db.Where("currency = ?", strings.ToUpper(c.Param("currency"))).First(&rate).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("exchange rate"), http.StatusNotFound, nil))
+		return
+	}
+
+	if err := db.Delete(&rate).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgDeleted("exchange rate"), http.StatusOK, true))
+}
+
+// exchangeRates kurs tiap mata uang terhadap utils.BaseCurrency
+type exchangeRates map[string]float64
+
+func loadExchangeRates(db *gorm.DB) (exchangeRates, error) {
+	var list []models.ExchangeRate
+	if err := db.Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	rates := exchangeRates{utils.BaseCurrency: 1}
+	for _, rate := range list {
+		rates[rate.Currency] = rate.Rate
+	}
+	return rates, nil
+}
+
+// convert false jika kurs salah satu mata uang belum ada
+func (rates exchangeRates) convert(money utils.Money, currency string) (utils.Money, bool) {
+	if money.Currency == currency {
+		return money, true
+	}
+	fromRate, ok := rates[money.Currency]
+	if !ok {
+		return utils.Money{}, false
+	}
+	toRate, ok := rates[currency]
+	if !ok {
+		return utils.Money{}, false
+	}
+	return utils.NewMoney(currency, utils.ConvertMinorUnits(money.Amount, money.Currency, fromRate, currency, toRate)), true
+}
+
+// basePrice harga dasar phone (major unit IDR) dalam bentuk Money
+func basePrice(price float64) utils.Money {
+	return utils.NewMoney(utils.BaseCurrency, int64(math.Round(price*math.Pow10(utils.CurrencyMinorUnits[utils.BaseCurrency]))))
+}
+
+// localPriceQuery baca & validasi query region / currency
+func localPriceQuery(c *gin.Context) (string, string, error) {
+	region := strings.ToUpper(c.Query("region"))
+	currency := strings.ToUpper(c.Query("currency"))
+
+	if _, ok := utils.RegionCurrencies[region]; region != "" && !ok {
+		return "", "", fmt.Errorf("region harus salah satu dari: %s", codeList(utils.RegionCurrencies))
+	}
+	if _, ok := utils.CurrencyMinorUnits[currency]; currency != "" && !ok {
+		return "", "", fmt.Errorf("currency harus salah satu dari: %s", codeList(utils.CurrencyMinorUnits))
+	}
+
+	return region, currency, nil
+}
+
+// codeList daftar kode region / currency yg didukung, urut & dipisah koma
+func codeList[V any](codes map[string]V) string {
+	list := make([]string, 0, len(codes))
+	for code := range codes {
+		list = append(list, code)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
+
+// applyLocalPrices isi LocalPrice phone sesuai region / currency. harga region
+// dipakai jika ada, selain itu harga dasar dikonversi ke mata uang region / currency
+func applyLocalPrices(db *gorm.DB, phones []PhonesCompleteResponse, region, currency string) error {
+	if (region == "" && currency == "") || len(phones) == 0 {
+		return nil
+	}
+
+	rates, err := loadExchangeRates(db)
+	if err != nil {
+		return err
+	}
+
+	regional := map[int]models.PhonePrice{}
+	if region != "" {
+		ids := make([]int, len(phones))
+		for i, phone := range phones {
+			ids[i] = phone.PhoneID
+		}
+		var prices []models.PhonePrice
+		if err := db.Where("phone_id IN ? AND region = ?", ids, region).Find(&prices).Error; err != nil {
+			return err
+		}
+		for _, price := range prices {
+			regional[int(price.PhoneID)] = price
+		}
+	}
+
+	target := currency
+	if target == "" {
+		target = utils.RegionCurrencies[region]
+	}
+
+	for i := range phones {
+		money := basePrice(phones[i].Price)
+		if price, ok := regional[phones[i].PhoneID]; ok {
+			money = utils.NewMoney(price.Currency, price.Amount)
+		}
+
+		converted := false
+
+		if money.Currency != target {
+			result, ok := rates.convert(money, target)
+			if !ok {
+				continue
+			}
+			money = result
+			converted = true
+		}
+
+		phones[i].LocalPrice = &LocalPrice{Money: money, Region: region, Converted: converted}
+	}
+
+	return nil
+}
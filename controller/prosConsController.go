@@ -0,0 +1,305 @@
+package controller
+
+import (
+	"final-project/contentfilter"
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	maxProsConsItems      = 10
+	minProConLength       = 2
+	maxProConLength       = 150
+	defaultProsConsLimit  = 10
+	maxProsConsLimit      = 50
+	proConClusterMinScore = 0.5
+)
+
+// kata umum yg diabaikan saat mengelompokkan poin yg mirip
+var proConStopwords = map[string]bool{
+	"yang": true, "yg": true, "dan": true, "di": true, "ke": true, "dari": true, "ini": true,
+	"itu": true, "sangat": true, "banget": true, "bgt": true, "cukup": true, "agak": true,
+	"sih": true, "juga": true, "untuk": true, "dengan": true, "dgn": true, "nya": true,
+	"lumayan": true, "sekali": true, "sudah": true, "udah": true, "ada": true, "bisa": true,
+	"the": true, "a": true, "an": true, "is": true, "are": true, "very": true, "really": true,
+	"quite": true, "and": true, "of": true, "for": true, "with": true, "it": true, "its": true,
+	"so": true, "pretty": true, "has": true, "have": true,
+}
+
+type ProConMention struct {
+	// teks yg paling sering dipakai dalam kelompok
+	Text string `json:"text"`
+	// jumlah review yg menyebut poin ini
+	Mentions int `json:"mentions"`
+	// teks lain yg dianggap sama
+	Variants []string `json:"variants"`
+}
+
+type ProsConsSummary struct {
+	PhoneID uint `json:"phone_id"`
+	// jumlah review approved yg punya pros / cons
+	ReviewCount int             `json:"review_count"`
+	Pros        []ProConMention `json:"pros"`
+	Cons        []ProConMention `json:"cons"`
+}
+
+// Get phone pros & cons godoc
+// @Summary Get the most mentioned pros & cons of a phone. (PUBLIC)
+// @Description Similar pros / cons of all approved reviews of a phone are grouped together (ignoring case, punctuation & filler words) and ranked by the number of reviews mentioning them
+// @Tags Phones
+// @Produce json
+// @Param id path string true "Phone id or slug"
+// @Param limit query int false "maximum number of pros and of cons (default 10, max 50)"
+// @Success 200 {object} ProsConsSummary
+// @Router /phones/{id}/pros-cons [get]
+func GetPhoneProsCons(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var phone models.Phone
+	if err := visiblePhones(c, db).Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultProsConsLimit)))
+	if err != nil || limit < 1 || limit > maxProsConsLimit {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(fmt.Sprintf("limit harus berupa angka 1 - %d", maxProsConsLimit), http.StatusBadRequest, nil))
+		return
+	}
+
+	var points []models.ReviewProCon
+	if err := db.Model(&models.ReviewProCon{}).
+		Select("review_pro_cons.*").
+		Joins("JOIN reviews on reviews.id = review_pro_cons.review_id").
+		Where("reviews.phone_id = ? AND reviews.status = ?", phone.ID, models.ReviewStatusApproved).
+		Order("review_pro_cons.review_id ASC, review_pro_cons.position ASC").
+		Find(&points).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	reviews := map[uint]bool{}
+	var pros, cons []models.ReviewProCon
+	for _, point := range points {
+		reviews[point.ReviewID] = true
+		if point.Kind == models.ReviewProConPro {
+			pros = append(pros, point)
+		} else {
+			cons = append(cons, point)
+		}
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, ProsConsSummary{
+		PhoneID:     phone.ID,
+		ReviewCount: len(reviews),
+		Pros:        clusterProsCons(pros, limit),
+		Cons:        clusterProsCons(cons, limit),
+	}))
+}
+
+type proConCluster struct {
+	tokens  map[string]bool
+	reviews map[uint]bool
+	// jumlah pemakaian tiap teks & urutan kemunculan pertamanya
+	texts map[string]int
+	order []string
+}
+
+// clusterProsCons kelompokkan poin yg mirip (jaccard kata >= proConClusterMinScore)
+// lalu urutkan dari yg paling banyak disebut
+func clusterProsCons(points []models.ReviewProCon, limit int) []ProConMention {
+	var clusters []*proConCluster
+	for _, point := range points {
+		tokens := proConTokens(point.Text)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		var best *proConCluster
+		bestScore := 0.0
+		for _, cluster := range clusters {
+			if score := jaccard(tokens, cluster.tokens); score >= proConClusterMinScore && score > bestScore {
+				best, bestScore = cluster, score
+			}
+		}
+		if best == nil {
+			best = &proConCluster{tokens: tokens, reviews: map[uint]bool{}, texts: map[string]int{}}
+			clusters = append(clusters, best)
+		}
+
+		best.reviews[point.ReviewID] = true
+		text := strings.TrimSpace(point.Text)
+		if _, ok := best.texts[text]; !ok {
+			best.order = append(best.order, text)
+		}
+		best.texts[text]++
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i].reviews) > len(clusters[j].reviews)
+	})
+	if len(clusters) > limit {
+		clusters = clusters[:limit]
+	}
+
+	mentions := make([]ProConMention, 0, len(clusters))
+	for _, cluster := range clusters {
+		texts := append([]string{}, cluster.order...)
+		sort.SliceStable(texts, func(i, j int) bool {
+			return cluster.texts[texts[i]] > cluster.texts[texts[j]]
+		})
+		mentions = append(mentions, ProConMention{
+			Text:     texts[0],
+			Mentions: len(cluster.reviews),
+			Variants: texts[1:],
+		})
+	}
+	return mentions
+}
+
+// proConTokens kata penting dalam poin, akhiran -nya & bentuk jamak -s dihapus
+func proConTokens(text string) map[string]bool {
+	tokens := map[string]bool{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(word) > 5 && strings.HasSuffix(word, "nya") {
+			word = strings.TrimSuffix(word, "nya")
+		}
+		if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			word = strings.TrimSuffix(word, "s")
+		}
+		if !proConStopwords[word] {
+			tokens[word] = true
+		}
+	}
+	return tokens
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	intersection := 0
+	for token := range a {
+		if b[token] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	if union == 0 {
+		return 0
+	}
+	return float64(intersection) / float64(union)
+}
+
+// isProsConsValid rapikan & validasi daftar pros / cons, nil berarti tidak dikirim
+func isProsConsValid(c *gin.Context, name string, items []string) ([]string, bool) {
+	if items == nil {
+		return nil, true
+	}
+	if len(items) > maxProsConsItems {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(fmt.Sprintf("%s maksimal %d poin", name, maxProsConsItems), http.StatusBadRequest, nil))
+		return nil, false
+	}
+
+	result := make([]string, 0, len(items))
+	seen := map[string]bool{}
+	for _, item := range items {
+		item = strings.Join(strings.Fields(item), " ")
+		length := utf8.RuneCountInString(item)
+		if length < minProConLength || length > maxProConLength {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(fmt.Sprintf("tiap poin %s harus %d - %d karakter", name, minProConLength, maxProConLength), http.StatusBadRequest, nil))
+			return nil, false
+		}
+		if seen[strings.ToLower(item)] {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(fmt.Sprintf("poin %s %q ditulis lebih dari sekali", name, item), http.StatusBadRequest, nil))
+			return nil, false
+		}
+		seen[strings.ToLower(item)] = true
+		result = append(result, item)
+	}
+	return result, true
+}
+
+// filterProsCons jalankan filter konten pada tiap poin, poin yg tertahan filter
+// membuat review masuk antrian moderasi (moderated berisi hasil filternya)
+func filterProsCons(c *gin.Context, db *gorm.DB, input contentfilter.Input, items []string) ([]string, *contentfilter.Result, bool) {
+	var moderated *contentfilter.Result
+	for i, item := range items {
+		input.Content = item
+		filtered, ok := filterContent(c, db, input)
+		if !ok {
+			return nil, nil, false
+		}
+		items[i] = filtered.Content
+		if filtered.Action == contentfilter.ActionModerate && moderated == nil {
+			moderated = &filtered
+		}
+	}
+	return items, moderated, true
+}
+
+// saveProsCons ganti poin pros / cons review, items nil berarti tidak diubah
+func saveProsCons(tx *gorm.DB, reviewID uint, kind string, items []string) error {
+	if items == nil {
+		return nil
+	}
+	if err := tx.Where("review_id = ? AND kind = ?", reviewID, kind).Delete(&models.ReviewProCon{}).Error; err != nil {
+		return err
+	}
+	for i, item := range items {
+		point := models.ReviewProCon{ReviewID: reviewID, Kind: kind, Text: item, Position: uint(i) + 1}
+		if err := tx.Create(&point).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getReviewProsCons pros & cons tiap review sesuai urutan
+func getReviewProsCons(db *gorm.DB, reviewIDs []uint) (map[uint][]string, map[uint][]string, error) {
+	pros, cons := map[uint][]string{}, map[uint][]string{}
+	if len(reviewIDs) == 0 {
+		return pros, cons, nil
+	}
+
+	var points []models.ReviewProCon
+	if err := db.Where("review_id IN ?", reviewIDs).Order("position ASC, id ASC").Find(&points).Error; err != nil {
+		return nil, nil, err
+	}
+	for _, point := range points {
+		if point.Kind == models.ReviewProConPro {
+			pros[point.ReviewID] = append(pros[point.ReviewID], point.Text)
+		} else {
+			cons[point.ReviewID] = append(cons[point.ReviewID], point.Text)
+		}
+	}
+	return pros, cons, nil
+}
+
+// loadProsConsOfReview isi pros & cons review untuk response
+func loadProsConsOfReview(c *gin.Context, db *gorm.DB, review *models.Review) bool {
+	pros, cons, err := getReviewProsCons(db, []uint{review.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return false
+	}
+	review.Pros, review.Cons = pros[review.ID], cons[review.ID]
+	return true
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"final-project/models"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestProConTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want map[string]bool
+	}{
+		{"Baterainya sangat awet!", map[string]bool{"baterai": true, "awet": true}},
+		// akhiran -s kata Indonesia ikut terpotong, tetap sama di semua poin
+		{"Kamera bagus banget", map[string]bool{"kamera": true, "bagu": true}},
+		{"Great speakers", map[string]bool{"great": true, "speaker": true}},
+		{"Glass back", map[string]bool{"glass": true, "back": true}},
+		{"yg itu juga", map[string]bool{}},
+	}
+	for _, tt := range tests {
+		if got := proConTokens(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("proConTokens(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	set := func(words ...string) map[string]bool {
+		m := map[string]bool{}
+		for _, w := range words {
+			m[w] = true
+		}
+		return m
+	}
+
+	tests := []struct {
+		name string
+		a, b map[string]bool
+		want float64
+	}{
+		{"sama persis", set("baterai", "awet"), set("baterai", "awet"), 1},
+		{"sebagian sama", set("baterai", "awet"), set("baterai", "boros"), 1.0 / 3},
+		{"subset", set("kamera", "bagus", "malam"), set("kamera", "bagus"), 2.0 / 3},
+		{"tidak ada yg sama", set("layar"), set("speaker"), 0},
+		{"keduanya kosong", set(), set(), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jaccard(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("jaccard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterProsCons(t *testing.T) {
+	points := []models.ReviewProCon{
+		{ReviewID: 1, Text: "Baterai awet"},
+		{ReviewID: 2, Text: "baterainya awet banget"},
+		{ReviewID: 3, Text: "Baterai awet"},
+		{ReviewID: 3, Text: "Kamera bagus"},
+		{ReviewID: 4, Text: "kamera bagus sekali"},
+		{ReviewID: 4, Text: "Speaker keras"},
+		// review yg sama menyebut dua kali tetap dihitung satu
+		{ReviewID: 1, Text: "baterai awet!"},
+		{ReviewID: 5, Text: "yg itu"},
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []ProConMention
+	}{
+		{
+			name:  "semua kelompok",
+			limit: 10,
+			want: []ProConMention{
+				{Text: "Baterai awet", Mentions: 3, Variants: []string{"baterainya awet banget", "baterai awet!"}},
+				{Text: "Kamera bagus", Mentions: 2, Variants: []string{"kamera bagus sekali"}},
+				{Text: "Speaker keras", Mentions: 1, Variants: []string{}},
+			},
+		},
+		{
+			name:  "dibatasi limit",
+			limit: 1,
+			want: []ProConMention{
+				{Text: "Baterai awet", Mentions: 3, Variants: []string{"baterainya awet banget", "baterai awet!"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clusterProsCons(points, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("clusterProsCons() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
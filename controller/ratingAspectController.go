@@ -0,0 +1,341 @@
+package controller
+
+import (
+	"errors"
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ratingAspectInput struct {
+	Key         string `json:"key" binding:"required"`
+	Label       string `json:"label" binding:"required"`
+	Description string `json:"description"`
+	Position    uint   `json:"position"`
+}
+
+type ratingAspectUpdate struct {
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	Position    *uint  `json:"position"`
+}
+
+// Get all rating aspects godoc
+// @Summary Get all review rating aspects. (PUBLIC)
+// @Description Get a list of aspects (camera, battery, performance, ...) that can be rated in a review
+// @Tags Rating Aspects
+// @Produce json
+// @Success 200 {object} []models.RatingAspect
+// @Router /rating-aspects [get]
+func GetAllRatingAspects(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var aspects []models.RatingAspect
+	if err := db.Order("position ASC, id ASC").Find(&aspects).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, aspects))
+}
+
+// Create rating aspect godoc
+// @Summary Create review rating aspect (ADMIN ONLY)
+// @Description Register a new aspect that can be rated in a review, key must be lowercase snake_case
+// @Tags Rating Aspects
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param Body body ratingAspectInput true "example JSON body to create a rating aspect"
+// @Produce json
+// @Success 200 {object} models.RatingAspect
+// @Router /rating-aspects [post]
+func CreateRatingAspect(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input ratingAspectInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	if !attributeKeyRegex.MatchString(input.Key) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("key harus huruf kecil, angka atau underscore (snake_case)", http.StatusBadRequest, nil))
+		return
+	}
+
+	var count int64
+	db.Model(&models.RatingAspect{}).Where("aspect_key = ?", input.Key).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.MsgAlreadyExist("key"), http.StatusBadRequest, nil))
+		return
+	}
+
+	aspect_data := models.RatingAspect{
+		Key:         input.Key,
+		Label:       strings.TrimSpace(input.Label),
+		Description: input.Description,
+		Position:    input.Position,
+	}
+
+	if err := db.Create(&aspect_data).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgAdded("rating aspect"), http.StatusOK, aspect_data))
+}
+
+// Update rating aspect godoc
+// @Summary Update review rating aspect (ADMIN ONLY)
+// @Description Update label, description or position of a rating aspect by id, key can not be changed
+// @Tags Rating Aspects
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "aspect id"
+// @Param Body body ratingAspectUpdate true "example JSON body to update a rating aspect"
+// @Produce json
+// @Success 200 {object} models.RatingAspect
+// @Router /rating-aspects/{id} [put]
+func UpdateRatingAspect(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input ratingAspectUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	var aspect models.RatingAspect
+	if err := db.Where("id = ?", c.Param("id")).First(&aspect).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("rating aspect"), http.StatusNotFound, nil))
+		return
+	}
+
+	// Update yang diinput saja
+	if input.Label != "" {
+		aspect.Label = strings.TrimSpace(input.Label)
+	}
+	if input.Description != "" {
+		aspect.Description = input.Description
+	}
+	if input.Position != nil {
+		aspect.Position = *input.Position
+	}
+	aspect.UpdatedAt = time.Now()
+
+	if err := db.Save(&aspect).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON("Failed to update rating aspect", http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("rating aspect"), http.StatusOK, aspect))
+}
+
+// Delete rating aspect godoc
+// @Summary Delete review rating aspect (ADMIN ONLY)
+// @Description Delete a rating aspect by id, including its ratings in every review
+// @Tags Rating Aspects
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Param id path string true "aspect id"
+// @Success 200 {object} map[string]boolean
+// @Router /rating-aspects/{id} [delete]
+func DeleteRatingAspect(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var aspect models.RatingAspect
+	if err := db.Where("id = ?", c.Param("id")).First(&aspect).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("rating aspect"), http.StatusNotFound, nil))
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("aspect_id = ?", aspect.ID).Delete(&models.ReviewAspectRating{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&aspect).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgDeleted("rating aspect"), http.StatusOK, nil))
+}
+
+// isAspectRatingsValid cek key aspek terdaftar & rating 1 - 5 (0 hanya untuk
+// menghapus rating aspek saat update), mengembalikan aspek berdasarkan key
+func isAspectRatingsValid(c *gin.Context, db *gorm.DB, ratings map[string]uint, allowZero bool) (map[string]models.RatingAspect, bool) {
+	aspects := map[string]models.RatingAspect{}
+	if len(ratings) == 0 {
+		return aspects, true
+	}
+
+	keys := make([]string, 0, len(ratings))
+	for key := range ratings {
+		keys = append(keys, key)
+	}
+	var list []models.RatingAspect
+	if err := db.Where("aspect_key IN ?", keys).Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return nil, false
+	}
+	for _, aspect := range list {
+		aspects[aspect.Key] = aspect
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, ok := aspects[key]; !ok {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(lib.ErrMsgNotFound("rating aspect "+key), http.StatusBadRequest, nil))
+			return nil, false
+		}
+		rating := ratings[key]
+		if rating > 5 || (rating == 0 && !allowZero) {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(fmt.Sprintf("rating aspek %s harus 1 - 5", key), http.StatusBadRequest, nil))
+			return nil, false
+		}
+	}
+	return aspects, true
+}
+
+// saveAspectRatings simpan rating aspek review, rating 0 menghapus rating aspek tsb
+func saveAspectRatings(tx *gorm.DB, reviewID uint, ratings map[string]uint, aspects map[string]models.RatingAspect) error {
+	for key, rating := range ratings {
+		aspect := aspects[key]
+		if rating == 0 {
+			if err := tx.Where("review_id = ? AND aspect_id = ?", reviewID, aspect.ID).Delete(&models.ReviewAspectRating{}).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		var value models.ReviewAspectRating
+		err := tx.Where(models.ReviewAspectRating{ReviewID: reviewID, AspectID: aspect.ID}).
+			Assign(models.ReviewAspectRating{Rating: rating}).
+			FirstOrCreate(&value).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getReviewAspectRatings rating aspek review beserta aspeknya, urut sesuai posisi aspek
+func getReviewAspectRatings(db *gorm.DB, reviewIDs []uint) (map[uint][]models.ReviewAspectRating, error) {
+	result := map[uint][]models.ReviewAspectRating{}
+	if len(reviewIDs) == 0 {
+		return result, nil
+	}
+
+	// alias join "Aspect" di-quote oleh gorm, kolomnya harus di-quote juga
+	// agar tidak dibaca sebagai aspect (huruf kecil) di postgres
+	var ratings []models.ReviewAspectRating
+	if err := db.Joins("Aspect").
+		Where("review_aspect_ratings.review_id IN ?", reviewIDs).
+		Order(clause.OrderByColumn{Column: clause.Column{Table: "Aspect", Name: "position"}}).
+		Order(clause.OrderByColumn{Column: clause.Column{Table: "Aspect", Name: "id"}}).
+		Find(&ratings).Error; err != nil {
+		return nil, err
+	}
+	for _, rating := range ratings {
+		result[rating.ReviewID] = append(result[rating.ReviewID], rating)
+	}
+	return result, nil
+}
+
+// phoneAspectAverages rata-rata rating tiap aspek per phone (key aspek -> rata-rata)
+func phoneAspectAverages(db *gorm.DB, phoneIDs []uint) (map[uint]map[string]float64, error) {
+	result := map[uint]map[string]float64{}
+	if len(phoneIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		PhoneID   uint
+		AspectKey string
+		AVGRating float64
+	}
+	if err := db.Table("review_aspect_ratings").
+		Select("reviews.phone_id, rating_aspects.aspect_key, AVG(review_aspect_ratings.rating) as avg_rating").
+		Joins("JOIN reviews on reviews.id = review_aspect_ratings.review_id").
+		Joins("JOIN rating_aspects on rating_aspects.id = review_aspect_ratings.aspect_id").
+		Where("reviews.phone_id IN ? AND reviews.status = ?", phoneIDs, models.ReviewStatusApproved).
+		Group("reviews.phone_id, rating_aspects.aspect_key").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if result[row.PhoneID] == nil {
+			result[row.PhoneID] = map[string]float64{}
+		}
+		result[row.PhoneID][row.AspectKey] = math.Round(row.AVGRating*100) / 100
+	}
+	return result, nil
+}
+
+// applyAspectRatings isi rata-rata rating aspek tiap phone
+func applyAspectRatings(db *gorm.DB, phones []PhonesCompleteResponse) error {
+	ids := make([]uint, 0, len(phones))
+	for _, phone := range phones {
+		ids = append(ids, uint(phone.PhoneID))
+	}
+
+	averages, err := phoneAspectAverages(db, ids)
+	if err != nil {
+		return err
+	}
+	for i := range phones {
+		phones[i].AspectRatings = averages[uint(phones[i].PhoneID)]
+	}
+	return nil
+}
+
+// applyAspectSort urutkan phone berdasarkan rata-rata rating satu aspek,
+// phone tanpa rating aspek tsb selalu di akhir
+func applyAspectSort(db *gorm.DB, query *gorm.DB, key, direction string) (*gorm.DB, error) {
+	var aspect models.RatingAspect
+	if err := db.Where("aspect_key = ?", key).First(&aspect).Error; err != nil {
+		return query, errors.New(lib.ErrMsgNotFound("rating aspect " + key))
+	}
+
+	return query.
+		Joins(`LEFT JOIN (SELECT reviews.phone_id, AVG(review_aspect_ratings.rating) as aspect_avg
+				FROM review_aspect_ratings
+				JOIN reviews on reviews.id = review_aspect_ratings.review_id
+				WHERE review_aspect_ratings.aspect_id = ? AND reviews.status = ?
+				GROUP BY reviews.phone_id) aspect_sort on aspect_sort.phone_id = phones.id`, aspect.ID, models.ReviewStatusApproved).
+		Order("MAX(aspect_sort.aspect_avg) IS NULL").
+		Order("MAX(aspect_sort.aspect_avg) " + direction).
+		Order("phone_id ASC"), nil
+}
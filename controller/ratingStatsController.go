@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type RatingBucket struct {
+	Rating  uint    `json:"rating"`
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+type RatingTrendPoint struct {
+	// bulan format YYYY-MM
+	Month       string   `json:"month"`
+	ReviewCount int      `json:"review_count"`
+	AvgRating   *float64 `json:"avg_rating"`
+	// rata-rata semua review sampai akhir bulan ini
+	CumulativeAvgRating *float64 `json:"cumulative_avg_rating"`
+}
+
+type RatingStats struct {
+	PhoneID           uint               `json:"phone_id"`
+	ReviewCount       int                `json:"review_count"`
+	AvgRating         float64            `json:"avg_rating"`
+	MedianRating      float64            `json:"median_rating"`
+	StandardDeviation float64            `json:"standard_deviation"`
+	Histogram         []RatingBucket     `json:"histogram"`
+	Trend             []RatingTrendPoint `json:"trend"`
+}
+
+// Get phone rating stats godoc
+// @Summary Get rating distribution & trend of a phone. (PUBLIC)
+// @Description Get the 1 - 5 star histogram, review count, average, median & standard deviation of the approved reviews of a phone, plus the average rating per month (months without reviews have a null average) to see whether the reception changed over time
+// @Tags Phones
+// @Produce json
+// @Param id path string true "Phone id or slug"
+// @Param months query int false "only the last n months in the trend, default since the first review"
+// @Success 200 {object} RatingStats
+// @Router /phones/{id}/rating-stats [get]
+func GetPhoneRatingStats(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var phone models.Phone
+	if err := visiblePhones(c, db).Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	months := 0
+	if value := c.Query("months"); value != "" {
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 1 || number > 120 {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON("months harus berupa angka 1 - 120", http.StatusBadRequest, nil))
+			return
+		}
+		months = number
+	}
+
+	var reviews []ratedReview
+	if err := approvedReviews(db.Model(&models.Review{})).
+		Select("rating, created_at").
+		Where("phone_id = ?", phone.ID).
+		Order("created_at ASC").
+		Scan(&reviews).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	stats := RatingStats{PhoneID: phone.ID, ReviewCount: len(reviews), Histogram: []RatingBucket{}, Trend: []RatingTrendPoint{}}
+
+	counts := map[uint]int{}
+	ratings := make([]float64, 0, len(reviews))
+	for _, review := range reviews {
+		counts[review.Rating]++
+		ratings = append(ratings, float64(review.Rating))
+	}
+	// bintang 5 lebih dulu seperti tampilan star breakdown
+	for rating := uint(5); rating >= 1; rating-- {
+		bucket := RatingBucket{Rating: rating, Count: counts[rating]}
+		if len(reviews) > 0 {
+			bucket.Percent = roundRating(float64(counts[rating]) * 100 / float64(len(reviews)))
+		}
+		stats.Histogram = append(stats.Histogram, bucket)
+	}
+
+	if len(ratings) > 0 {
+		mean, median, stddev := ratingSummary(ratings)
+		stats.AvgRating = roundRating(mean)
+		stats.MedianRating = median
+		stats.StandardDeviation = roundRating(stddev)
+
+		stats.Trend = ratingTrend(reviews, months, time.Now())
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, stats))
+}
+
+// ratingSummary rata-rata, median & standar deviasi populasi rating, ratings
+// tidak boleh kosong & ikut diurutkan
+func ratingSummary(ratings []float64) (mean, median, stddev float64) {
+	for _, rating := range ratings {
+		mean += rating
+	}
+	mean /= float64(len(ratings))
+
+	sumSquares := 0.0
+	for _, rating := range ratings {
+		sumSquares += (rating - mean) * (rating - mean)
+	}
+	stddev = math.Sqrt(sumSquares / float64(len(ratings)))
+
+	sort.Float64s(ratings)
+	middle := len(ratings) / 2
+	median = ratings[middle]
+	if len(ratings)%2 == 0 {
+		median = (ratings[middle-1] + ratings[middle]) / 2
+	}
+	return mean, median, stddev
+}
+
+type ratedReview struct {
+	Rating    uint
+	CreatedAt time.Time
+}
+
+// ratingTrend rata-rata rating per bulan dari bulan review pertama (atau n bulan
+// terakhir) sampai bulan now, bulan tanpa review tetap ada dgn avg null.
+// reviews harus urut dari yg terlama
+func ratingTrend(reviews []ratedReview, months int, now time.Time) []RatingTrendPoint {
+	now = now.In(time.Local)
+	first := reviews[0].CreatedAt.In(time.Local)
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	start := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.Local)
+	if months > 0 {
+		start = current.AddDate(0, -(months - 1), 0)
+	}
+
+	type monthSum struct {
+		count int
+		sum   float64
+	}
+	sums := map[string]*monthSum{}
+	// review sebelum awal trend tetap dihitung di rata-rata kumulatif
+	var before monthSum
+	for _, review := range reviews {
+		createdAt := review.CreatedAt.In(time.Local)
+		if createdAt.Before(start) {
+			before.count++
+			before.sum += float64(review.Rating)
+			continue
+		}
+		key := createdAt.Format("2006-01")
+		if sums[key] == nil {
+			sums[key] = &monthSum{}
+		}
+		sums[key].count++
+		sums[key].sum += float64(review.Rating)
+	}
+
+	var trend []RatingTrendPoint
+	total := before
+	for month := start; !month.After(current); month = month.AddDate(0, 1, 0) {
+		point := RatingTrendPoint{Month: month.Format("2006-01")}
+		if sum := sums[point.Month]; sum != nil {
+			avg := roundRating(sum.sum / float64(sum.count))
+			point.ReviewCount = sum.count
+			point.AvgRating = &avg
+			total.count += sum.count
+			total.sum += sum.sum
+		}
+		if total.count > 0 {
+			cumulative := roundRating(total.sum / float64(total.count))
+			point.CumulativeAvgRating = &cumulative
+		}
+		trend = append(trend, point)
+	}
+	return trend
+}
+
+func roundRating(value float64) float64 {
+	return math.Round(value*100) / 100
+}
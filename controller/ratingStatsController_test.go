@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRatingSummary(t *testing.T) {
+	tests := []struct {
+		name                string
+		ratings             []float64
+		mean, median, stdev float64
+	}{
+		{"satu review", []float64{4}, 4, 4, 0},
+		{"jumlah ganjil", []float64{5, 1, 3}, 3, 3, math.Sqrt(8.0 / 3)},
+		{"jumlah genap", []float64{5, 4, 2, 1}, 3, 3, math.Sqrt(2.5)},
+		{"rating sama", []float64{5, 5, 5, 5}, 5, 5, 0},
+		{"median genap tidak bulat", []float64{4, 5, 5, 4, 5, 3}, 13.0 / 3, 4.5, math.Sqrt(5.0 / 9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mean, median, stddev := ratingSummary(tt.ratings)
+			if math.Abs(mean-tt.mean) > 1e-9 || math.Abs(median-tt.median) > 1e-9 || math.Abs(stddev-tt.stdev) > 1e-9 {
+				t.Errorf("ratingSummary() = %v, %v, %v, want %v, %v, %v", mean, median, stddev, tt.mean, tt.median, tt.stdev)
+			}
+		})
+	}
+}
+
+func TestRatingTrend(t *testing.T) {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 12, 0, 0, 0, time.Local)
+	}
+	now := at(2024, time.May, 20)
+	reviews := []ratedReview{
+		{Rating: 5, CreatedAt: at(2024, time.January, 3)},
+		{Rating: 3, CreatedAt: at(2024, time.January, 28)},
+		{Rating: 2, CreatedAt: at(2024, time.March, 15)},
+		{Rating: 5, CreatedAt: at(2024, time.May, 1)},
+	}
+
+	type point struct {
+		Month                 string
+		ReviewCount           int
+		AvgRating, Cumulative float64
+	}
+	// avg -1 berarti null (bulan tanpa review)
+	tests := []struct {
+		name   string
+		months int
+		want   []point
+	}{
+		{
+			name:   "sejak review pertama",
+			months: 0,
+			want: []point{
+				{"2024-01", 2, 4, 4},
+				{"2024-02", 0, -1, 4},
+				{"2024-03", 1, 2, 3.33},
+				{"2024-04", 0, -1, 3.33},
+				{"2024-05", 1, 5, 3.75},
+			},
+		},
+		{
+			name:   "n bulan terakhir, review lama tetap di kumulatif",
+			months: 2,
+			want: []point{
+				{"2024-04", 0, -1, 3.33},
+				{"2024-05", 1, 5, 3.75},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []point
+			for _, p := range ratingTrend(reviews, tt.months, now) {
+				gp := point{Month: p.Month, ReviewCount: p.ReviewCount, AvgRating: -1, Cumulative: -1}
+				if p.AvgRating != nil {
+					gp.AvgRating = *p.AvgRating
+				}
+				if p.CumulativeAvgRating != nil {
+					gp.Cumulative = *p.CumulativeAvgRating
+				}
+				got = append(got, gp)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ratingTrend() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"errors"
+	"final-project/models"
+	"final-project/recommendation"
+	"final-project/utils"
+	"final-project/utils/token"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type RecommendationResponse struct {
+	PhonesCompleteResponse
+	Score float64 `json:"score"`
+	// collaborative / content / popular
+	Source     string     `json:"source"`
+	ComputedAt *time.Time `json:"computed_at"`
+}
+
+// Get my recommendations godoc
+// @Summary Get phone recommendations for logged in user
+// @Description Get phones the user has not reviewed yet, recommended from the user's review ratings (item-item collaborative filtering, with spec-based fallback for users with few reviews). recommendations are recomputed periodically by a cron job (see /admin/recommendations/recompute), users without reviews get the top phones of the bayesian leaderboard (source: popular)
+// @Tags Users
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param limit query int false "number of phones, default 10, max 20"
+// @Produce json
+// @Success 200 {object} []RecommendationResponse
+// @Router /users/me/recommendations [get]
+func GetMyRecommendations(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	// user id dari token
+	userID, err := token.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+	limit = min(limit, recommendation.MaxPerUser)
+
+	// phone yg sudah direview setelah rekomendasi dihitung tetap disaring
+	reviewed := db.Model(&models.Review{}).Select("phone_id").Where("user_id = ?", userID)
+
+	// phone draft disaring sebelum limit agar jumlah hasil tetap sesuai limit
+	var cached []models.UserRecommendation
+	if err := db.Model(&models.UserRecommendation{}).
+		Select("user_recommendations.*").
+		Joins("JOIN phones on phones.id = user_recommendations.phone_id").
+		Where("user_recommendations.user_id = ? AND user_recommendations.phone_id NOT IN (?)", userID, reviewed).
+		Where("phones.status <> ?", models.PhoneStatusDraft).
+		Order("user_recommendations.position ASC").
+		Limit(limit).
+		Find(&cached).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	result := []RecommendationResponse{}
+
+	if len(cached) > 0 {
+		ids := make([]uint, len(cached))
+		for i, r := range cached {
+			ids[i] = r.PhoneID
+		}
+
+		byID, err := phonesCompleteByID(db, ids)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+
+		// urutan mengikuti hasil rekomendasi, phone yg sudah dihapus dilewati
+		for _, r := range cached {
+			phone, ok := byID[int(r.PhoneID)]
+			if !ok {
+				continue
+			}
+			computedAt := r.CreatedAt
+			result = append(result, RecommendationResponse{
+				PhonesCompleteResponse: phone,
+				Score:                  r.Score,
+				Source:                 r.Source,
+				ComputedAt:             &computedAt,
+			})
+		}
+	}
+
+	// user tanpa rekomendasi (belum pernah review) mendapat phone teratas
+	// leaderboard (skor bayesian), phone dgn sedikit review tidak langsung di atas
+	if len(result) == 0 {
+		mean, weight, err := defaultLeaderboardPrior(db)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+		var entries []LeaderboardEntry
+		if err := leaderboardQuery(db).Where("phones.id NOT IN (?)", reviewed).Scan(&entries).Error; err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+		ranked := rankLeaderboard(entries, leaderboardOptions{
+			Method:      LeaderboardBayesian,
+			PriorMean:   mean,
+			PriorWeight: weight,
+			Limit:       limit,
+		})
+
+		ids := make([]uint, len(ranked))
+		for i, entry := range ranked {
+			ids[i] = entry.PhoneID
+		}
+		byID, err := phonesCompleteByID(db, ids)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+		for _, entry := range ranked {
+			phone, ok := byID[int(entry.PhoneID)]
+			if !ok {
+				continue
+			}
+			result = append(result, RecommendationResponse{
+				PhonesCompleteResponse: phone,
+				Score:                  entry.Score,
+				Source:                 recommendation.SourcePopular,
+			})
+		}
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, result))
+}
+
+// phonesCompleteByID data lengkap phone dgn id tsb berdasarkan id phone
+func phonesCompleteByID(db *gorm.DB, ids []uint) (map[int]PhonesCompleteResponse, error) {
+	byID := map[int]PhonesCompleteResponse{}
+	if len(ids) == 0 {
+		return byID, nil
+	}
+	var phones []PhonesCompleteResponse
+	if err := phoneCompleteQuery(db).Where("phones.id IN ?", ids).Scan(&phones).Error; err != nil {
+		return nil, err
+	}
+	for _, phone := range phones {
+		byID[phone.PhoneID] = phone
+	}
+	return byID, nil
+}
+
+// Recompute recommendations godoc
+// @Summary Recompute phone recommendations of all users. (ADMIN ONLY)
+// @Description Recompute the cached recommendations of every user with reviews. also called periodically by the cron job on GET /cron/recommendations with the header "Authorization: Bearer <CRON_SECRET>" (see vercel.json)
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} map[string]boolean
+// @Router /admin/recommendations/recompute [post]
+// @Router /cron/recommendations [get]
+func RecomputeRecommendations(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	err := recommendation.Run(db)
+	if errors.Is(err, recommendation.ErrAlreadyRunning) {
+		c.JSON(http.StatusConflict,
+			utils.ResponseJSON(err.Error(), http.StatusConflict, nil))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("rekomendasi berhasil dihitung ulang", http.StatusOK, nil))
+}
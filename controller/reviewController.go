@@ -1,13 +1,16 @@
 package controller
 
 import (
+	"final-project/contentfilter"
 	"final-project/lib"
 	"final-project/models"
 	"final-project/utils"
 	"final-project/utils/token"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,17 +18,29 @@ import (
 )
 
 type reviewInput struct {
-	Rating  uint   `json:"rating" binding:"required,min=1,max=5"`
-	Content string `json:"content" binding:"required"`
+	Rating    uint   `json:"rating" binding:"required,min=1,max=5"`
+	Content   string `json:"content" binding:"required"`
+	VariantID *uint  `json:"variant_id"`
+	// rating per aspek (opsional), key aspek -> rating 1 - 5, contoh {"camera": 5}
+	Aspects map[string]uint `json:"aspects"`
+	// kelebihan & kekurangan (opsional), maksimal 10 poin masing-masing
+	Pros []string `json:"pros"`
+	Cons []string `json:"cons"`
 }
 type reviewUpdate struct {
-	Rating  uint   `json:"rating" binding:"min=1,max=5"`
-	Content string `json:"content" `
+	Rating    uint   `json:"rating" binding:"min=1,max=5"`
+	Content   string `json:"content" `
+	VariantID *uint  `json:"variant_id"`
+	// hanya aspek yg dikirim yg diubah, rating 0 menghapus rating aspek tsb
+	Aspects map[string]uint `json:"aspects"`
+	// pros / cons yg dikirim menggantikan daftar lama, [] untuk mengosongkan
+	Pros []string `json:"pros"`
+	Cons []string `json:"cons"`
 }
 
 // Create New Review godoc
 // @Summary Create New Review
-// @Description This route will create review data , user ID is taken from the JWT token, one user only can give one review to one phone
+// @Description This route will create review data , user ID is taken from the JWT token, one user only can give one review to one phone. depending on the moderation policy (see /admin/moderation/config) the review is published immediately (status approved) or waits for approval (status pending). variant_id is optional and must be a variant of the reviewed phone. aspects is optional, a map of rating aspect key (see /rating-aspects) to a 1 - 5 rating. pros & cons are optional ordered lists of at most 10 points of 2 - 150 characters
 // @Tags Phones
 // @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
 // @Security BearerToken
@@ -69,7 +84,7 @@ func CreateReview(c *gin.Context) {
 
 	// cek phone ada atau tidak
 	var phone []models.Phone
-	if err := db.Where("id = ?", phoneID).Find(&phone).Error; err != nil {
+	if err := visiblePhones(c, db).Where("id = ?", phoneID).Find(&phone).Error; err != nil {
 		c.JSON(http.StatusBadRequest,
 			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
 		return
@@ -102,26 +117,112 @@ func CreateReview(c *gin.Context) {
 		return
 	}
 
+	// variant yg dimiliki reviewer (opsional) harus milik phone yg direview
+	if input.VariantID != nil && !isVariantOfPhone(db, *input.VariantID, uint(phoneID)) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.ErrMsgNotFound("variant untuk phone ini"), http.StatusBadRequest, nil))
+		return
+	}
+
+	aspects, ok := isAspectRatingsValid(c, db, input.Aspects, false)
+	if !ok {
+		return
+	}
+
+	pros, ok := isProsConsValid(c, "pros", input.Pros)
+	if !ok {
+		return
+	}
+	cons, ok := isProsConsValid(c, "cons", input.Cons)
+	if !ok {
+		return
+	}
+
+	filterInput := contentfilter.Input{Kind: contentfilter.KindReview, UserID: userID, Content: input.Content}
+	filtered, ok := filterContent(c, db, filterInput)
+	if !ok {
+		return
+	}
+	pros, prosModerated, ok := filterProsCons(c, db, filterInput, pros)
+	if !ok {
+		return
+	}
+	cons, consModerated, ok := filterProsCons(c, db, filterInput, cons)
+	if !ok {
+		return
+	}
+	if filtered.Action != contentfilter.ActionModerate {
+		if prosModerated != nil {
+			filtered = *prosModerated
+		} else if consModerated != nil {
+			filtered = *consModerated
+		}
+	}
+
+	// review akun baru bisa menunggu moderasi dulu
+	status, err := initialReviewStatus(c, db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
 	review_data := models.Review{
-		Rating:  input.Rating,
-		Content: input.Content,
-		UserID:  userID,
-		PhoneID: uint(phoneID),
+		Rating:      input.Rating,
+		Content:     filtered.Content,
+		ContentHash: filtered.ContentHash,
+		UserID:      userID,
+		PhoneID:     uint(phoneID),
+		VariantID:   input.VariantID,
+		Status:      status,
+	}
+	// konten yg tertahan filter masuk antrian moderasi
+	if filtered.Action == contentfilter.ActionModerate {
+		review_data.Status = models.ReviewStatusPending
+		review_data.ModerationReason = filtered.Reason
+		review_data.ModerationNote = filtered.Note()
 	}
 
-	db.Create(&review_data)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&review_data).Error; err != nil {
+			return err
+		}
+		if err := saveProsCons(tx, review_data.ID, models.ReviewProConPro, pros); err != nil {
+			return err
+		}
+		if err := saveProsCons(tx, review_data.ID, models.ReviewProConCon, cons); err != nil {
+			return err
+		}
+		return saveAspectRatings(tx, review_data.ID, input.Aspects, aspects)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	if !loadAspectRatingsOfReview(c, db, &review_data) {
+		return
+	}
+	if !loadProsConsOfReview(c, db, &review_data) {
+		return
+	}
 
-	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgAdded("review"), http.StatusOK, review_data))
+	msg := lib.MsgAdded("review")
+	if review_data.Status == models.ReviewStatusPending {
+		msg = "review berhasil ditambahkan dan menunggu moderasi"
+	}
+	c.JSON(http.StatusOK, utils.ResponseJSON(msg, http.StatusOK, review_data))
 }
 
 // Update Review for phone godoc
 // @Summary Update Review for phone
-// @Description This route will update review data , user ID is taken from the JWT token
+// @Description This route will update review data , user ID is taken from the JWT token. the previous content, rating, pros & cons are kept as a revision (see /reviews/{id}/revisions), content & rating can not be changed anymore after REVIEW_EDIT_WINDOW_HOURS (0 = no limit). the moderation policy is applied again to the edited review, a rejected or hidden review goes back to the moderation queue
 // @Tags Reviews
 // @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
 // @Security BearerToken
 // @Param id path string true "review id"
-// @Param Body body reviewInput true "example JSON body to update a review for phone"
+// @Param Body body reviewUpdate true "example JSON body to update a review for phone, send variant_id 0 to remove the variant. only the sent aspects are changed, send an aspect rating 0 to remove it"
 // @Produce json
 // @Success 200 {object} models.Review
 // @Router /reviews/{id} [put]
@@ -157,29 +258,154 @@ func UpdateReview(c *gin.Context) {
 		return
 	}
 
+	// konten, rating, pros & cons terkunci setelah batas waktu edit
+	window := editWindow("REVIEW_EDIT_WINDOW_HOURS")
+	contentChanged := input.Rating != 0 || input.Content != "" || input.Pros != nil || input.Cons != nil
+	if contentChanged && isEditLocked(rev.CreatedAt, window) {
+		c.JSON(http.StatusForbidden,
+			utils.ResponseJSON(fmt.Sprintf("konten dan rating review tidak bisa diubah lebih dari %g jam setelah dibuat", window.Hours()), http.StatusForbidden, nil))
+		return
+	}
+	previous := rev
+	previousPros, previousCons, err := getReviewProsCons(db, []uint{rev.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
 	// Update yang diinput saja
 	if input.Rating != 0 {
 		rev.Rating = input.Rating
 	}
+	var filtered contentfilter.Result
 	if input.Content != "" {
-		rev.Content = input.Content
+		result, ok := filterContent(c, db, contentfilter.Input{Kind: contentfilter.KindReview, ID: rev.ID, UserID: userID, Content: input.Content})
+		if !ok {
+			return
+		}
+		filtered = result
+		rev.Content = filtered.Content
+		rev.ContentHash = filtered.ContentHash
+	}
+	if input.VariantID != nil {
+		// variant_id = 0 untuk melepas variant dari review
+		if *input.VariantID == 0 {
+			rev.VariantID = nil
+		} else if !isVariantOfPhone(db, *input.VariantID, rev.PhoneID) {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(lib.ErrMsgNotFound("variant untuk phone ini"), http.StatusBadRequest, nil))
+			return
+		} else {
+			rev.VariantID = input.VariantID
+		}
+	}
+
+	aspects, ok := isAspectRatingsValid(c, db, input.Aspects, true)
+	if !ok {
+		return
+	}
+
+	pros, ok := isProsConsValid(c, "pros", input.Pros)
+	if !ok {
+		return
+	}
+	cons, ok := isProsConsValid(c, "cons", input.Cons)
+	if !ok {
+		return
+	}
+	filterInput := contentfilter.Input{Kind: contentfilter.KindReview, ID: rev.ID, UserID: userID}
+	pros, prosModerated, ok := filterProsCons(c, db, filterInput, pros)
+	if !ok {
+		return
+	}
+	cons, consModerated, ok := filterProsCons(c, db, filterInput, cons)
+	if !ok {
+		return
+	}
+	if filtered.Action != contentfilter.ActionModerate {
+		if prosModerated != nil {
+			filtered = *prosModerated
+		} else if consModerated != nil {
+			filtered = *consModerated
+		}
+	}
+
+	// review yg ditolak / disembunyikan kembali ke antrian moderasi setelah diedit
+	if rev.Status == models.ReviewStatusRejected || rev.Status == models.ReviewStatusHidden {
+		rev.Status = models.ReviewStatusPending
+	} else {
+		status, err := initialReviewStatus(c, db, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+		rev.Status = status
+	}
+	if filtered.Action == contentfilter.ActionModerate {
+		rev.Status = models.ReviewStatusPending
+		rev.ModerationReason = filtered.Reason
+		rev.ModerationNote = filtered.Note()
 	}
 
 	rev.UpdatedAt = time.Now()
 
-	if err := db.Save(&rev).Error; err != nil {
+	// versi lama disimpan jika konten, rating, pros / cons berubah
+	edited := rev.Rating != previous.Rating || rev.Content != previous.Content ||
+		(pros != nil && !slices.Equal(pros, previousPros[rev.ID])) ||
+		(cons != nil && !slices.Equal(cons, previousCons[rev.ID]))
+	if edited {
+		rev.EditedAt = &rev.UpdatedAt
+		rev.RevisionCount++
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if edited {
+			revision := models.ReviewRevision{
+				ReviewID: rev.ID,
+				Rating:   previous.Rating,
+				Content:  previous.Content,
+				Pros:     previousPros[rev.ID],
+				Cons:     previousCons[rev.ID],
+				EditorID: userID,
+			}
+			if err := tx.Create(&revision).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Omit("AspectRatings").Save(&rev).Error; err != nil {
+			return err
+		}
+		if err := saveProsCons(tx, rev.ID, models.ReviewProConPro, pros); err != nil {
+			return err
+		}
+		if err := saveProsCons(tx, rev.ID, models.ReviewProConCon, cons); err != nil {
+			return err
+		}
+		return saveAspectRatings(tx, rev.ID, input.Aspects, aspects)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError,
 			utils.ResponseJSON("Failed to update review", http.StatusInternalServerError, nil))
 		return
 	}
 
+	if !loadAspectRatingsOfReview(c, db, &rev) {
+		return
+	}
+	if !loadProsConsOfReview(c, db, &rev) {
+		return
+	}
+
 	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("review"), http.StatusOK, rev))
 }
 
 type ReviewsResponse struct {
 	models.Review
-	Username   string `json:"username"`
-	PhoneModel string `json:"phone_model"`
+	Username    string `json:"username"`
+	PhoneModel  string `json:"phone_model"`
+	ReviewVotes `gorm:"-"`
 }
 
 // Get all reviews data
@@ -188,6 +414,8 @@ type ReviewsResponse struct {
 // @Tags Reviews
 // @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
 // @Security BearerToken
+// @Param with_photos query bool false "only reviews with (true) / without (false) photos"
+// @Param status query string false "pending / approved / rejected / hidden, default all"
 // @Produce json
 // @Success 200 {object} []map[string]any
 // @Router /reviews [get]
@@ -196,7 +424,22 @@ func GetAllReviews(c *gin.Context) {
 
 	db := c.MustGet("db").(*gorm.DB)
 
-	if err := db.Table("reviews").
+	query, err := applyReviewPhotoFilter(c, db.Table("reviews"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+	if status := c.Query("status"); status != "" {
+		if !slices.Contains(models.ReviewStatuses, status) {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON("status harus "+strings.Join(models.ReviewStatuses, ", "), http.StatusBadRequest, nil))
+			return
+		}
+		query = query.Where("reviews.status = ?", status)
+	}
+
+	if err := query.
 		Select("reviews.*, users.username as username, phones.model as phone_model").
 		Joins("join users on reviews.user_id = users.id").
 		Joins("join phones on reviews.phone_id = phones.id").
@@ -207,20 +450,51 @@ func GetAllReviews(c *gin.Context) {
 		return
 	}
 
+	reviewIDs := make([]uint, 0, len(data))
+	for _, review := range data {
+		reviewIDs = append(reviewIDs, review.ID)
+	}
+	votes, err := getReviewVotes(db, reviewIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	photos, err := getReviewPhotos(db, reviewIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	pros, cons, err := getReviewProsCons(db, reviewIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	for i := range data {
+		data[i].ReviewVotes = votes[data[i].ID]
+		data[i].Photos = photos[data[i].ID]
+		data[i].Pros, data[i].Cons = pros[data[i].ID], cons[data[i].ID]
+	}
+
 	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, data))
 }
 
 type Reviews struct {
 	models.Review
-	Username string `json:"username"`
+	Username    string `json:"username"`
+	ReviewVotes `gorm:"-"`
 }
 
 // Get reviews data by Phone data ID godoc
 // @Summary Get reviews data by Phone id. (PUBLIC)
-// @Description Get all Reviews data by phone id. if reviews data is empty, the review data will not be displayed
+// @Description Get all approved Reviews data by phone id. if reviews data is empty, the review data will not be displayed. most_helpful ranks reviews by the lower bound of the wilson interval of helpful votes, so a review with few votes does not outrank a review with many mostly helpful votes
 // @Tags Phones
 // @Produce json
-// @Param id path string true "Phone id"
+// @Param id path string true "Phone id or slug"
+// @Param sort query string false "most_helpful / newest / highest / lowest rating, default insertion order"
+// @Param with_photos query bool false "only reviews with (true) / without (false) photos"
 // @Success 200 {object} []models.Phone
 // @Router /phones/{id}/reviews [get]
 func GetReviewsDataByPhoneId(c *gin.Context) {
@@ -229,7 +503,30 @@ func GetReviewsDataByPhoneId(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
 
 	id := c.Param("id")
-	if err := db.Table("reviews").
+
+	// review phone draft hanya bisa dilihat admin
+	var phone models.Phone
+	if err := visiblePhones(c, db).Where("id = ?", id).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	sort := c.Query("sort")
+	query, ok := applyReviewSort(db.Table("reviews"), sort)
+	if !ok {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("sort harus most_helpful, newest, highest atau lowest", http.StatusBadRequest, nil))
+		return
+	}
+	query, err := applyReviewPhotoFilter(c, approvedReviews(query))
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
+		return
+	}
+
+	if err := query.
 		Select("reviews.*, users.username as username").
 		Joins("join users on reviews.user_id = users.id").
 		Where("reviews.phone_id = ?", id).
@@ -240,6 +537,44 @@ func GetReviewsDataByPhoneId(c *gin.Context) {
 		return
 	}
 
+	reviewIDs := make([]uint, 0, len(reviews))
+	for _, review := range reviews {
+		reviewIDs = append(reviewIDs, review.ID)
+	}
+	aspectRatings, err := getReviewAspectRatings(db, reviewIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	votes, err := getReviewVotes(db, reviewIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	photos, err := getReviewPhotos(db, reviewIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	pros, cons, err := getReviewProsCons(db, reviewIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	for i := range reviews {
+		reviews[i].AspectRatings = aspectRatings[reviews[i].ID]
+		reviews[i].ReviewVotes = votes[reviews[i].ID]
+		reviews[i].Photos = photos[reviews[i].ID]
+		reviews[i].Pros, reviews[i].Cons = pros[reviews[i].ID], cons[reviews[i].ID]
+	}
+	if sort == ReviewSortMostHelpful {
+		sortReviewsByHelpful(reviews)
+	}
+
 	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, reviews))
 }
 
@@ -274,11 +609,38 @@ func DeleteReviewById(c *gin.Context) {
 		return
 	}
 
+	// file foto review dihapus setelah datanya terhapus
+	photoURLs, err := reviewPhotoURLs(db, db.Model(&models.Review{}).Select("id").Where("id = ?", reviewID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
 	if err := db.Model(&models.Review{}).Where("id = ?", reviewID).Delete(&review).Error; err != nil {
 		c.JSON(http.StatusBadRequest,
 			utils.ResponseJSON(err.Error(), http.StatusBadRequest, nil))
 		return
 	}
+	removeReviewPhotoFiles(c, photoURLs)
 	c.JSON(http.StatusOK,
 		utils.ResponseJSON(lib.MsgDeleted("review"), http.StatusOK, nil))
 }
+
+func isVariantOfPhone(db *gorm.DB, variantID, phoneID uint) bool {
+	var count int64
+	db.Model(&models.PhoneVariant{}).Where("id = ? AND phone_id = ?", variantID, phoneID).Count(&count)
+	return count > 0
+}
+
+// loadAspectRatingsOfReview isi rating aspek review untuk response
+func loadAspectRatingsOfReview(c *gin.Context, db *gorm.DB, review *models.Review) bool {
+	aspectRatings, err := getReviewAspectRatings(db, []uint{review.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return false
+	}
+	review.AspectRatings = aspectRatings[review.ID]
+	return true
+}
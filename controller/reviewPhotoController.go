@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"errors"
+	"final-project/lib"
+	"final-project/models"
+	"final-project/storage"
+	"final-project/utils"
+	"final-project/utils/token"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// MaxReviewPhotos batas jumlah foto per review (env REVIEW_MAX_PHOTOS)
+func MaxReviewPhotos() int {
+	count, err := strconv.Atoi(utils.GetEnv("REVIEW_MAX_PHOTOS", "5"))
+	if err != nil || count <= 0 {
+		count = 5
+	}
+	return count
+}
+
+// Add review photo godoc
+// @Summary Attach a photo to a review
+// @Description Upload a photo (field "image") to the logged in user's review, a review has at most REVIEW_MAX_PHOTOS photos (default 5). EXIF metadata (GPS location, device, ...) is removed before the photo is stored, thumbnails are generated automatically
+// @Tags Reviews
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Accept multipart/form-data
+// @Param id path string true "review id"
+// @Param image formData file true "image file (jpeg, png, gif, webp)"
+// @Produce json
+// @Success 200 {object} models.ReviewPhoto
+// @Router /reviews/{id}/photos [post]
+func CreateReviewPhoto(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	review, ok := findOwnReview(c, db)
+	if !ok {
+		return
+	}
+
+	var count int64
+	if err := db.Model(&models.ReviewPhoto{}).Where("review_id = ?", review.ID).Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	if count >= int64(MaxReviewPhotos()) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(fmt.Sprintf("review maksimal memiliki %d foto", MaxReviewPhotos()), http.StatusBadRequest, nil))
+		return
+	}
+
+	uploaded, ok := saveUploadedImage(c, "reviews/"+strconv.Itoa(int(review.ID))+"/photos")
+	if !ok {
+		return
+	}
+
+	// file yg sama menghasilkan url yg sama, tidak boleh dilampirkan dua kali
+	var duplicate int64
+	db.Model(&models.ReviewPhoto{}).Where("review_id = ? AND url = ?", review.ID, uploaded.URL).Count(&duplicate)
+	if duplicate > 0 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("foto ini sudah dilampirkan pada review", http.StatusBadRequest, nil))
+		return
+	}
+
+	photo_data := models.ReviewPhoto{
+		URL:        uploaded.URL,
+		Thumbnails: uploaded.Thumbnails,
+		Position:   uint(count) + 1,
+		ReviewID:   review.ID,
+	}
+	if err := db.Create(&photo_data).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgAdded("review photo"), http.StatusOK, photo_data))
+}
+
+// Delete review photo godoc
+// @Summary Delete a photo of a review
+// @Description Delete a photo of the logged in user's review, the file is also removed from storage
+// @Tags Reviews
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "review id"
+// @Param photo_id path string true "photo id"
+// @Produce json
+// @Success 200 {object} map[string]boolean
+// @Router /reviews/{id}/photos/{photo_id} [delete]
+func DeleteReviewPhoto(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	review, ok := findOwnReview(c, db)
+	if !ok {
+		return
+	}
+
+	var photo models.ReviewPhoto
+	if err := db.Where("id = ? AND review_id = ?", c.Param("photo_id"), review.ID).First(&photo).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("review photo"), http.StatusNotFound, nil))
+		return
+	}
+
+	// posisi foto setelahnya dimajukan
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&photo).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.ReviewPhoto{}).
+			Where("review_id = ? AND position > ?", review.ID, photo.Position).
+			UpdateColumn("position", gorm.Expr("position - 1")).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	removeReviewPhotoFiles(c, []string{photo.URL})
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgDeleted("review photo"), http.StatusOK, nil))
+}
+
+// findOwnReview review dari param id yg dimiliki user yg login
+func findOwnReview(c *gin.Context, db *gorm.DB) (models.Review, bool) {
+	var review models.Review
+
+	// user id dari token
+	userID, err := token.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return review, false
+	}
+
+	if err := db.Where("id = ? AND user_id = ?", c.Param("id"), userID).First(&review).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON("Review tidak ditemukan atau bukan milik user", http.StatusNotFound, nil))
+		return review, false
+	}
+	return review, true
+}
+
+// getReviewPhotos foto tiap review sesuai urutan posisi
+func getReviewPhotos(db *gorm.DB, reviewIDs []uint) (map[uint][]models.ReviewPhoto, error) {
+	result := map[uint][]models.ReviewPhoto{}
+	if len(reviewIDs) == 0 {
+		return result, nil
+	}
+
+	var photos []models.ReviewPhoto
+	if err := db.Where("review_id IN ?", reviewIDs).Order("position ASC, id ASC").Find(&photos).Error; err != nil {
+		return nil, err
+	}
+	for _, photo := range photos {
+		result[photo.ReviewID] = append(result[photo.ReviewID], photo)
+	}
+	return result, nil
+}
+
+// reviewPhotoURLs url foto review yg akan ikut terhapus, reviews berisi
+// subquery id review
+func reviewPhotoURLs(db *gorm.DB, reviews *gorm.DB) ([]string, error) {
+	var urls []string
+	err := db.Model(&models.ReviewPhoto{}).Where("review_id IN (?)", reviews).Pluck("url", &urls).Error
+	return urls, err
+}
+
+// removeReviewPhotoFiles hapus file foto review dari storage setelah datanya
+// terhapus, kegagalan hanya dicatat
+func removeReviewPhotoFiles(c *gin.Context, urls []string) {
+	store := c.MustGet("storage").(storage.Storage)
+	for _, url := range urls {
+		if err := storage.DeleteImage(c.Request.Context(), store, url); err != nil {
+			log.Println("gagal menghapus file foto review:", err.Error())
+		}
+	}
+}
+
+// applyReviewPhotoFilter filter review berdasarkan query with_photos
+func applyReviewPhotoFilter(c *gin.Context, query *gorm.DB) (*gorm.DB, error) {
+	value := c.Query("with_photos")
+	if value == "" {
+		return query, nil
+	}
+
+	withPhotos, err := strconv.ParseBool(value)
+	if err != nil {
+		return query, errors.New("with_photos harus true atau false")
+	}
+
+	photos := "SELECT 1 FROM review_photos WHERE review_photos.review_id = reviews.id"
+	if withPhotos {
+		return query.Where("EXISTS (" + photos + ")"), nil
+	}
+	return query.Where("NOT EXISTS (" + photos + ")"), nil
+}
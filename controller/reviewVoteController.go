@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"final-project/utils/token"
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	ReviewSortMostHelpful = "most_helpful"
+	ReviewSortNewest      = "newest"
+	ReviewSortHighest     = "highest"
+	ReviewSortLowest      = "lowest"
+)
+
+type reviewVoteInput struct {
+	// true = membantu, false = tidak membantu
+	Helpful *bool `json:"helpful" binding:"required"`
+}
+
+// ReviewVotes jumlah vote review, helpful_score batas bawah interval wilson
+// proporsi vote membantu (0 - 1) yg dipakai untuk urutan most_helpful
+type ReviewVotes struct {
+	HelpfulCount   int64   `json:"helpful_count"`
+	UnhelpfulCount int64   `json:"unhelpful_count"`
+	HelpfulScore   float64 `json:"helpful_score"`
+}
+
+// Vote review godoc
+// @Summary Vote a review as helpful or unhelpful
+// @Description Vote a review helpful (true) or unhelpful (false), user ID is taken from the JWT token. one user only has one vote per review (voting again changes the vote) and can not vote their own review
+// @Tags Reviews
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "review id"
+// @Param Body body reviewVoteInput true "example JSON body to vote a review"
+// @Produce json
+// @Success 200 {object} ReviewVotes
+// @Router /reviews/{id}/vote [put]
+func VoteReview(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input reviewVoteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	// user id dari token
+	userID, err := token.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	var review models.Review
+	if err := approvedReviews(db).Where("id = ?", c.Param("id")).First(&review).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("review"), http.StatusNotFound, nil))
+		return
+	}
+
+	if review.UserID == userID {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("anda tidak bisa memberikan vote ke review sendiri", http.StatusBadRequest, nil))
+		return
+	}
+
+	var vote models.ReviewVote
+	if err := db.Where(models.ReviewVote{ReviewID: review.ID, UserID: userID}).
+		Assign(map[string]any{"helpful": *input.Helpful}).
+		FirstOrCreate(&vote).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	votes, err := getReviewVotes(db, []uint{review.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("vote review"), http.StatusOK, votes[review.ID]))
+}
+
+// Remove review vote godoc
+// @Summary Remove vote of a review
+// @Description Remove the logged in user's helpful / unhelpful vote of a review
+// @Tags Reviews
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "review id"
+// @Produce json
+// @Success 200 {object} ReviewVotes
+// @Router /reviews/{id}/vote [delete]
+func DeleteReviewVote(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	// user id dari token
+	userID, err := token.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	var vote models.ReviewVote
+	if err := db.Where("review_id = ? AND user_id = ?", c.Param("id"), userID).First(&vote).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("vote review"), http.StatusNotFound, nil))
+		return
+	}
+
+	if err := db.Delete(&vote).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	votes, err := getReviewVotes(db, []uint{vote.ReviewID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgDeleted("vote review"), http.StatusOK, votes[vote.ReviewID]))
+}
+
+// getReviewVotes jumlah vote tiap review, review tanpa vote tetap ada di hasil
+func getReviewVotes(db *gorm.DB, reviewIDs []uint) (map[uint]ReviewVotes, error) {
+	result := map[uint]ReviewVotes{}
+	for _, id := range reviewIDs {
+		result[id] = ReviewVotes{}
+	}
+	if len(reviewIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		ReviewID       uint
+		HelpfulCount   int64
+		UnhelpfulCount int64
+	}
+	if err := db.Model(&models.ReviewVote{}).
+		Select(`review_id,
+				SUM(CASE WHEN helpful THEN 1 ELSE 0 END) as helpful_count,
+				SUM(CASE WHEN helpful THEN 0 ELSE 1 END) as unhelpful_count`).
+		Where("review_id IN ?", reviewIDs).
+		Group("review_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.ReviewID] = ReviewVotes{
+			HelpfulCount:   row.HelpfulCount,
+			UnhelpfulCount: row.UnhelpfulCount,
+			HelpfulScore:   helpfulScore(row.HelpfulCount, row.UnhelpfulCount),
+		}
+	}
+	return result, nil
+}
+
+// helpfulScore batas bawah interval wilson proporsi vote membantu, review dgn
+// sedikit vote tidak langsung mengalahkan review dgn banyak vote
+func helpfulScore(helpful, unhelpful int64) float64 {
+	lower := wilsonLowerBound(float64(helpful), float64(helpful+unhelpful))
+	return math.Round(lower*10000) / 10000
+}
+
+// applyReviewSort urutan review sesuai query sort, most_helpful diurutkan
+// setelah jumlah vote dihitung (sortReviewsByHelpful)
+func applyReviewSort(query *gorm.DB, sort string) (*gorm.DB, bool) {
+	switch sort {
+	case "":
+		// default urutan review dibuat
+		return query.Order("reviews.id ASC"), true
+	case ReviewSortMostHelpful:
+		// urutan awal sebelum sortReviewsByHelpful, review dgn skor sama tetap urut id
+		return query.Order("reviews.id ASC"), true
+	case ReviewSortNewest:
+		return query.Order("reviews.created_at DESC").Order("reviews.id DESC"), true
+	case ReviewSortHighest:
+		return query.Order("reviews.rating DESC").Order("reviews.created_at DESC"), true
+	case ReviewSortLowest:
+		return query.Order("reviews.rating ASC").Order("reviews.created_at DESC"), true
+	default:
+		return query, false
+	}
+}
+
+func sortReviewsByHelpful(reviews []Reviews) {
+	sort.SliceStable(reviews, func(i, j int) bool {
+		a, b := reviews[i].ReviewVotes, reviews[j].ReviewVotes
+		if a.HelpfulScore != b.HelpfulScore {
+			return a.HelpfulScore > b.HelpfulScore
+		}
+		if a.HelpfulCount != b.HelpfulCount {
+			return a.HelpfulCount > b.HelpfulCount
+		}
+		return reviews[i].CreatedAt.After(reviews[j].CreatedAt)
+	})
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"final-project/models"
+	"testing"
+	"time"
+)
+
+func TestHelpfulScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		helpful   int64
+		unhelpful int64
+		want      float64
+	}{
+		{"tanpa vote", 0, 0, 0},
+		{"semua tidak membantu", 0, 5, 0},
+		{"satu vote membantu", 1, 0, 0.2065},
+		{"sepuluh vote membantu", 10, 0, 0.7225},
+		{"seimbang", 50, 50, 0.4038},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := helpfulScore(tt.helpful, tt.unhelpful); got != tt.want {
+				t.Errorf("helpfulScore(%d, %d) = %v, want %v", tt.helpful, tt.unhelpful, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortReviewsByHelpful(t *testing.T) {
+	now := time.Now()
+	review := func(id uint, helpful, unhelpful int64, createdAt time.Time) Reviews {
+		return Reviews{
+			Review: models.Review{ID: id, CreatedAt: createdAt},
+			ReviewVotes: ReviewVotes{
+				HelpfulCount:   helpful,
+				UnhelpfulCount: unhelpful,
+				HelpfulScore:   helpfulScore(helpful, unhelpful),
+			},
+		}
+	}
+	reviews := []Reviews{
+		review(1, 1, 0, now),
+		review(2, 40, 5, now),
+		review(3, 0, 0, now.Add(-time.Hour)),
+		review(4, 0, 0, now),
+		review(5, 45, 5, now),
+	}
+
+	sortReviewsByHelpful(reviews)
+
+	// skor lebih tinggi dulu, skor sama yg lebih baru dulu
+	want := []uint{5, 2, 1, 4, 3}
+	for i, id := range want {
+		if reviews[i].ID != id {
+			got := []uint{}
+			for _, r := range reviews {
+				got = append(got, r.ID)
+			}
+			t.Fatalf("urutan = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"final-project/utils/token"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type RevisionResponse struct {
+	ID             uint      `json:"id"`
+	Rating         uint      `json:"rating,omitempty"`
+	Content        string    `json:"content"`
+	Pros           []string  `gorm:"serializer:json" json:"pros,omitempty"`
+	Cons           []string  `gorm:"serializer:json" json:"cons,omitempty"`
+	EditorID       uint      `json:"editor_id"`
+	EditorUsername string    `json:"editor_username"`
+	EditedAt       time.Time `json:"edited_at"`
+}
+
+// RevisionsResponse versi sekarang & versi lama (terlama lebih dulu)
+type RevisionsResponse struct {
+	ID            uint               `json:"id"`
+	Rating        uint               `json:"rating,omitempty"`
+	Content       string             `json:"content"`
+	Pros          []string           `json:"pros,omitempty"`
+	Cons          []string           `json:"cons,omitempty"`
+	EditedAt      *time.Time         `json:"edited_at"`
+	RevisionCount uint               `json:"revision_count"`
+	Revisions     []RevisionResponse `json:"revisions"`
+}
+
+// editWindow batas waktu edit sejak data dibuat dari env (jam), 0 berarti tanpa batas
+func editWindow(key string) time.Duration {
+	hours, err := strconv.Atoi(utils.GetEnv(key, "0"))
+	if err != nil || hours <= 0 {
+		return 0
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// isEditLocked konten tidak bisa diubah lagi setelah batas waktu edit lewat
+func isEditLocked(createdAt time.Time, window time.Duration) bool {
+	return window > 0 && time.Since(createdAt) > window
+}
+
+// Get review revisions godoc
+// @Summary Get the edit history of a review
+// @Description Get the previous versions (content, rating, pros & cons) of a review, oldest first, together with the current version. only the author of the review and admins can see the history
+// @Tags Reviews
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "review id"
+// @Produce json
+// @Success 200 {object} RevisionsResponse
+// @Router /reviews/{id}/revisions [get]
+func GetReviewRevisions(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var review models.Review
+	if err := db.Where("id = ?", c.Param("id")).First(&review).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("review"), http.StatusNotFound, nil))
+		return
+	}
+	if !canSeeRevisions(c, review.UserID) {
+		return
+	}
+
+	var revisions []RevisionResponse
+	if err := db.Table("review_revisions").
+		Select("review_revisions.id, review_revisions.rating, review_revisions.content, review_revisions.pros, review_revisions.cons, review_revisions.editor_id, users.username as editor_username, review_revisions.created_at as edited_at").
+		Joins("LEFT JOIN users on users.id = review_revisions.editor_id").
+		Where("review_revisions.review_id = ?", review.ID).
+		Order("review_revisions.id ASC").
+		Scan(&revisions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	if !loadProsConsOfReview(c, db, &review) {
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, RevisionsResponse{
+		ID:            review.ID,
+		Rating:        review.Rating,
+		Content:       review.Content,
+		Pros:          review.Pros,
+		Cons:          review.Cons,
+		EditedAt:      review.EditedAt,
+		RevisionCount: review.RevisionCount,
+		Revisions:     revisions,
+	}))
+}
+
+// Get comment revisions godoc
+// @Summary Get the edit history of a comment
+// @Description Get the previous versions of a comment, oldest first, together with the current version. only the author of the comment and admins can see the history
+// @Tags Comments
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "comment id"
+// @Produce json
+// @Success 200 {object} RevisionsResponse
+// @Router /comments/{id}/revisions [get]
+func GetCommentRevisions(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var comment models.Comment
+	if err := db.Where("id = ?", c.Param("id")).First(&comment).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("comment"), http.StatusNotFound, nil))
+		return
+	}
+	if !canSeeRevisions(c, comment.UserID) {
+		return
+	}
+
+	var revisions []RevisionResponse
+	if err := db.Table("comment_revisions").
+		Select("comment_revisions.id, comment_revisions.content, comment_revisions.editor_id, users.username as editor_username, comment_revisions.created_at as edited_at").
+		Joins("LEFT JOIN users on users.id = comment_revisions.editor_id").
+		Where("comment_revisions.comment_id = ?", comment.ID).
+		Order("comment_revisions.id ASC").
+		Scan(&revisions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, RevisionsResponse{
+		ID:            comment.ID,
+		Content:       comment.Content,
+		EditedAt:      comment.EditedAt,
+		RevisionCount: comment.RevisionCount,
+		Revisions:     revisions,
+	}))
+}
+
+// canSeeRevisions riwayat edit hanya untuk penulis & admin
+func canSeeRevisions(c *gin.Context, authorID uint) bool {
+	userID, err := token.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return false
+	}
+	if userID != authorID && !isCatalogAdmin(c) {
+		c.JSON(http.StatusForbidden,
+			utils.ResponseJSON("riwayat edit hanya bisa dilihat penulis dan admin", http.StatusForbidden, nil))
+		return false
+	}
+	return true
+}
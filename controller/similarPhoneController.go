@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"final-project/lib"
+	"final-project/models"
+	"final-project/similarity"
+	"final-project/utils"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultSimilarLimit = 10
+	maxSimilarLimit     = 50
+	// rentang harga kandidat (±50% harga phone)
+	similarPriceBand = 0.5
+)
+
+// bobot bawaan rating, bobot fitur lain dari similarity.DefaultWeights. semua
+// bobot bisa diganti lewat query weight_<nama>
+const defaultSimilarRatingWeight = 1
+
+// PhoneFeatureResponse ringkasan spec phone yg dipakai untuk menghitung kemiripan
+type PhoneFeatureResponse struct {
+	PhoneID         uint     `json:"phone_id"`
+	BrandID         uint     `json:"brand_id"`
+	BrandName       string   `json:"brand_name"`
+	PhoneModel      string   `json:"phone_model"`
+	PhoneImage      string   `json:"phone_image"`
+	Price           float64  `json:"price"`
+	AVGRating       float64  `json:"avg_rating"`
+	MemoryGB        float64  `json:"memory_gb"`
+	StorageGB       float64  `json:"storage_gb"`
+	CameraMP        float64  `json:"camera_mp"`
+	BatteryMah      *float64 `json:"battery_mah"`
+	OperatingSystem string   `json:"operating_system"`
+}
+
+type SimilarPhoneResponse struct {
+	PhoneFeatureResponse
+	// skor kemiripan 0 - 1, makin besar makin mirip
+	Score float64 `json:"score"`
+}
+
+// Get similar phones godoc
+// @Summary Get similar phones by Phone id. (PUBLIC)
+// @Description Get other phones ranked by similarity score (0 - 1) based on specification distance (memory, storage, main rear camera, battery, OS family), price proximity and rating. every factor has a weight that can be overridden with weight_<factor>, set a weight to 0 to ignore the factor. the specification & price of every phone are stored as precomputed normalized features, only phones within ±50% of the price that share a category are scored first, the filter is loosened when there are not enough phones
+// @Tags Phones
+// @Produce json
+// @Param id path string true "Phone id or slug"
+// @Param limit query int false "number of phones, default 10, max 50"
+// @Param same_brand query bool false "include phones of the same brand, default true"
+// @Param weight_memory query number false "memory weight, default 1"
+// @Param weight_storage query number false "storage weight, default 1"
+// @Param weight_camera query number false "camera weight, default 1"
+// @Param weight_battery query number false "battery weight, default 1"
+// @Param weight_os query number false "operating system weight, default 1"
+// @Param weight_price query number false "price weight, default 2"
+// @Param weight_rating query number false "rating weight, default 1"
+// @Success 200 {object} []SimilarPhoneResponse
+// @Router /phones/{id}/similar [get]
+func GetSimilarPhones(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var phone models.Phone
+	if err := visiblePhones(c, db.Model(&models.Phone{})).Where("phones.id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	target, err := getPhoneFeature(db, phone.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	defaults := map[string]float64{"rating": defaultSimilarRatingWeight}
+	for name, weight := range similarity.DefaultWeights {
+		defaults[name] = weight
+	}
+	weights := map[string]float64{}
+	for name, weight := range defaults {
+		if value := c.Query("weight_" + name); value != "" {
+			w, err := strconv.ParseFloat(value, 64)
+			if err != nil || w < 0 || math.IsInf(w, 0) || math.IsNaN(w) {
+				c.JSON(http.StatusBadRequest,
+					utils.ResponseJSON(fmt.Sprintf("weight_%s harus berupa angka >= 0", name), http.StatusBadRequest, nil))
+				return
+			}
+			weight = w
+		}
+		weights[name] = weight
+	}
+
+	// fitur yg tidak diketahui (contoh phone tanpa specification) tidak dibandingkan
+	weights = similarity.Weights(target, weights)
+
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	if total == 0 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("minimal satu weight harus lebih dari 0", http.StatusBadRequest, nil))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultSimilarLimit)))
+	if err != nil || limit <= 0 {
+		limit = defaultSimilarLimit
+	}
+	limit = min(limit, maxSimilarLimit)
+
+	// skor dihitung di database dari fitur yg sudah dinormalisasi
+	terms, args := similarity.ScoreTerms(target, weights)
+	if weights["rating"] > 0 {
+		terms = append(terms, strconv.FormatFloat(weights["rating"], 'f', -1, 64)+" * (c.avg_rating / 5.0)")
+	}
+
+	scoreExpr := fmt.Sprintf("(%s) / %s", strings.Join(terms, " + "), strconv.FormatFloat(total, 'f', -1, 64))
+
+	var categoryIDs []uint
+	if err := db.Table("phone_categories").Where("phone_id = ?", phone.ID).Pluck("category_id", &categoryIDs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	sameBrand, err := strconv.ParseBool(c.DefaultQuery("same_brand", "true"))
+	if err != nil {
+		sameBrand = true
+	}
+
+	// kandidat disaring dulu (rentang harga & kategori yg sama) agar tidak semua
+	// phone dihitung skornya, saringan dilonggarkan jika hasilnya kurang dari limit
+	usePriceBand := weights["price"] > 0 && target.Price > 0
+	useCategory := len(categoryIDs) > 0
+	var phones []SimilarPhoneResponse
+	for i, stage := range [][2]bool{{true, true}, {true, false}, {false, true}, {false, false}} {
+		priceBand, category := stage[0], stage[1]
+		if (priceBand && !usePriceBand) || (category && !useCategory) {
+			continue
+		}
+
+		candidates := visiblePhones(c, similarCandidateQuery(db)).Where("c.phone_id <> ?", phone.ID)
+		if !sameBrand {
+			candidates = candidates.Where("phones.brand_id <> ?", phone.BrandID)
+		}
+		if priceBand {
+			candidates = candidates.Where("c.price BETWEEN ? AND ?",
+				math.Floor(float64(target.Price)*(1-similarPriceBand)), math.Ceil(float64(target.Price)*(1+similarPriceBand)))
+		}
+		if category {
+			candidates = candidates.Where("c.phone_id IN (?)",
+				db.Table("phone_categories").Select("phone_id").Where("category_id IN ?", categoryIDs))
+		}
+
+		phones = []SimilarPhoneResponse{}
+		if err := db.Table("(?) as c", candidates).
+			Select("c.*, "+scoreExpr+" as score", args...).
+			Order("score DESC").Order("c.phone_id ASC").
+			Limit(limit).
+			Scan(&phones).Error; err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+		if len(phones) >= limit || i == 3 {
+			break
+		}
+	}
+
+	for i := range phones {
+		phones[i].Score = math.Round(phones[i].Score*10000) / 10000
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, phones))
+}
+
+// similarCandidateQuery fitur phone yg sudah dihitung beserta data phone &
+// rata-rata rating, rating hanya dihitung untuk kandidat yg lolos saringan
+func similarCandidateQuery(db *gorm.DB) *gorm.DB {
+	return db.Table("phone_features as c").
+		Select(`c.*,
+				phones.brand_id,
+				brands.name as brand_name,
+				phones.model as phone_model,
+				phones.image_url as phone_image,
+				COALESCE(ROUND((SELECT AVG(reviews.rating) FROM reviews
+					WHERE reviews.phone_id = c.phone_id AND reviews.status = ?), 2), 0) as avg_rating`,
+			models.ReviewStatusApproved).
+		Joins("JOIN phones on phones.id = c.phone_id").
+		Joins("JOIN brands on brands.id = phones.brand_id")
+}
+
+// getPhoneFeature fitur phone yg sudah dihitung, dihitung saat itu juga jika belum ada
+func getPhoneFeature(db *gorm.DB, phoneID uint) (models.PhoneFeature, error) {
+	var feature models.PhoneFeature
+	err := db.Where("phone_id = ?", phoneID).Limit(1).Find(&feature).Error
+	if err != nil || feature.PhoneID != 0 {
+		return feature, err
+	}
+	if err := similarity.Refresh(db, phoneID); err != nil {
+		return feature, err
+	}
+	err = db.Where("phone_id = ?", phoneID).First(&feature).Error
+	return feature, err
+}
+
+// refreshPhoneFeatures hitung ulang fitur kemiripan phone setelah specification /
+// harga berubah, kegagalan hanya dicatat karena data utama sudah tersimpan
+func refreshPhoneFeatures(db *gorm.DB, phoneIDs ...uint) {
+	if err := similarity.Refresh(db, phoneIDs...); err != nil {
+		log.Println("gagal menghitung ulang fitur phone:", err.Error())
+	}
+}
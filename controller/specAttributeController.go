@@ -0,0 +1,688 @@
+package controller
+
+import (
+	"errors"
+	"final-project/lib"
+	"final-project/models"
+	"final-project/utils"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var attributeKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type specAttributeInput struct {
+	Key           string   `json:"key" binding:"required"`
+	Label         string   `json:"label" binding:"required"`
+	DataType      string   `json:"data_type" binding:"required,oneof=string number boolean enum"`
+	Unit          string   `json:"unit"`
+	AllowedValues []string `json:"allowed_values"`
+	Group         string   `json:"group"`
+}
+
+type specAttributeUpdate struct {
+	Label         string   `json:"label"`
+	DataType      string   `json:"data_type" binding:"omitempty,oneof=string number boolean enum"`
+	Unit          string   `json:"unit"`
+	AllowedValues []string `json:"allowed_values"`
+	Group         string   `json:"group"`
+}
+
+type phoneAttributesInput struct {
+	// key atribut -> nilai, nilai null untuk menghapus atribut dari phone
+	Attributes map[string]any `json:"attributes" binding:"required"`
+}
+
+type PhoneAttributeResponse struct {
+	Key      string `json:"key"`
+	Label    string `json:"label"`
+	Group    string `json:"group"`
+	DataType string `json:"data_type"`
+	Unit     string `json:"unit"`
+	Value    any    `json:"value"`
+}
+
+// Get all spec attributes godoc
+// @Summary Get all specification attribute definitions. (PUBLIC)
+// @Description Get a list of admin-defined specification attributes, can be filtered by group
+// @Tags Spec Attributes
+// @Produce json
+// @Param group query string false "attribute group"
+// @Success 200 {object} []models.SpecAttribute
+// @Router /spec-attributes [get]
+func GetAllSpecAttributes(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	query := db.Model(&models.SpecAttribute{})
+	if group := c.Query("group"); group != "" {
+		query = query.Where("attribute_group = ?", group)
+	}
+
+	var attributes []models.SpecAttribute
+	if err := query.Order("attribute_group ASC, id ASC").Find(&attributes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, attributes))
+}
+
+// Create spec attribute godoc
+// @Summary Create specification attribute definition (ADMIN ONLY)
+// @Description Register a new specification attribute (e.g. esim, satellite_sos), key must be lowercase snake_case, allowed_values is required for enum data type
+// @Tags Spec Attributes
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param Body body specAttributeInput true "example JSON body to create a specification attribute"
+// @Produce json
+// @Success 200 {object} models.SpecAttribute
+// @Router /spec-attributes [post]
+func CreateSpecAttribute(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	// Validate input
+	var input specAttributeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	if !attributeKeyRegex.MatchString(input.Key) {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("key harus huruf kecil, angka atau underscore (snake_case)", http.StatusBadRequest, nil))
+		return
+	}
+
+	// cek key atribut sudah ada atau belum
+	var count int64
+	db.Model(&models.SpecAttribute{}).Where("attribute_key = ?", input.Key).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.MsgAlreadyExist("key"), http.StatusBadRequest, nil))
+		return
+	}
+
+	attribute_data := models.SpecAttribute{
+		Key:           input.Key,
+		Label:         input.Label,
+		DataType:      input.DataType,
+		Unit:          input.Unit,
+		AllowedValues: input.AllowedValues,
+		Group:         input.Group,
+	}
+
+	if !isSpecAttributeValid(c, attribute_data) {
+		return
+	}
+
+	if err := db.Create(&attribute_data).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgAdded("spec attribute"), http.StatusOK, attribute_data))
+}
+
+// Update spec attribute godoc
+// @Summary Update specification attribute definition (ADMIN ONLY)
+// @Description Update a specification attribute by id, key can not be changed and data_type can only be changed when no phone uses the attribute, allowed_values can not drop a value still used by a phone
+// @Tags Spec Attributes
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "attribute id"
+// @Param Body body specAttributeUpdate true "example JSON body to update a specification attribute"
+// @Produce json
+// @Success 200 {object} models.SpecAttribute
+// @Router /spec-attributes/{id} [put]
+func UpdateSpecAttribute(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input specAttributeUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	var attribute models.SpecAttribute
+	if err := db.Where("id = ?", c.Param("id")).First(&attribute).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("spec attribute"), http.StatusNotFound, nil))
+		return
+	}
+
+	// data type hanya boleh diganti jika belum ada phone yg memakai atribut ini
+	if input.DataType != "" && input.DataType != attribute.DataType {
+		var used int64
+		db.Model(&models.SpecAttributeValue{}).Where("attribute_id = ?", attribute.ID).Count(&used)
+		if used > 0 {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON("data_type tidak bisa diganti karena atribut sudah dipakai phone", http.StatusBadRequest, nil))
+			return
+		}
+		attribute.DataType = input.DataType
+	}
+
+	// Update yg diinput saja
+	if input.Label != "" {
+		attribute.Label = input.Label
+	}
+	if input.Unit != "" {
+		attribute.Unit = input.Unit
+	}
+	if input.AllowedValues != nil {
+		attribute.AllowedValues = input.AllowedValues
+	}
+	if input.Group != "" {
+		attribute.Group = input.Group
+	}
+
+	if !isSpecAttributeValid(c, attribute) {
+		return
+	}
+
+	// allowed_values tidak boleh dipersempit selama masih ada phone yg memakai
+	// nilai yg dihapus dari daftar
+	if input.AllowedValues != nil && len(attribute.AllowedValues) > 0 {
+		var used []string
+		if err := db.Model(&models.SpecAttributeValue{}).
+			Where("attribute_id = ?", attribute.ID).
+			Distinct().
+			Pluck("value_string", &used).Error; err != nil {
+			c.JSON(http.StatusInternalServerError,
+				utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+			return
+		}
+		var removed []string
+		for _, value := range used {
+			if value != "" && !slices.Contains(attribute.AllowedValues, value) {
+				removed = append(removed, value)
+			}
+		}
+		if len(removed) > 0 {
+			slices.Sort(removed)
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON("allowed_values tidak bisa diubah karena nilai berikut masih dipakai phone: "+strings.Join(removed, ", "), http.StatusBadRequest, nil))
+			return
+		}
+	}
+
+	attribute.UpdatedAt = time.Now()
+
+	if err := db.Save(&attribute).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON("Failed to update spec attribute", http.StatusInternalServerError, nil))
+		return
+	}
+
+	// key atribut benchmark bisa berubah
+	refreshValueScores(db)
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("spec attribute"), http.StatusOK, attribute))
+}
+
+// Delete spec attribute godoc
+// @Summary Delete specification attribute definition (ADMIN ONLY)
+// @Description Delete a specification attribute by id, including its values on every phone
+// @Tags Spec Attributes
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Param id path string true "attribute id"
+// @Success 200 {object} map[string]boolean
+// @Router /spec-attributes/{id} [delete]
+func DeleteSpecAttribute(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var attribute models.SpecAttribute
+	if err := db.Where("id = ?", c.Param("id")).First(&attribute).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("spec attribute"), http.StatusNotFound, nil))
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("attribute_id = ?", attribute.ID).Delete(&models.SpecAttributeValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&attribute).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	refreshValueScores(db)
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgDeleted("spec attribute"), http.StatusOK, nil))
+}
+
+// Get phone attributes godoc
+// @Summary Get specification attributes by Phone id. (PUBLIC)
+// @Description Get all admin-defined specification attribute values of a phone
+// @Tags Phones
+// @Produce json
+// @Param id path string true "Phone id or slug"
+// @Success 200 {object} []PhoneAttributeResponse
+// @Router /phones/{id}/attributes [get]
+func GetPhoneAttributes(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var phone models.Phone
+	if err := visiblePhones(c, db).Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	attributes, err := getPhoneAttributes(db, phone.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, attributes))
+}
+
+// Set phone attributes godoc
+// @Summary Set specification attributes for phone (ADMIN ONLY)
+// @Description Set attribute values of a phone by attribute key, values are validated against the attribute data type & allowed values. send null to remove an attribute from the phone
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Param id path string true "Phone id"
+// @Param Body body phoneAttributesInput true "example JSON body : {\"attributes\": {\"esim\": true, \"satellite_sos\": \"emergency only\"}}"
+// @Produce json
+// @Success 200 {object} []PhoneAttributeResponse
+// @Router /phones/{id}/attributes [put]
+func SetPhoneAttributes(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input phoneAttributesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		errorMessage := utils.CustomBindError(err)
+		if errorMessage != "" {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(errorMessage, http.StatusBadRequest, nil))
+		}
+		return
+	}
+
+	var phone models.Phone
+	if err := db.Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	keys := make([]string, 0, len(input.Attributes))
+	for key := range input.Attributes {
+		keys = append(keys, key)
+	}
+
+	var definitions []models.SpecAttribute
+	if err := db.Where("attribute_key IN ?", keys).Find(&definitions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	byKey := map[string]models.SpecAttribute{}
+	for _, def := range definitions {
+		byKey[def.Key] = def
+	}
+
+	// validasi semua nilai dulu sebelum disimpan
+	dataErr := []string{}
+	values := []models.SpecAttributeValue{}
+	removed := []uint{}
+	for _, key := range keys {
+		def, ok := byKey[key]
+		if !ok {
+			dataErr = append(dataErr, lib.ErrMsgNotFound("atribut "+key))
+			continue
+		}
+		raw := input.Attributes[key]
+		if raw == nil {
+			removed = append(removed, def.ID)
+			continue
+		}
+		value, err := buildAttributeValue(def, raw)
+		if err != nil {
+			dataErr = append(dataErr, err.Error())
+			continue
+		}
+		value.PhoneID = phone.ID
+		values = append(values, value)
+	}
+
+	if len(dataErr) > 0 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(strings.Join(dataErr, ", "), http.StatusBadRequest, nil))
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if len(removed) > 0 {
+			if err := tx.Where("phone_id = ? AND attribute_id IN ?", phone.ID, removed).
+				Delete(&models.SpecAttributeValue{}).Error; err != nil {
+				return err
+			}
+		}
+		for _, value := range values {
+			var existing models.SpecAttributeValue
+			err := tx.Where("phone_id = ? AND attribute_id = ?", phone.ID, value.AttributeID).First(&existing).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			value.ID = existing.ID
+			if err := tx.Omit("Attribute").Save(&value).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	// atribut benchmark ikut dihitung di value score
+	refreshValueScores(db, phone.ID)
+
+	attributes, err := getPhoneAttributes(db, phone.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("phone attributes"), http.StatusOK, attributes))
+}
+
+type PhoneCompareResponse struct {
+	Phones     []PhoneCompareItem    `json:"phones"`
+	Attributes []AttributeCompareRow `json:"attributes"`
+}
+
+type PhoneCompareItem struct {
+	PhonesCompleteResponse
+	Specification *models.Specification `json:"specification"`
+}
+
+// satu baris tabel perbandingan, values urut sesuai urutan phones
+type AttributeCompareRow struct {
+	Key      string `json:"key"`
+	Label    string `json:"label"`
+	Group    string `json:"group"`
+	DataType string `json:"data_type"`
+	Unit     string `json:"unit"`
+	Values   []any  `json:"values"`
+}
+
+// Compare phones godoc
+// @Summary Compare phones. (PUBLIC)
+// @Description Compare 2 - 4 phones side by side, including their specification and every admin-defined attribute used by at least one of the phones
+// @Tags Phones
+// @Produce json
+// @Param ids query string true "comma separated phone ids, e.g. 1,2,3"
+// @Success 200 {object} PhoneCompareResponse
+// @Router /phones/compare [get]
+func ComparePhones(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	ids := []uint{}
+	for _, idStr := range strings.Split(c.Query("ids"), ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(idStr))
+		if err != nil || id <= 0 {
+			continue
+		}
+		if !slices.Contains(ids, uint(id)) {
+			ids = append(ids, uint(id))
+		}
+	}
+
+	if len(ids) < 2 || len(ids) > 4 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("ids harus berisi 2 - 4 id phone", http.StatusBadRequest, nil))
+		return
+	}
+
+	var phones []PhonesCompleteResponse
+	if err := visiblePhones(c, phoneCompleteQuery(db)).Where("phones.id IN ?", ids).Scan(&phones).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	if len(phones) != len(ids) {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	var specs []models.Specification
+	if err := db.Preload("CameraModules").Where("phone_id IN ?", ids).Find(&specs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	var values []models.SpecAttributeValue
+	if err := db.Preload("Attribute").Where("phone_id IN ?", ids).Find(&values).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	// urutkan phone sesuai urutan ids di query
+	response := PhoneCompareResponse{Phones: []PhoneCompareItem{}, Attributes: []AttributeCompareRow{}}
+	position := map[uint]int{}
+	for i, id := range ids {
+		position[id] = i
+		for _, phone := range phones {
+			if uint(phone.PhoneID) != id {
+				continue
+			}
+			item := PhoneCompareItem{PhonesCompleteResponse: phone}
+			for j := range specs {
+				if specs[j].PhoneID == id {
+					item.Specification = &specs[j]
+				}
+			}
+			response.Phones = append(response.Phones, item)
+		}
+	}
+
+	rows := map[uint]*AttributeCompareRow{}
+	order := []uint{}
+	for _, value := range values {
+		row, ok := rows[value.AttributeID]
+		if !ok {
+			row = &AttributeCompareRow{
+				Key:      value.Attribute.Key,
+				Label:    value.Attribute.Label,
+				Group:    value.Attribute.Group,
+				DataType: value.Attribute.DataType,
+				Unit:     value.Attribute.Unit,
+				Values:   make([]any, len(ids)),
+			}
+			rows[value.AttributeID] = row
+			order = append(order, value.AttributeID)
+		}
+		row.Values[position[value.PhoneID]] = value.TypedValue()
+	}
+	slices.SortFunc(order, func(a, b uint) int {
+		return strings.Compare(rows[a].Group+rows[a].Key, rows[b].Group+rows[b].Key)
+	})
+	for _, id := range order {
+		response.Attributes = append(response.Attributes, *rows[id])
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, response))
+}
+
+func isSpecAttributeValid(c *gin.Context, attribute models.SpecAttribute) bool {
+	if attribute.DataType == models.AttributeTypeEnum && len(attribute.AllowedValues) == 0 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.MsgRequired("allowed_values"), http.StatusBadRequest, nil))
+		return false
+	}
+	if attribute.DataType != models.AttributeTypeEnum && attribute.DataType != models.AttributeTypeString &&
+		len(attribute.AllowedValues) > 0 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON("allowed_values hanya untuk data_type string atau enum", http.StatusBadRequest, nil))
+		return false
+	}
+	return true
+}
+
+// buildAttributeValue validasi nilai dari JSON sesuai definisi atribut
+func buildAttributeValue(def models.SpecAttribute, raw any) (models.SpecAttributeValue, error) {
+	value := models.SpecAttributeValue{AttributeID: def.ID}
+
+	switch def.DataType {
+	case models.AttributeTypeNumber:
+		number, ok := raw.(float64)
+		if !ok {
+			return value, fmt.Errorf("%s harus berupa angka", def.Key)
+		}
+		value.ValueNumber = &number
+	case models.AttributeTypeBoolean:
+		boolean, ok := raw.(bool)
+		if !ok {
+			return value, fmt.Errorf("%s harus berupa true/false", def.Key)
+		}
+		value.ValueBool = &boolean
+	default:
+		text, ok := raw.(string)
+		if !ok || text == "" {
+			return value, fmt.Errorf("%s harus berupa teks", def.Key)
+		}
+		if len(def.AllowedValues) > 0 && !slices.Contains(def.AllowedValues, text) {
+			return value, fmt.Errorf("%s harus salah satu dari: %s", def.Key, strings.Join(def.AllowedValues, ", "))
+		}
+		value.ValueString = text
+	}
+
+	return value, nil
+}
+
+func getPhoneAttributes(db *gorm.DB, phoneID uint) ([]PhoneAttributeResponse, error) {
+	var values []models.SpecAttributeValue
+	if err := db.Preload("Attribute").Where("phone_id = ?", phoneID).Find(&values).Error; err != nil {
+		return nil, err
+	}
+
+	attributes := make([]PhoneAttributeResponse, 0, len(values))
+	for _, value := range values {
+		attributes = append(attributes, PhoneAttributeResponse{
+			Key:      value.Attribute.Key,
+			Label:    value.Attribute.Label,
+			Group:    value.Attribute.Group,
+			DataType: value.Attribute.DataType,
+			Unit:     value.Attribute.Unit,
+			Value:    value.TypedValue(),
+		})
+	}
+	slices.SortFunc(attributes, func(a, b PhoneAttributeResponse) int {
+		return strings.Compare(a.Group+a.Key, b.Group+b.Key)
+	})
+
+	return attributes, nil
+}
+
+// applyPhoneAttributeFilter filter phones berdasarkan atribut tambahan,
+// contoh ?attr[esim]=true&attr_min[charging_watt]=60
+func applyPhoneAttributeFilter(c *gin.Context, query *gorm.DB) (*gorm.DB, error) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	exact := c.QueryMap("attr")
+	minimum := c.QueryMap("attr_min")
+	maximum := c.QueryMap("attr_max")
+	if len(exact) == 0 && len(minimum) == 0 && len(maximum) == 0 {
+		return query, nil
+	}
+
+	keys := []string{}
+	for _, m := range []map[string]string{exact, minimum, maximum} {
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+
+	var definitions []models.SpecAttribute
+	if err := db.Where("attribute_key IN ?", keys).Find(&definitions).Error; err != nil {
+		return query, err
+	}
+	byKey := map[string]models.SpecAttribute{}
+	for _, def := range definitions {
+		byKey[def.Key] = def
+	}
+
+	for key, raw := range exact {
+		def, ok := byKey[key]
+		if !ok {
+			return query, errors.New(lib.ErrMsgNotFound("atribut " + key))
+		}
+		sub := db.Model(&models.SpecAttributeValue{}).Select("phone_id").Where("attribute_id = ?", def.ID)
+		switch def.DataType {
+		case models.AttributeTypeNumber:
+			number, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return query, fmt.Errorf("%s harus berupa angka", key)
+			}
+			sub = sub.Where("value_number = ?", number)
+		case models.AttributeTypeBoolean:
+			boolean, err := strconv.ParseBool(raw)
+			if err != nil {
+				return query, fmt.Errorf("%s harus berupa true/false", key)
+			}
+			sub = sub.Where("value_bool = ?", boolean)
+		default:
+			sub = sub.Where("LOWER(value_string) = LOWER(?)", raw)
+		}
+		query = query.Where("phones.id IN (?)", sub)
+	}
+
+	for op, m := range map[string]map[string]string{">=": minimum, "<=": maximum} {
+		for key, raw := range m {
+			def, ok := byKey[key]
+			if !ok {
+				return query, errors.New(lib.ErrMsgNotFound("atribut " + key))
+			}
+			if def.DataType != models.AttributeTypeNumber {
+				return query, fmt.Errorf("filter min/max hanya untuk atribut angka (%s)", key)
+			}
+			number, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return query, fmt.Errorf("%s harus berupa angka", key)
+			}
+			sub := db.Model(&models.SpecAttributeValue{}).Select("phone_id").
+				Where("attribute_id = ?", def.ID).
+				Where("value_number "+op+" ?", number)
+			query = query.Where("phones.id IN (?)", sub)
+		}
+	}
+
+	return query, nil
+}
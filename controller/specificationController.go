@@ -4,37 +4,78 @@ import (
 	"final-project/lib"
 	"final-project/models"
 	"final-project/utils"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
+type cameraModuleInput struct {
+	Position     string  `json:"position" binding:"required,oneof=rear front"`
+	Role         string  `json:"role" binding:"required,oneof=main ultrawide telephoto macro depth"`
+	ResolutionMP float64 `json:"resolution_mp" binding:"required"`
+	OpticalZoom  float64 `json:"optical_zoom"`
+}
+
 type specificationInput struct {
-	Network           string `json:"network" binding:"required"`
-	OperatingSystem   string `json:"operating_system" binding:"required"`
-	Storage           uint   `json:"storage" binding:"required"`
-	Memory            uint   `json:"memory" binding:"required"`
-	Camera            uint   `json:"camera" binding:"required"`
-	Battery           string `json:"battery" binding:"required"`
-	AdditionalFeature string `json:"additional_feature"`
+	DisplaySizeInch           float64             `json:"display_size_inch"`
+	DisplayResolutionWidthPx  uint                `json:"display_resolution_width_px"`
+	DisplayResolutionHeightPx uint                `json:"display_resolution_height_px"`
+	DisplayRefreshRateHz      uint                `json:"display_refresh_rate_hz"`
+	DisplayType               string              `json:"display_type"`
+	OperatingSystem           string              `json:"operating_system" binding:"required"`
+	Chipset                   string              `json:"chipset"`
+	MemoryGB                  uint                `json:"memory_gb" binding:"required"`
+	StorageGB                 uint                `json:"storage_gb" binding:"required"`
+	BatteryMah                *uint               `json:"battery_mah" binding:"required"`
+	ChargingWatt              uint                `json:"charging_watt"`
+	HeightMm                  float64             `json:"height_mm"`
+	WidthMm                   float64             `json:"width_mm"`
+	ThicknessMm               float64             `json:"thickness_mm"`
+	WeightGram                float64             `json:"weight_gram"`
+	IPRating                  string              `json:"ip_rating"`
+	Network                   string              `json:"network" binding:"required"`
+	Wifi                      string              `json:"wifi"`
+	Bluetooth                 string              `json:"bluetooth"`
+	NFC                       bool                `json:"nfc"`
+	USB                       string              `json:"usb"`
+	AdditionalFeature         string              `json:"additional_feature"`
+	CameraModules             []cameraModuleInput `json:"camera_modules" binding:"required,min=1,dive"`
 }
 
 type specificationUpdate struct {
-	Network           string `json:"network"`
-	OperatingSystem   string `json:"operating_system"`
-	Storage           uint   `json:"storage"`
-	Memory            uint   `json:"memory"`
-	Camera            uint   `json:"camera"`
-	Battery           string `json:"battery"`
-	AdditionalFeature string `json:"additional_feature"`
+	DisplaySizeInch           float64             `json:"display_size_inch"`
+	DisplayResolutionWidthPx  uint                `json:"display_resolution_width_px"`
+	DisplayResolutionHeightPx uint                `json:"display_resolution_height_px"`
+	DisplayRefreshRateHz      uint                `json:"display_refresh_rate_hz"`
+	DisplayType               string              `json:"display_type"`
+	OperatingSystem           string              `json:"operating_system"`
+	Chipset                   string              `json:"chipset"`
+	MemoryGB                  uint                `json:"memory_gb"`
+	StorageGB                 uint                `json:"storage_gb"`
+	BatteryMah                uint                `json:"battery_mah"`
+	ChargingWatt              uint                `json:"charging_watt"`
+	HeightMm                  float64             `json:"height_mm"`
+	WidthMm                   float64             `json:"width_mm"`
+	ThicknessMm               float64             `json:"thickness_mm"`
+	WeightGram                float64             `json:"weight_gram"`
+	IPRating                  string              `json:"ip_rating"`
+	Network                   string              `json:"network"`
+	Wifi                      string              `json:"wifi"`
+	Bluetooth                 string              `json:"bluetooth"`
+	NFC                       *bool               `json:"nfc"`
+	USB                       string              `json:"usb"`
+	AdditionalFeature         string              `json:"additional_feature"`
+	CameraModules             []cameraModuleInput `json:"camera_modules" binding:"omitempty,dive"`
 }
 
 // Create Specification for phone godoc
 // @Summary Create Specification for phone (ADMIN ONLY)
-// @Description Creating a specification data for phone, only admin can access this route
+// @Description Creating a specification data for phone, only admin can access this route. units : display in inch/px/Hz, memory & storage in GB, battery in mAh, charging in watt, dimensions in mm, weight in gram, camera in MP. network accepts 2G/3G/4G/5G or free text like "GSM / LTE / 5G"
 // @Tags Phones
 // @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
 // @Security BearerToken
@@ -103,34 +144,34 @@ func CreateSpecification(c *gin.Context) {
 		return
 	}
 
-	specification_data := models.Specification{
-		Network:           input.Network,
-		OperatingSystem:   input.OperatingSystem,
-		Storage:           uint(input.Storage),
-		Memory:            uint(input.Memory),
-		Camera:            uint(input.Camera),
-		Battery:           input.Battery,
-		AdditionalFeature: input.AdditionalFeature,
-		PhoneID:           uint(phoneID),
+	specification_data := toSpecification(input)
+	specification_data.PhoneID = uint(phoneID)
+
+	if !isSpecificationDataValid(c, &specification_data) {
+		return
 	}
 
+	// camera modules ikut dibuat oleh gorm (association)
 	if err := db.Create(&specification_data).Error; err != nil {
 		c.JSON(http.StatusInternalServerError,
 			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
 		return
 	}
 
+	refreshValueScores(db, specification_data.PhoneID)
+	refreshPhoneFeatures(db, specification_data.PhoneID)
+
 	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgAdded("specification"), http.StatusOK, specification_data))
 }
 
 // Update Specification for phone godoc
 // @Summary Update Specification for phone (ADMIN ONLY)
-// @Description Creating a specification data for phone, only admin can access this route
+// @Description Updating a specification data for phone, only admin can access this route. only filled fields are updated, camera_modules (if sent) replaces all existing camera modules
 // @Tags Phones
 // @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
 // @Security BearerToken
 // @Param id path string true "phone id"
-// @Param Body body specificationInput true "example JSON body to update a specification for phone"
+// @Param Body body specificationUpdate true "example JSON body to update a specification for phone"
 // @Produce json
 // @Success 200 {object} models.Specification
 // @Router /phones/{id}/specification [put]
@@ -152,41 +193,200 @@ func UpdateSpecification(c *gin.Context) {
 
 	// cek data phone ada
 	var spec models.Specification
-	if err := db.Where("phone_id = ?", phoneID).First(&spec).Error; err != nil {
+	if err := db.Preload("CameraModules").Where("phone_id = ?", phoneID).First(&spec).Error; err != nil {
 		c.JSON(http.StatusBadRequest,
 			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusBadRequest, nil))
 		return
 	}
 
 	// Update yg diinput saja
-	if input.Network != "" {
-		spec.Network = input.Network
+	if input.DisplaySizeInch != 0 {
+		spec.DisplaySizeInch = input.DisplaySizeInch
+	}
+	if input.DisplayResolutionWidthPx != 0 {
+		spec.DisplayResolutionWidthPx = input.DisplayResolutionWidthPx
+	}
+	if input.DisplayResolutionHeightPx != 0 {
+		spec.DisplayResolutionHeightPx = input.DisplayResolutionHeightPx
+	}
+	if input.DisplayRefreshRateHz != 0 {
+		spec.DisplayRefreshRateHz = input.DisplayRefreshRateHz
+	}
+	if input.DisplayType != "" {
+		spec.DisplayType = input.DisplayType
 	}
 	if input.OperatingSystem != "" {
 		spec.OperatingSystem = input.OperatingSystem
 	}
-	if input.Storage != 0 {
-		spec.Storage = input.Storage
+	if input.Chipset != "" {
+		spec.Chipset = input.Chipset
+	}
+	if input.MemoryGB != 0 {
+		spec.MemoryGB = input.MemoryGB
+	}
+	if input.StorageGB != 0 {
+		spec.StorageGB = input.StorageGB
+	}
+	if input.BatteryMah != 0 {
+		spec.BatteryMah = &input.BatteryMah
+	}
+	if input.ChargingWatt != 0 {
+		spec.ChargingWatt = input.ChargingWatt
+	}
+	if input.HeightMm != 0 {
+		spec.HeightMm = input.HeightMm
 	}
-	if input.Memory != 0 {
-		spec.Memory = input.Memory
+	if input.WidthMm != 0 {
+		spec.WidthMm = input.WidthMm
 	}
-	if input.Camera != 0 {
-		spec.Camera = input.Camera
+	if input.ThicknessMm != 0 {
+		spec.ThicknessMm = input.ThicknessMm
 	}
-	if input.Battery != "" {
-		spec.Battery = input.Battery
+	if input.WeightGram != 0 {
+		spec.WeightGram = input.WeightGram
+	}
+	if input.IPRating != "" {
+		spec.IPRating = strings.ToUpper(input.IPRating)
+	}
+	if input.Network != "" {
+		spec.Network = input.Network
+	}
+	if input.Wifi != "" {
+		spec.Wifi = input.Wifi
+	}
+	if input.Bluetooth != "" {
+		spec.Bluetooth = input.Bluetooth
+	}
+	if input.NFC != nil {
+		spec.NFC = *input.NFC
+	}
+	if input.USB != "" {
+		spec.USB = input.USB
 	}
 	if input.AdditionalFeature != "" {
 		spec.AdditionalFeature = input.AdditionalFeature
 	}
+	replaceCameras := input.CameraModules != nil
+	if replaceCameras {
+		spec.CameraModules = toCameraModules(input.CameraModules)
+	}
+
+	if !isSpecificationDataValid(c, &spec) {
+		return
+	}
+
 	spec.UpdatedAt = time.Now()
 
-	if err := db.Save(&spec).Error; err != nil {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if replaceCameras {
+			if err := tx.Where("specification_id = ?", spec.ID).Delete(&models.CameraModule{}).Error; err != nil {
+				return err
+			}
+			for i := range spec.CameraModules {
+				spec.CameraModules[i].SpecificationID = spec.ID
+			}
+			if len(spec.CameraModules) > 0 {
+				if err := tx.Create(&spec.CameraModules).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Omit("CameraModules").Save(&spec).Error
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError,
 			utils.ResponseJSON("Failed to update specification", http.StatusInternalServerError, nil))
 		return
 	}
 
+	refreshValueScores(db, spec.PhoneID)
+	refreshPhoneFeatures(db, spec.PhoneID)
+
 	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("specification"), http.StatusOK, spec))
 }
+
+func toCameraModules(inputs []cameraModuleInput) []models.CameraModule {
+	modules := make([]models.CameraModule, 0, len(inputs))
+	for _, in := range inputs {
+		modules = append(modules, models.CameraModule{
+			Position:     in.Position,
+			Role:         in.Role,
+			ResolutionMP: in.ResolutionMP,
+			OpticalZoom:  in.OpticalZoom,
+		})
+	}
+	return modules
+}
+
+// isSpecificationDataValid validasi nilai & satuan specification, network
+// teks bebas dinormalisasi menjadi generasi jaringan (2G/3G/4G/5G)
+func isSpecificationDataValid(c *gin.Context, spec *models.Specification) bool {
+	if dataErr := validateSpecification(spec); len(dataErr) > 0 {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(strings.Join(dataErr, ", "), http.StatusBadRequest, nil))
+		return false
+	}
+
+	return true
+}
+
+// validateSpecification sama seperti isSpecificationDataValid tapi
+// mengembalikan daftar pesan error (dipakai juga oleh import katalog)
+func validateSpecification(spec *models.Specification) []string {
+	dataErr := []string{}
+
+	network := utils.ParseNetworkGeneration(spec.Network)
+	if network == "" {
+		dataErr = append(dataErr, "network harus berisi 2G, 3G, 4G atau 5G")
+	}
+	spec.Network = network
+
+	if spec.DisplaySizeInch != 0 && (spec.DisplaySizeInch < 1 || spec.DisplaySizeInch > 20) {
+		dataErr = append(dataErr, "display_size_inch harus antara 1 - 20 inch")
+	}
+	if (spec.DisplayResolutionWidthPx == 0) != (spec.DisplayResolutionHeightPx == 0) {
+		dataErr = append(dataErr, "display_resolution_width_px dan display_resolution_height_px harus diisi bersamaan")
+	}
+	if spec.DisplayResolutionWidthPx > 10000 || spec.DisplayResolutionHeightPx > 10000 {
+		dataErr = append(dataErr, "resolusi display maksimal 10000 px")
+	}
+	if spec.DisplayRefreshRateHz != 0 && (spec.DisplayRefreshRateHz < 30 || spec.DisplayRefreshRateHz > 240) {
+		dataErr = append(dataErr, "display_refresh_rate_hz harus antara 30 - 240 Hz")
+	}
+	if spec.MemoryGB > 64 {
+		dataErr = append(dataErr, "memory_gb maksimal 64 GB")
+	}
+	if spec.StorageGB > 4096 {
+		dataErr = append(dataErr, "storage_gb maksimal 4096 GB")
+	}
+	// battery_mah null hanya ada pada data lama yg tidak bisa di-parse
+	if spec.BatteryMah != nil && (*spec.BatteryMah < 500 || *spec.BatteryMah > 30000) {
+		dataErr = append(dataErr, "battery_mah harus antara 500 - 30000 mAh")
+	}
+	if spec.ChargingWatt > 300 {
+		dataErr = append(dataErr, "charging_watt maksimal 300 W")
+	}
+	if spec.HeightMm < 0 || spec.HeightMm > 300 || spec.WidthMm < 0 || spec.WidthMm > 300 {
+		dataErr = append(dataErr, "height_mm dan width_mm harus antara 0 - 300 mm")
+	}
+	if spec.ThicknessMm < 0 || spec.ThicknessMm > 50 {
+		dataErr = append(dataErr, "thickness_mm harus antara 0 - 50 mm")
+	}
+	if spec.WeightGram < 0 || spec.WeightGram > 1000 {
+		dataErr = append(dataErr, "weight_gram harus antara 0 - 1000 gram")
+	}
+	if spec.IPRating != "" && !utils.IsValidIPRating(spec.IPRating) {
+		dataErr = append(dataErr, "ip_rating harus berformat IPxx, contoh IP68")
+	}
+
+	for i, cam := range spec.CameraModules {
+		if cam.ResolutionMP <= 0 || cam.ResolutionMP > 400 {
+			dataErr = append(dataErr, fmt.Sprintf("camera_modules[%d].resolution_mp harus antara 0 - 400 MP", i))
+		}
+		if cam.OpticalZoom < 0 || cam.OpticalZoom > 100 {
+			dataErr = append(dataErr, fmt.Sprintf("camera_modules[%d].optical_zoom harus antara 0 - 100x", i))
+		}
+	}
+
+	return dataErr
+}
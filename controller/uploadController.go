@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"final-project/lib"
+	"final-project/models"
+	"final-project/storage"
+	"final-project/utils"
+	"final-project/utils/token"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Upload phone image godoc
+// @Summary Upload Phone image (ADMIN ONLY)
+// @Description Upload an image file (jpeg, png, gif or webp) as the phone image, thumbnails (small, medium, large) are generated automatically. the phone's image_url is replaced with the uploaded image url
+// @Tags Phones
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Accept multipart/form-data
+// @Param id path string true "Phone id"
+// @Param image formData file true "image file"
+// @Produce json
+// @Success 200 {object} storage.UploadedImage
+// @Router /phones/{id}/image [post]
+func UploadPhoneImage(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var phone models.Phone
+	if err := db.Where("id = ?", c.Param("id")).First(&phone).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("phone"), http.StatusNotFound, nil))
+		return
+	}
+
+	uploaded, ok := saveUploadedImage(c, "phones/"+strconv.Itoa(int(phone.ID)))
+	if !ok {
+		return
+	}
+
+	oldURL := phone.ImageURL
+	if err := db.Model(&phone).Updates(models.Phone{ImageURL: uploaded.URL, UpdatedAt: time.Now()}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	// image lama bisa jadi cover gallery, jangan dihapus jika masih dipakai gallery
+	var usedByGallery int64
+	db.Model(&models.PhoneImage{}).Where("url = ?", oldURL).Count(&usedByGallery)
+	if usedByGallery == 0 {
+		removeReplacedImage(c, oldURL, uploaded.URL)
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("phone image"), http.StatusOK, uploaded))
+}
+
+// Upload brand logo godoc
+// @Summary Upload Brand logo (ADMIN ONLY)
+// @Description Upload an image file (jpeg, png, gif or webp) as the brand logo, thumbnails (small, medium, large) are generated automatically. the brand's logo_url is replaced with the uploaded image url
+// @Tags Brands
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Accept multipart/form-data
+// @Param id path string true "Brand id"
+// @Param image formData file true "image file"
+// @Produce json
+// @Success 200 {object} storage.UploadedImage
+// @Router /brands/{id}/logo [post]
+func UploadBrandLogo(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var brand models.Brand
+	if err := db.Where("id = ?", c.Param("id")).First(&brand).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON(lib.ErrMsgNotFound("brand"), http.StatusNotFound, nil))
+		return
+	}
+
+	uploaded, ok := saveUploadedImage(c, "brands/"+strconv.Itoa(int(brand.ID)))
+	if !ok {
+		return
+	}
+
+	oldURL := brand.LogoURL
+	if err := db.Model(&brand).Updates(models.Brand{LogoURL: uploaded.URL, UpdatedAt: time.Now()}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	removeReplacedImage(c, oldURL, uploaded.URL)
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("brand logo"), http.StatusOK, uploaded))
+}
+
+// Upload profile image godoc
+// @Summary Upload Profile image
+// @Description Upload an image file (jpeg, png, gif or webp) as the profile image, user ID is taken from JWT Token so only acount's owner can update the profile image
+// @Tags Profiles
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Accept multipart/form-data
+// @Param image formData file true "image file"
+// @Produce json
+// @Success 200 {object} storage.UploadedImage
+// @Router /profiles/image [post]
+func UploadProfileImage(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	// user id dari token
+	userID, err := token.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	var profile models.Profile
+	if err := db.Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		c.JSON(http.StatusNotFound,
+			utils.ResponseJSON("user ini belum mengisikan profile", http.StatusNotFound, nil))
+		return
+	}
+
+	uploaded, ok := saveUploadedImage(c, "profiles/"+strconv.Itoa(int(userID)))
+	if !ok {
+		return
+	}
+
+	oldURL := profile.ImageURL
+	if err := db.Model(&profile).Updates(models.Profile{ImageURL: uploaded.URL, UpdatedAt: time.Now()}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+	removeReplacedImage(c, oldURL, uploaded.URL)
+
+	c.JSON(http.StatusOK, utils.ResponseJSON(lib.MsgUpdated("profile image"), http.StatusOK, uploaded))
+}
+
+// saveUploadedImage mengambil file dari form field "image" lalu menyimpannya ke storage
+func saveUploadedImage(c *gin.Context, dir string) (*storage.UploadedImage, bool) {
+	store := c.MustGet("storage").(storage.Storage)
+
+	file, err := c.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			utils.ResponseJSON(lib.MsgRequired("image"), http.StatusBadRequest, nil))
+		return nil, false
+	}
+
+	uploaded, err := storage.SaveImage(c.Request.Context(), store, dir, file)
+	if err != nil {
+		if storage.IsImageError(err) {
+			c.JSON(http.StatusBadRequest,
+				utils.ResponseJSON(storage.ImageErrorMessage(err), http.StatusBadRequest, nil))
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return nil, false
+	}
+
+	return uploaded, true
+}
+
+// removeReplacedImage menghapus gambar lama dari storage setelah diganti,
+// kegagalan hanya dicatat karena data baru sudah tersimpan
+func removeReplacedImage(c *gin.Context, oldURL, newURL string) {
+	if oldURL == "" || oldURL == newURL {
+		return
+	}
+	store := c.MustGet("storage").(storage.Storage)
+	if err := storage.DeleteImage(c.Request.Context(), store, oldURL); err != nil {
+		log.Println("gagal menghapus gambar lama:", err.Error())
+	}
+}
@@ -155,11 +155,15 @@ func DeleteMyAccount(c *gin.Context) {
 			utils.ResponseJSON("password salah, gagal menghapus akun", http.StatusBadRequest, nil))
 		return
 	}
+	// file foto review user ikut dihapus dari storage
+	photoURLs, _ := reviewPhotoURLs(db, db.Model(&models.Review{}).Select("id").Where("user_id = ?", user.ID))
+
 	if err := db.Delete(&user).Error; err != nil {
 		c.JSON(http.StatusInternalServerError,
 			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
 		return
 	}
+	removeReviewPhotoFiles(c, photoURLs)
 	c.JSON(http.StatusOK,
 		utils.ResponseJSON(lib.MsgDeleted("user"), http.StatusOK, nil))
 }
@@ -195,11 +199,15 @@ func DeleteUserById(c *gin.Context) {
 		return
 	}
 
+	// file foto review user ikut dihapus dari storage
+	photoURLs, _ := reviewPhotoURLs(db, db.Model(&models.Review{}).Select("id").Where("user_id = ?", user.ID))
+
 	if err := db.Delete(&user).Error; err != nil {
 		c.JSON(http.StatusInternalServerError,
 			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
 		return
 	}
+	removeReviewPhotoFiles(c, photoURLs)
 	c.JSON(http.StatusOK,
 		utils.ResponseJSON(lib.MsgDeleted("user"), http.StatusOK, user))
 }
@@ -344,7 +352,7 @@ func GetUserReviewByID(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
 
 	userID := c.Param("id")
-	if err := db.Preload("Reviews").Find(&user, userID).Error; err != nil {
+	if err := db.Preload("Reviews", "status = ?", models.ReviewStatusApproved).Find(&user, userID).Error; err != nil {
 		c.JSON(http.StatusNotFound,
 			utils.ResponseJSON(lib.ErrMsgNotFound("user"), http.StatusNotFound, nil))
 		return
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"final-project/utils"
+	"final-project/valuescore"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Get value score config godoc
+// @Summary Get value score configuration. (ADMIN ONLY)
+// @Description Get the weight of each spec score component (memory, storage, camera, battery, benchmark) and the number attribute used as benchmark, configured with VALUE_SCORE_WEIGHTS & VALUE_SCORE_BENCHMARK_ATTRIBUTE
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} valuescore.Config
+// @Router /admin/value-score [get]
+func GetValueScoreConfig(c *gin.Context) {
+	config, err := valuescore.LoadConfig()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("", http.StatusOK, config))
+}
+
+// Recompute value score godoc
+// @Summary Recompute value score of all phones. (ADMIN ONLY)
+// @Description Recompute spec_score & value_score of all phones. spec_score (0 - 100) is the weighted average of each spec component normalized to the best phone, value_score is spec_score per 1.000.000 of the base price. scores of a phone are recomputed automatically when its specification, benchmark attribute or price changes, all phones are recomputed automatically only when the highest value or weight of a component changes
+// @Tags Admins
+// @Param Authorization header string true "Authorization : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} map[string]boolean
+// @Router /admin/value-score/recompute [post]
+func RecomputeValueScore(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	if err := valuescore.Recompute(db); err != nil {
+		c.JSON(http.StatusInternalServerError,
+			utils.ResponseJSON(err.Error(), http.StatusInternalServerError, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseJSON("value score berhasil dihitung ulang", http.StatusOK, nil))
+}
+
+// refreshValueScores hitung ulang value score phone setelah specification / harga
+// berubah, kegagalan hanya dicatat karena data utama sudah tersimpan
+func refreshValueScores(db *gorm.DB, phoneIDs ...uint) {
+	if err := valuescore.Refresh(db, phoneIDs...); err != nil {
+		log.Println("gagal menghitung ulang value score:", err.Error())
+	}
+}
+
+// applyValueScoreFilter filter phone berdasarkan query min_value_score & max_value_score
+func applyValueScoreFilter(c *gin.Context, query *gorm.DB) (*gorm.DB, error) {
+	for key, operator := range map[string]string{"min_value_score": ">=", "max_value_score": "<="} {
+		value := c.Query(key)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) {
+			return query, fmt.Errorf("%s harus berupa angka", key)
+		}
+		query = query.Where("phones.value_score "+operator+" ?", number)
+	}
+	return query, nil
+}
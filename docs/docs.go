@@ -24,21 +24,21 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admins": {
-            "get": {
+        "/admin/brands/merge": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Get a list of account with 'admin' role, only role admin can acces this route",
+                "description": "Move all phones (with their reviews) and sub brands from the source brand to the target brand in one transaction, then delete the source brand. phone slugs are regenerated with the target brand name, old phone slugs keep redirecting. the old id \u0026 slug of the source brand are redirected to the target brand. duplicate phones inside the merged brand can then be merged with /admin/phones/merge",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admins"
                 ],
-                "summary": "Get all account with 'admin' role. (admin only)",
+                "summary": "Merge a duplicate brand into another brand (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -46,39 +46,42 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "the body to merge brands",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.brandMergeInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "type": "string"
-                                }
-                            }
+                            "$ref": "#/definitions/controller.BrandMergeReport"
                         }
                     }
                 }
             }
         },
-        "/admins/register": {
-            "post": {
+        "/admin/content-filter": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "registering a new account with admin role, only account with role admin can access this route",
+                "description": "Get the rules of the content filter applied to reviews \u0026 comments (profanity, link, phone_number, duplicate) with their action: reject (the content is refused), mask (matching parts are replaced with *) or moderate (the content is saved as pending in the moderation queue), plus the words added by admins on top of the built in Indonesian \u0026 English wordlists",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admins"
                 ],
-                "summary": "Register a new account as admin role. (admin only)",
+                "summary": "Get content filter configuration. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -86,43 +89,33 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "the body to register a admin",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.RegisterInput"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/controller.ContentFilterConfig"
                         }
                     }
                 }
             }
         },
-        "/admins/{id}": {
-            "get": {
+        "/admin/content-filter/rules/{key}": {
+            "put": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Get admin data by ID. only role admin can acces to this route",
+                "description": "Enable / disable a content filter rule or change its action (reject, mask or moderate). the duplicate rule can not use mask. changes apply immediately",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admins"
                 ],
-                "summary": "Get single admin by ID. (admin only)",
+                "summary": "Update a content filter rule. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -133,43 +126,46 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "admin id",
-                        "name": "id",
+                        "description": "rule key",
+                        "name": "key",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "the body to update a content filter rule",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.contentFilterRuleInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "type": "string"
-                                }
-                            }
+                            "$ref": "#/definitions/contentfilter.RuleSetting"
                         }
                     }
                 }
             }
         },
-        "/admins/{id}/profile": {
-            "get": {
+        "/admin/content-filter/test": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Get all Admins profile data by admin id. only admin can access this route, if admin not create profile yet the profile will not appear",
+                "description": "Run the content filter on a text without saving anything, returns the matching rules, the resulting action and the masked content. the duplicate rule compares with all saved reviews \u0026 comments",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admins"
                 ],
-                "summary": "Get profiles data by Admin id. (admin only)",
+                "summary": "Try the content filter on a text. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -179,41 +175,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "admin id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "the text to check",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.contentFilterTestInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.User"
-                            }
+                            "$ref": "#/definitions/contentfilter.Result"
                         }
                     }
                 }
             }
         },
-        "/admins/{id}/reviews": {
-            "get": {
+        "/admin/content-filter/words": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Get all Users review data by user id if user not create review yet the review will not appear",
+                "description": "Add a word (language id or en) checked by the profanity rule in addition to the built in wordlists. end the word with * to also match words starting with it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admins"
                 ],
-                "summary": "Get reviews data by User id. (ADMIN ONLY)",
+                "summary": "Add a word to the profanity filter. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -223,41 +218,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "user id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "the body to add a word",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.contentFilterWordInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.User"
-                            }
+                            "$ref": "#/definitions/models.ContentFilterWord"
                         }
                     }
                 }
             }
         },
-        "/auth/change-password": {
-            "put": {
+        "/admin/content-filter/words/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "changging current logged in user's password",
+                "description": "Remove a word added by an admin, built in words can not be removed (disable the profanity rule instead)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admins"
                 ],
-                "summary": "Change password (ADMIN AND USER)",
+                "summary": "Remove a word from the profanity filter. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -267,13 +261,11 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "body for changing user's password, user id is taken from the authorization token",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.changePasswordInput"
-                        }
+                        "type": "string",
+                        "description": "word id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -281,59 +273,76 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
                         }
                     }
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Logging in to get jwt token to access admin or user api by roles.",
+        "/admin/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Export the whole catalog in the same format accepted by POST /admin/import",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admins"
                 ],
-                "summary": "Login.",
+                "summary": "Export all brands, phones \u0026 specifications as CSV / JSON (ADMIN ONLY)",
                 "parameters": [
                     {
-                        "description": "the body to login a user choose using email or username",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.LoginInput"
-                        }
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv / json, default json",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.CatalogRow"
+                            }
                         }
                     }
                 }
             }
         },
-        "/auth/logout": {
+        "/admin/import": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Simple logout for (user/admin)",
+                "description": "Bulk import catalog rows. every row contains a brand, a phone and optionally its specification, phones are upserted by brand name + model (brands are created when they do not exist yet, brand_logo_url is required for new brands). the whole file is imported in one transaction, nothing is saved if any row is invalid. use dry_run=true to only validate the file. CSV columns are the same as the export, camera_modules in CSV is written as \"position:role:resolution_mp[:optical_zoom]\" separated by \";\", e.g. \"rear:main:50:1;front:main:12\". JSON is an array of rows with a nested specification object",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admins"
                 ],
-                "summary": "logout (ADMIN AND USER)",
+                "summary": "Import brands, phones \u0026 specifications from CSV / JSON (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -341,87 +350,142 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "catalog file (.csv or .json)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv / json, default from the file extension",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only validate the file, nothing is saved",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/controller.ImportReport"
                         }
                     }
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "registering a user from public access.",
+        "/admin/moderation/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get comments by moderation status, oldest first. status defaults to pending, comments become pending when the content filter sends them to moderation",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admins"
                 ],
-                "summary": "Register a user.",
+                "summary": "Get the comment moderation queue. (ADMIN ONLY)",
                 "parameters": [
                     {
-                        "description": "the body to register a user, username min 5 characters",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.RegisterInput"
-                        }
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending (default) / approved / rejected / hidden",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by moderation reason code",
+                        "name": "reason",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Comment"
+                            }
                         }
                     }
                 }
             }
         },
-        "/brands": {
-            "get": {
-                "description": "Get a list of Phone brands.",
+        "/admin/moderation/comments/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Publish a pending, rejected or hidden comment",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Brands"
+                    "Admins"
+                ],
+                "summary": "Approve a comment. (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all Phones brands. (PUBLIC)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Brand"
-                            }
+                            "$ref": "#/definitions/models.Comment"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/moderation/comments/{id}/reject": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Creating a new Brand data, only account with role admin can access this route",
+                "description": "Reject a comment with a reason code (see /admin/moderation/config), the comment is no longer public. editing the comment sends it back to the queue",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Brands"
+                    "Admins"
                 ],
-                "summary": "Create New Brand (ADMIN ONLY)",
+                "summary": "Reject a comment. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -431,12 +495,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "example JSON body to create a new Brand",
+                        "type": "string",
+                        "description": "comment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the reason to reject the comment",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.brandInput"
+                            "$ref": "#/definitions/controller.moderationInput"
                         }
                     }
                 ],
@@ -444,28 +515,33 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Brand"
+                            "$ref": "#/definitions/models.Comment"
                         }
                     }
                 }
             }
         },
-        "/brands/{id}": {
+        "/admin/moderation/config": {
             "get": {
-                "description": "Get Brand data by ID.",
-                "produces": [
-                    "application/json"
-                ],
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get the review moderation mode (REVIEW_MODERATION): pre (every new review waits for approval), post (every review is published, admins hide them afterwards) or trust (default, reviews of trusted accounts are published, others wait for approval). an account is trusted when it is older than REVIEW_TRUSTED_MIN_ACCOUNT_DAYS and has at least REVIEW_TRUSTED_MIN_APPROVED approved reviews. reviews of admins are always published. also lists the valid reason codes to reject / hide a review",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Brands"
+                    "Admins"
                 ],
-                "summary": "Get single brand data by ID. (PUBLIC)",
+                "summary": "Get review moderation configuration. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Brand id",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     }
                 ],
@@ -473,28 +549,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Brand"
-                            }
+                            "$ref": "#/definitions/controller.ModerationConfig"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/moderation/reviews": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Update Brand data by id, only account with role admin can access this route",
+                "description": "Get reviews by moderation status, oldest first. status defaults to pending",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Brands"
+                    "Admins"
                 ],
-                "summary": "Update Brand data. (ADMIN ONLY)",
+                "summary": "Get the review moderation queue. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -505,44 +580,57 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Brand id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "pending (default) / approved / rejected / hidden",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
-                        "description": "tExample JSON body to update Brand data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.brandInput"
-                        }
+                        "type": "string",
+                        "description": "filter by phone id",
+                        "name": "phone_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by reviewer id",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by moderation reason code",
+                        "name": "reason",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Brand"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.ModerationReviewsResponse"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/moderation/reviews/{id}/approve": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Delete a Brand by id",
+                "description": "Publish a pending, rejected or hidden review, the review is counted in the phone rating again",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Brands"
+                    "Admins"
                 ],
-                "summary": "Delete Brand by id . (ADMIN ONLY)",
+                "summary": "Approve a review. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -553,7 +641,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Brand id",
+                        "description": "review id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -563,62 +651,77 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "boolean"
-                            }
+                            "$ref": "#/definitions/models.Review"
                         }
                     }
                 }
             }
         },
-        "/brands/{id}/phones": {
-            "get": {
-                "description": "Get all Phones data by brand id.",
+        "/admin/moderation/reviews/{id}/hide": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Hide a review with a reason code (see /admin/moderation/config), used for post moderation. the review is no longer public nor counted in the phone rating",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Brands"
+                    "Admins"
                 ],
-                "summary": "Get phones data by Brand id. (PUBLIC)",
+                "summary": "Hide a published review. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Brand id",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "the reason to hide the review",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.moderationInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Brand"
-                            }
+                            "$ref": "#/definitions/models.Review"
                         }
                     }
                 }
             }
         },
-        "/comments": {
-            "get": {
+        "/admin/moderation/reviews/{id}/reject": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Get all of comments data.",
+                "description": "Reject a review with a reason code (see /admin/moderation/config), the review is no longer public nor counted in the phone rating. editing the review sends it back to the queue",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Admins"
                 ],
-                "summary": "Get all Phones comments. (ADMIN ONLY)",
+                "summary": "Reject a review. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -626,36 +729,49 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the reason to reject the review",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.moderationInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Comment"
-                            }
+                            "$ref": "#/definitions/models.Review"
                         }
                     }
                 }
             }
         },
-        "/comments/{id}": {
-            "put": {
+        "/admin/phones/merge": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "This route will update comment data, will only be able to update data related to the logged in user (user ID is taken from the JWT token)",
+                "description": "Move reviews (with their comments), specifications, images, variants, regional prices, attributes, categories \u0026 tags from the source phone to the target phone in one transaction, then delete the source phone. review_strategy picks the review kept when the same user reviewed both phones: keep_target (default), keep_source, keep_newest or keep_highest (rating). specifications are only moved when the target has none, prices \u0026 attributes the target already has are kept. the old id \u0026 slug of the source phone are redirected to the target phone",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Admins"
                 ],
-                "summary": "Update Comment on a review",
+                "summary": "Merge a duplicate phone into another phone (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -665,19 +781,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "comment id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "example JSON body to update a comment, user_id is taken from the authorization token",
+                        "description": "the body to merge phones",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.commentInput"
+                            "$ref": "#/definitions/controller.phoneMergeInput"
                         }
                     }
                 ],
@@ -685,25 +794,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Comment"
+                            "$ref": "#/definitions/controller.PhoneMergeReport"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/phones/publish-scheduled": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Delete a Comment by id, (user ID is taken from the JWT token so only loogged in user can delete its own coment)",
+                "description": "Change the status of scheduled draft phones to their requested status once publish_at has passed. called every minute by the cron job on GET /cron/publish with the header \"Authorization: Bearer \u003cCRON_SECRET\u003e\" (see vercel.json)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Admins"
                 ],
-                "summary": "Delete Comment by id .",
+                "summary": "Publish scheduled phones whose publish_at has passed. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -711,13 +822,6 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comment id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -726,28 +830,28 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "boolean"
+                                "type": "integer"
                             }
                         }
                     }
                 }
             }
         },
-        "/comments/{id}/admin": {
-            "delete": {
+        "/admin/recommendations/recompute": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Delete a Comment by id,",
+                "description": "Recompute the cached recommendations of every user with reviews. also called periodically by the cron job on GET /cron/recommendations with the header \"Authorization: Bearer \u003cCRON_SECRET\u003e\" (see vercel.json)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Admins"
                 ],
-                "summary": "Delete Comment by id (ADMIN ONLY).",
+                "summary": "Recompute phone recommendations of all users. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -755,13 +859,6 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comment id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -777,21 +874,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/dashboard/all-count-data": {
+        "/admin/value-score": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Get a number of all data",
+                "description": "Get the weight of each spec score component (memory, storage, camera, battery, benchmark) and the number attribute used as benchmark, configured with VALUE_SCORE_WEIGHTS \u0026 VALUE_SCORE_BENCHMARK_ATTRIBUTE",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Dashboard"
+                    "Admins"
                 ],
-                "summary": "number of all data (ADMIN ONLY)",
+                "summary": "Get value score configuration. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -805,96 +902,70 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "additionalProperties": true
-                            }
+                            "$ref": "#/definitions/valuescore.Config"
                         }
                     }
                 }
             }
         },
-        "/phones": {
-            "get": {
-                "description": "Get a list of Phone.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Phones"
-                ],
-                "summary": "Get all Phones data. (PUBLIC)",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Phone"
-                            }
-                        }
-                    }
-                }
-            },
+        "/admin/value-score/recompute": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Creating a new Phone data, only account with role admin can accsess this route",
+                "description": "Recompute spec_score \u0026 value_score of all phones. spec_score (0 - 100) is the weighted average of each spec component normalized to the best phone, value_score is spec_score per 1.000.000 of the base price. scores of a phone are recomputed automatically when its specification, benchmark attribute or price changes, all phones are recomputed automatically only when the highest value or weight of a component changes",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Phones"
+                    "Admins"
                 ],
-                "summary": "Create New Phone (ADMIN ONLY)",
+                "summary": "Recompute value score of all phones. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "example JSON body to create a new Phone, sample release_data format = 2023-09-22T00:00:00Z",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.phoneInput"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Phone"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
                         }
                     }
                 }
             }
         },
-        "/phones/{id}": {
+        "/admins": {
             "get": {
-                "description": "Get a Phone by id.",
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get a list of account with 'admin' role, only role admin can acces this route",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Phones"
+                    "Admins"
                 ],
-                "summary": "Get Phone. (PUBLIC)",
+                "summary": "Get all account with 'admin' role. (admin only)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "phone id",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     }
                 ],
@@ -902,25 +973,33 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Phone"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admins/register": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Update Phone data by id, only account with role admin can access this route",
+                "description": "registering a new account with admin role, only account with role admin can access this route",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Phones"
+                    "Admins"
                 ],
-                "summary": "Update Phone data. (ADMIN ONLY)",
+                "summary": "Register a new account as admin role. (admin only)",
                 "parameters": [
                     {
                         "type": "string",
@@ -930,19 +1009,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Phone id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Example JSON body to update Phone data, sample release_data format = 2023-09-22T00:00:00Z",
+                        "description": "the body to register a admin",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.phoneInput"
+                            "$ref": "#/definitions/controller.RegisterInput"
                         }
                     }
                 ],
@@ -950,25 +1022,28 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Phone"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admins/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Delete a Phone by id, only account with role admin can access this route",
+                "description": "Get admin data by ID. only role admin can acces to this route",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Phones"
+                    "Admins"
                 ],
-                "summary": "Delete Phone by id . (ADMIN ONLY)",
+                "summary": "Get single admin by ID. (admin only)",
                 "parameters": [
                     {
                         "type": "string",
@@ -979,7 +1054,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Phone id",
+                        "description": "admin id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -991,58 +1066,31 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "boolean"
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
                             }
                         }
                     }
                 }
             }
         },
-        "/phones/{id}/reviews": {
+        "/admins/{id}/profile": {
             "get": {
-                "description": "Get all Reviews data by phone id. if reviews data is empty, the review data will not be displayed",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Phones"
-                ],
-                "summary": "Get reviews data by Phone id. (PUBLIC)",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Phone id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Phone"
-                            }
-                        }
-                    }
-                }
-            },
-            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "This route will create review data , user ID is taken from the JWT token, one user only can give one review to one phone",
+                "description": "Get all Admins profile data by admin id. only admin can access this route, if admin not create profile yet the profile will not appear",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Phones"
+                    "Admins"
                 ],
-                "summary": "Create New Review",
+                "summary": "Get profiles data by Admin id. (admin only)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1053,45 +1101,51 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Phone id",
+                        "description": "admin id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "example JSON body to create a new Review",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.reviewInput"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Review"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
                         }
                     }
                 }
             }
         },
-        "/phones/{id}/specification": {
+        "/admins/{id}/reviews": {
             "get": {
-                "description": "Get Phone specifiction data by phone id. if phone's specification data empty, the spec data will not be displayed",
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get all Users review data by user id if user not create review yet the review will not appear",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Phones"
+                    "Admins"
                 ],
-                "summary": "Get specification data by Phone id. (PUBLIC)",
+                "summary": "Get reviews data by User id. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Phone id",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "user id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1103,26 +1157,28 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Phone"
+                                "$ref": "#/definitions/models.User"
                             }
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/change-password": {
             "put": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Creating a specification data for phone, only admin can access this route",
+                "description": "changging current logged in user's password",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Phones"
+                    "Auth"
                 ],
-                "summary": "Update Specification for phone (ADMIN ONLY)",
+                "summary": "Change password (ADMIN AND USER)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1132,19 +1188,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "phone id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "example JSON body to update a specification for phone",
+                        "description": "body for changing user's password, user id is taken from the authorization token",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.specificationInput"
+                            "$ref": "#/definitions/controller.changePasswordInput"
                         }
                     }
                 ],
@@ -1152,47 +1201,31 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Specification"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/login": {
             "post": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Creating a specification data for phone, only admin can access this route",
+                "description": "Logging in to get jwt token to access admin or user api by roles.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Phones"
+                    "Auth"
                 ],
-                "summary": "Create Specification for phone (ADMIN ONLY)",
+                "summary": "Login.",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Phone id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "example JSON body to create a specification for phone",
+                        "description": "the body to login a user choose using email or username",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.specificationInput"
+                            "$ref": "#/definitions/controller.LoginInput"
                         }
                     }
                 ],
@@ -1200,27 +1233,28 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Specification"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/profiles": {
-            "put": {
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Updating a profile data for user, user ID is taken from JWT Token so only acount's owner can update the profile",
+                "description": "Simple logout for (user/admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profiles"
+                    "Auth"
                 ],
-                "summary": "Update Profile for user",
+                "summary": "logout (ADMIN AND USER)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1228,55 +1262,37 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "example JSON body to update a profile for user, user_id is taken from the authorization token",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.profileInput"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Profile"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Creating a profile data for user, user ID is taken from JWT Token so only acount's owner can create the profile",
+                "description": "registering a user from public access.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profiles"
+                    "Auth"
                 ],
-                "summary": "Create Profile for user",
+                "summary": "Register a user.",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "example JSON body to create a profile for user, user_id is taken from the authorization token",
+                        "description": "the body to register a user, username min 5 characters",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.profileInput"
+                            "$ref": "#/definitions/controller.RegisterInput"
                         }
                     }
                 ],
@@ -1284,65 +1300,49 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Profile"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/reviews": {
+        "/brands": {
             "get": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Get all Reviews",
+                "description": "Get a list of Phone brands.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
-                ],
-                "summary": "Get all reviews (ADMIN ONLY)",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    }
+                    "Brands"
                 ],
+                "summary": "Get all Phones brands. (PUBLIC)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object",
-                                "additionalProperties": true
+                                "$ref": "#/definitions/models.Brand"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/reviews/{id}": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "This route will update review data , user ID is taken from the JWT token",
+                "description": "Creating a new Brand data, only account with role admin can access this route",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Brands"
                 ],
-                "summary": "Update Review for phone",
+                "summary": "Create New Brand (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1352,19 +1352,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "review id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "example JSON body to update a review for phone",
+                        "description": "example JSON body to create a new Brand, parent_id is optional (e.g. Redmi under Xiaomi)",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.reviewInput"
+                            "$ref": "#/definitions/controller.brandInput"
                         }
                     }
                 ],
@@ -1372,68 +1365,49 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Review"
+                            "$ref": "#/definitions/models.Brand"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "This route will delete review data, based on the review ID and will only be able to delete data related to the logged in user (user ID is taken from the JWT token)",
+            }
+        },
+        "/brands/tree": {
+            "get": {
+                "description": "Get all brands as a tree of parent brands and their sub brands (e.g. Xiaomi -\u003e Redmi, POCO). stats only counts the brand's own phones, total_stats rolls up the brand and all of its sub brands, avg_rating is weighted by review count",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
-                ],
-                "summary": "Delete review by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "review id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Brands"
                 ],
+                "summary": "Get brand hierarchy. (PUBLIC)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Review"
+                                "$ref": "#/definitions/controller.BrandTreeNode"
                             }
                         }
                     }
                 }
             }
         },
-        "/reviews/{id}/comments": {
+        "/brands/{id}": {
             "get": {
-                "description": "Get all Comments data by review id.",
+                "description": "Get Brand data by ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Brands"
                 ],
-                "summary": "Get comments data by Review id.",
+                "summary": "Get single brand data by ID. (PUBLIC)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Review id",
+                        "description": "Brand id or slug",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1445,26 +1419,26 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Review"
+                                "$ref": "#/definitions/models.Brand"
                             }
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Creating a comment on a phone review, (user ID is taken from the JWT token)",
+                "description": "Update Brand data by id, only account with role admin can access this route",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Brands"
                 ],
-                "summary": "Create Comment on a review",
+                "summary": "Update Brand data. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1475,18 +1449,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "review id",
+                        "description": "Brand id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "example JSON body to create a comment, user_id is taken from the authorization token",
+                        "description": "tExample JSON body to update Brand data, parent_id 0 removes the parent brand",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.commentInput"
+                            "$ref": "#/definitions/controller.brandUpdate"
                         }
                     }
                 ],
@@ -1494,27 +1468,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Comment"
+                            "$ref": "#/definitions/models.Brand"
                         }
                     }
                 }
-            }
-        },
-        "/roles": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Get a list of user's roles. only admin can access this route",
+                "description": "Delete a Brand by id, its sub brands are moved to the deleted brand's parent",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Brands"
                 ],
-                "summary": "Get all  roles. (ADMIN ONLY)",
+                "summary": "Delete Brand by id . (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1522,34 +1494,46 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Brand id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Role"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
                             }
                         }
                     }
                 }
-            },
+            }
+        },
+        "/brands/{id}/logo": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Creating a new Role data, only admin can access this route",
+                "description": "Upload an image file (jpeg, png, gif or webp) as the brand logo, thumbnails (small, medium, large) are generated automatically. the brand's logo_url is replaced with the uploaded image url",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Brands"
                 ],
-                "summary": "Create New Role (ADMIN ONLY)",
+                "summary": "Upload Brand logo (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1559,82 +1543,139 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "example JSON body to create a new Role",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.roleInput"
-                        }
+                        "type": "string",
+                        "description": "Brand id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Role"
+                            "$ref": "#/definitions/storage.UploadedImage"
                         }
                     }
                 }
             }
         },
-        "/roles/{id}": {
+        "/brands/{id}/phones": {
             "get": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Get a role data by id. only admin can access this route",
+                "description": "Get all Phones data by brand id. draft phones are only listed for admin",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Brands"
                 ],
-                "summary": "Get role by ID. (ADMIN ONLY)",
+                "summary": "Get phones data by Brand id. (PUBLIC)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "Brand id or slug",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Role id",
+                        "description": "comma separated phone statuses",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "also list phones of all sub brands (e.g. Redmi \u0026 POCO phones for Xiaomi)",
+                        "name": "include_sub_brands",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Brand"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/brands/{id}/tree": {
+            "get": {
+                "description": "Get a brand, its sub brands (recursively) and the rolled up stats of the whole subtree",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Brands"
+                ],
+                "summary": "Get a brand with its sub brands. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Brand id or slug",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.BrandTreeNode"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Get a list of phone categories",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get all phone categories. (PUBLIC)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Role"
+                                "$ref": "#/definitions/models.Category"
                             }
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Update Role data by id, only account with role admin can access this route",
+                "description": "Create a new phone category, slug is generated from name when empty",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Categories"
                 ],
-                "summary": "Update Role data. (ADMIN ONLY)",
+                "summary": "Create phone category (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1644,19 +1685,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Role id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "tExample JSON body to update Role data",
+                        "description": "example JSON body to create a category",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.roleInput"
+                            "$ref": "#/definitions/controller.categoryInput"
                         }
                     }
                 ],
@@ -1664,25 +1698,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Role"
+                            "$ref": "#/definitions/models.Category"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/categories/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Delete a Role by id, only admin can access this route",
+                "description": "Update a phone category by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Categories"
                 ],
-                "summary": "Delete Role by id . (ADMIN ONLY)",
+                "summary": "Update phone category (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1693,40 +1729,44 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Role id",
+                        "description": "Category id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "example JSON body to update a category",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.categoryUpdate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "boolean"
-                            }
+                            "$ref": "#/definitions/models.Category"
                         }
                     }
                 }
-            }
-        },
-        "/roles/{id}/users": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Get all Users data by role id. only admin can access this route, if role has'nt have any user it will not display the user data",
+                "description": "Delete a phone category by id, phones in this category are not deleted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Categories"
                 ],
-                "summary": "Get users data by Role id. (ADMIN ONLY)",
+                "summary": "Delete phone category (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1737,7 +1777,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Role id",
+                        "description": "Category id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1747,54 +1787,92 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Role"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
                             }
                         }
                     }
                 }
             }
         },
-        "/users": {
+        "/categories/{slug}/phones": {
             "get": {
-                "description": "Get a list of account with 'user' role.",
+                "description": "Get all phones in a category, can be narrowed down with the tag filter",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Categories"
+                ],
+                "summary": "Get phones data by category slug. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated tags, only phones having all of the tags",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated phone statuses",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc / desc",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "region code (e.g. ID, MY, SG, US), fills local_price",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency code (e.g. USD), fills local_price",
+                        "name": "currency",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all account with user role. (PUBLIC)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "type": "string"
-                                }
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.PhonesCompleteResponse"
                             }
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/comments": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "update its own user data, user ID is taken from JWT Token so only acount's owner can update the user information",
+                "description": "Get all of comments data.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Comments"
                 ],
-                "summary": "Update User data.",
+                "summary": "Get all Phones comments. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1802,40 +1880,36 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "tExample JSON body to update User data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.userUpdate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Comment"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/comments/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Will delete the user account itself, user ID is taken from JWT Token so only acount's owner can delete its own accout",
+                "description": "This route will update comment data, will only be able to update data related to the logged in user (user ID is taken from the JWT token). the previous content is kept as a revision (see /comments/{id}/revisions), a comment can not be changed anymore after COMMENT_EDIT_WINDOW_HOURS (0 = no limit)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Comments"
                 ],
-                "summary": "Delete user's own account",
+                "summary": "Update Comment on a review",
                 "parameters": [
                     {
                         "type": "string",
@@ -1845,12 +1919,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "the body to delete user's own account",
+                        "type": "string",
+                        "description": "comment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to update a comment, user_id is taken from the authorization token",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.deleteUserInput"
+                            "$ref": "#/definitions/controller.commentInput"
                         }
                     }
                 ],
@@ -1858,30 +1939,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.User"
-                            }
+                            "$ref": "#/definitions/models.Comment"
                         }
                     }
                 }
-            }
-        },
-        "/users/role": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Get role by user id (id is taken from JWT)",
+                "description": "Delete a Comment by id, (user ID is taken from the JWT token so only loogged in user can delete its own coment)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Comments"
                 ],
-                "summary": "Get role by User id. (ADMIN \u0026 USER)",
+                "summary": "Delete Comment by id .",
                 "parameters": [
                     {
                         "type": "string",
@@ -1889,35 +1965,54 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Role"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
                             }
                         }
                     }
                 }
             }
         },
-        "/users/{id}": {
-            "get": {
-                "description": "Get user data by ID.",
+        "/comments/{id}/admin": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a Comment by id,",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Comments"
                 ],
-                "summary": "Get single user by ID. (PUBLIC)",
+                "summary": "Delete Comment by id (ADMIN ONLY).",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user id",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1929,29 +2024,28 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "type": "string"
-                                }
+                                "type": "boolean"
                             }
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/comments/{id}/revisions": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Delete a User by id, only admin can access this route",
+                "description": "Get the previous versions of a comment, oldest first, together with the current version. only the author of the comment and admins can see the history",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Comments"
                 ],
-                "summary": "Delete User by id (ADMIN ONLY)",
+                "summary": "Get the edit history of a comment",
                 "parameters": [
                     {
                         "type": "string",
@@ -1962,44 +2056,80 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "User id",
+                        "description": "comment id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.RevisionsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cron/publish": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Change the status of scheduled draft phones to their requested status once publish_at has passed. called every minute by the cron job on GET /cron/publish with the header \"Authorization: Bearer \u003cCRON_SECRET\u003e\" (see vercel.json)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admins"
+                ],
+                "summary": "Publish scheduled phones whose publish_at has passed. (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "type": "string"
-                                }
+                                "type": "integer"
                             }
                         }
                     }
                 }
             }
         },
-        "/users/{id}/profile": {
+        "/cron/recommendations": {
             "get": {
-                "description": "Get all Users profile data by user id., if  user not create profile yet the profile will not be display",
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Recompute the cached recommendations of every user with reviews. also called periodically by the cron job on GET /cron/recommendations with the header \"Authorization: Bearer \u003cCRON_SECRET\u003e\" (see vercel.json)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admins"
                 ],
-                "summary": "Get profiles data by User id. (PUBLIC)",
+                "summary": "Recompute phone recommendations of all users. (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user id",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     }
                 ],
@@ -2007,31 +2137,36 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.User"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
                             }
                         }
                     }
                 }
             }
         },
-        "/users/{id}/reviews": {
+        "/dashboard/all-count-data": {
             "get": {
-                "description": "Get all Users review data by user id if user not create review yet the review will not appear",
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get a number of all data",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Dashboard"
                 ],
-                "summary": "Get reviews data by User id. (PUBLIC)",
+                "summary": "number of all data (ADMIN ONLY)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user id",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     }
                 ],
@@ -2041,56 +2176,4726 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.User"
+                                "type": "object",
+                                "additionalProperties": true
                             }
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controller.LoginInput": {
-            "type": "object",
-            "required": [
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
         },
-        "controller.RegisterInput": {
-            "type": "object",
-            "required": [
-                "email",
-                "password",
-                "username"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string",
-                    "minLength": 5
-                },
-                "username": {
-                    "type": "string",
-                    "minLength": 5
+        "/exchange-rates": {
+            "get": {
+                "description": "Get exchange rates used to convert prices, rate is the value of 1 unit of the currency in IDR",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange Rates"
+                ],
+                "summary": "Get all exchange rates. (PUBLIC)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ExchangeRate"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "controller.brandInput": {
-            "type": "object",
-            "required": [
+        "/exchange-rates/{currency}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Set the exchange rate of a currency (ISO 4217), rate is the value of 1 unit of the currency in IDR",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange Rates"
+                ],
+                "summary": "Create or update exchange rate. (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency code, e.g. USD",
+                        "name": "currency",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to set an exchange rate",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.exchangeRateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ExchangeRate"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete the exchange rate of a currency, prices can no longer be converted to / from it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange Rates"
+                ],
+                "summary": "Delete exchange rate. (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency code, e.g. USD",
+                        "name": "currency",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards": {
+            "get": {
+                "description": "Get the best rated phones ranked by a score adjusted for the number of reviews, so a phone with a single 5 star review does not outrank a phone with hundreds of 4.8 reviews. method bayesian (default): (prior_weight * prior_mean + sum of ratings) / (prior_weight + reviews). method wilson: lower bound of the 95% wilson interval of the rating scaled to 0 - 1, mapped back to 1 - 5. default prior comes from LEADERBOARD_PRIOR_MEAN (empty = average of all reviews) and LEADERBOARD_PRIOR_WEIGHT",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leaderboards"
+                ],
+                "summary": "Get phone leaderboard. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bayesian / wilson, default bayesian",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "bayesian prior mean (1 - 5)",
+                        "name": "prior_mean",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "bayesian prior weight (number of virtual reviews)",
+                        "name": "prior_weight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "minimum number of reviews, default 1",
+                        "name": "min_reviews",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of phones, default 10, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only phones of this brand",
+                        "name": "brand_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only phones in this category slug",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "budget / mid-range / upper-mid-range / flagship",
+                        "name": "price_band",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only phones released in this year",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.LeaderboardResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards/{dimension}": {
+            "get": {
+                "description": "Get the top phones of every brand, category, price band or release year, ranked with the same adjusted score as /leaderboards",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leaderboards"
+                ],
+                "summary": "Get phone leaderboards per brand, category, price band or release year. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "brands / categories / price-bands / years",
+                        "name": "dimension",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "bayesian / wilson, default bayesian",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "bayesian prior mean (1 - 5)",
+                        "name": "prior_mean",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "bayesian prior weight (number of virtual reviews)",
+                        "name": "prior_weight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "minimum number of reviews, default 1",
+                        "name": "min_reviews",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of phones per group, default 10, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.LeaderboardResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones": {
+            "get": {
+                "description": "Get a list of Phone. draft phones are only listed for admin (send the admin token)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get all Phones data. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search keyword",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc / desc",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id (default) / value_score / aspect:\u003ckey\u003e (average rating of a rating aspect, e.g. aspect:camera), phones without the score are listed last",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "only phones with value_score (spec score per 1.000.000 price) at least this",
+                        "name": "min_value_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "only phones with value_score at most this",
+                        "name": "max_value_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only phones with a variant of this memory (GB)",
+                        "name": "memory",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only phones with a variant of this storage (GB)",
+                        "name": "storage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only phones with a variant of this color",
+                        "name": "color",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only phones with a variant priced at least this",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only phones with a variant priced at most this",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only phones with an available / unavailable variant",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated statuses (rumored, announced, released, discontinued, draft for admin only), e.g. status=rumored,announced for upcoming phones",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated category slugs, only phones in any of the categories",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated tags, only phones having all of the tags",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only phones whose attribute \u003ckey\u003e equals this value, e.g. attr[esim]=true",
+                        "name": "attr[key]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "only phones whose number attribute \u003ckey\u003e is at least this value",
+                        "name": "attr_min[key]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "only phones whose number attribute \u003ckey\u003e is at most this value",
+                        "name": "attr_max[key]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "region code (e.g. ID, MY, SG, US), fills local_price with the regional price or the converted base price",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency code (e.g. USD), fills local_price converted to this currency",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Phone"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Creating a new Phone data, only account with role admin can accsess this route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Create New Phone (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to create a new Phone, sample release_data format = 2023-09-22T00:00:00Z. status default released, send a future publish_at to schedule publishing",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.phoneInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Phone"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/calendar": {
+            "get": {
+                "description": "Get phones released (or planned to be released) between from and to, grouped by release month and brand. announced / rumored phones without a release date are listed in unscheduled. default range is the current month and the next 2 months, max 24 months",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get phone release calendar. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "start month / date, YYYY-MM or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end month / date (inclusive), YYYY-MM or YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only phones of this brand",
+                        "name": "brand_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated statuses, e.g. status=rumored,announced for upcoming phones only",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "region code (e.g. ID, MY, SG, US), fills local_price",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency code (e.g. USD), fills local_price",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.CalendarResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/calendar.ics": {
+            "get": {
+                "description": "iCalendar (.ics) feed of phone launch dates from the start of the current month up to 12 months ahead, one all-day event per phone. the url can be subscribed from calendar apps (Google Calendar, Apple Calendar, Outlook)",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get upcoming phone launches as iCalendar feed. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "only phones of this brand",
+                        "name": "brand_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated statuses",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/compare": {
+            "get": {
+                "description": "Compare 2 - 4 phones side by side, including their specification and every admin-defined attribute used by at least one of the phones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Compare phones. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated phone ids, e.g. 1,2,3",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.PhoneCompareResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}": {
+            "get": {
+                "description": "Get a Phone by id or slug (e.g. samsung-galaxy-s24-ultra), including its ordered image gallery, categories and tags. an old slug of a renamed phone is redirected (301) to the current slug",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get Phone. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "phone id or slug",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "region code (e.g. ID, MY, SG, US), fills local_price with the regional price or the converted base price",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency code (e.g. USD), fills local_price converted to this currency",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Phone"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update Phone data by id, only account with role admin can access this route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Update Phone data. (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Example JSON body to update Phone data, sample release_data format = 2023-09-22T00:00:00Z. send a future publish_at to schedule publishing, send status without publish_at to cancel a schedule",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.phoneUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Phone"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a Phone by id, only account with role admin can access this route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Delete Phone by id . (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/attributes": {
+            "get": {
+                "description": "Get all admin-defined specification attribute values of a phone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get specification attributes by Phone id. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone id or slug",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.PhoneAttributeResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Set attribute values of a phone by attribute key, values are validated against the attribute data type \u0026 allowed values. send null to remove an attribute from the phone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Set specification attributes for phone (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body : {\\",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.phoneAttributesInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.PhoneAttributeResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/categories": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Replace the categories of a phone by category slug, send an empty list to remove all categories",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Set Phone categories (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body : {\\",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.phoneCategoriesInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Category"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/image": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Upload an image file (jpeg, png, gif or webp) as the phone image, thumbnails (small, medium, large) are generated automatically. the phone's image_url is replaced with the uploaded image url",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Upload Phone image (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.UploadedImage"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/images": {
+            "get": {
+                "description": "Get the ordered image gallery of a phone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get image gallery by Phone id. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone id or slug",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PhoneImage"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Add an image to the end of the phone gallery, either upload a file (field \"image\") or send an external url (field \"url\"). the first image of a gallery automatically becomes the cover, the cover image is also used as the phone image_url",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Add image to Phone gallery (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "image file",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "external image url, used when no file is uploaded",
+                        "name": "url",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "image caption",
+                        "name": "caption",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "front / back / color / lifestyle / other",
+                        "name": "kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "set this image as the gallery cover",
+                        "name": "is_cover",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PhoneImage"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/images/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Set the gallery order, image_ids must contain every image id of the phone exactly once",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Reorder Phone gallery (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body : {\\",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.phoneImageOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PhoneImage"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/images/{image_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update caption / kind of a gallery image or make it the cover image",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Update Phone gallery image (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image id",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to update a gallery image",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.phoneImageUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PhoneImage"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete an image from the phone gallery, uploaded files are removed from storage. if the cover is deleted the first remaining image becomes the cover",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Delete Phone gallery image (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image id",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/prices": {
+            "get": {
+                "description": "Get the base price (IDR) and every regional price of a phone. amount is in the currency minor unit (e.g. cents), display is the decimal value. send currency to also get every price converted with the exchange rate table",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get regional prices of a Phone. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone id or slug",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency code to convert the prices to, e.g. USD",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.PhonePricesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/prices/{region}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Set the price of a phone in a region (ISO 3166-1 alpha-2, e.g. ID, MY, SG, US). currency defaults to the region currency, amount is the decimal value in major unit and may not have more decimals than the currency minor unit",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Create or update regional price of a Phone. (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "region code, e.g. US",
+                        "name": "region",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to set a regional price, e.g. {\\",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.phonePriceInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PhonePrice"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete the price of a phone in a region, the region falls back to the converted base price",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Delete regional price of a Phone. (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "region code, e.g. US",
+                        "name": "region",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/pros-cons": {
+            "get": {
+                "description": "Similar pros / cons of all approved reviews of a phone are grouped together (ignoring case, punctuation \u0026 filler words) and ranked by the number of reviews mentioning them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get the most mentioned pros \u0026 cons of a phone. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone id or slug",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of pros and of cons (default 10, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ProsConsSummary"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/rating-stats": {
+            "get": {
+                "description": "Get the 1 - 5 star histogram, review count, average, median \u0026 standard deviation of the approved reviews of a phone, plus the average rating per month (months without reviews have a null average) to see whether the reception changed over time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get rating distribution \u0026 trend of a phone. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone id or slug",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the last n months in the trend, default since the first review",
+                        "name": "months",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.RatingStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/reviews": {
+            "get": {
+                "description": "Get all approved Reviews data by phone id. if reviews data is empty, the review data will not be displayed. most_helpful ranks reviews by the lower bound of the wilson interval of helpful votes, so a review with few votes does not outrank a review with many mostly helpful votes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get reviews data by Phone id. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone id or slug",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "most_helpful / newest / highest / lowest rating, default insertion order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only reviews with (true) / without (false) photos",
+                        "name": "with_photos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Phone"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "This route will create review data , user ID is taken from the JWT token, one user only can give one review to one phone. depending on the moderation policy (see /admin/moderation/config) the review is published immediately (status approved) or waits for approval (status pending). variant_id is optional and must be a variant of the reviewed phone. aspects is optional, a map of rating aspect key (see /rating-aspects) to a 1 - 5 rating. pros \u0026 cons are optional ordered lists of at most 10 points of 2 - 150 characters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Create New Review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to create a new Review",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.reviewInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/similar": {
+            "get": {
+                "description": "Get other phones ranked by similarity score (0 - 1) based on specification distance (memory, storage, main rear camera, battery, OS family), price proximity and rating. every factor has a weight that can be overridden with weight_\u003cfactor\u003e, set a weight to 0 to ignore the factor. the specification \u0026 price of every phone are stored as precomputed normalized features, only phones within ±50% of the price that share a category are scored first, the filter is loosened when there are not enough phones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get similar phones by Phone id. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone id or slug",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of phones, default 10, max 50",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include phones of the same brand, default true",
+                        "name": "same_brand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "memory weight, default 1",
+                        "name": "weight_memory",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "storage weight, default 1",
+                        "name": "weight_storage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "camera weight, default 1",
+                        "name": "weight_camera",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "battery weight, default 1",
+                        "name": "weight_battery",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "operating system weight, default 1",
+                        "name": "weight_os",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "price weight, default 2",
+                        "name": "weight_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "rating weight, default 1",
+                        "name": "weight_rating",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.SimilarPhoneResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/specification": {
+            "get": {
+                "description": "Get Phone specifiction data by phone id. if phone's specification data empty, the spec data will not be displayed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get specification data by Phone id. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone id or slug",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Phone"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Updating a specification data for phone, only admin can access this route. only filled fields are updated, camera_modules (if sent) replaces all existing camera modules",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Update Specification for phone (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to update a specification for phone",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.specificationUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Specification"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Creating a specification data for phone, only admin can access this route. units : display in inch/px/Hz, memory \u0026 storage in GB, battery in mAh, charging in watt, dimensions in mm, weight in gram, camera in MP. network accepts 2G/3G/4G/5G or free text like \"GSM / LTE / 5G\"",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Create Specification for phone (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to create a specification for phone",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.specificationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Specification"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/tags": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Replace the tags of a phone, tags are free-form and saved in lowercase. tags that do not exist yet are created automatically, send an empty list to remove all tags",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Set Phone tags (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body : {\\",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.phoneTagsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tag"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/variants": {
+            "get": {
+                "description": "Get all memory/storage/color variants of a phone, ordered by price",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Get variants data by Phone id. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone id or slug",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PhoneVariant"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Creating a memory/storage/color variant with its own price for a phone, only admin can access this route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Create Variant for phone (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to create a variant for phone, memory \u0026 storage in GB",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.phoneVariantInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PhoneVariant"
+                        }
+                    }
+                }
+            }
+        },
+        "/phones/{id}/variants/{variant_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Updating a phone variant data, only admin can access this route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Update Variant for phone (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variant id",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to update a variant for phone",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.phoneVariantUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PhoneVariant"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a phone variant by id, reviews tied to this variant will keep the review but lose the variant, only admin can access this route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Phones"
+                ],
+                "summary": "Delete Variant for phone (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variant id",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Updating a profile data for user, user ID is taken from JWT Token so only acount's owner can update the profile",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profiles"
+                ],
+                "summary": "Update Profile for user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to update a profile for user, user_id is taken from the authorization token",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.profileInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Profile"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Creating a profile data for user, user ID is taken from JWT Token so only acount's owner can create the profile",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profiles"
+                ],
+                "summary": "Create Profile for user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to create a profile for user, user_id is taken from the authorization token",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.profileInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Profile"
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles/image": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Upload an image file (jpeg, png, gif or webp) as the profile image, user ID is taken from JWT Token so only acount's owner can update the profile image",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profiles"
+                ],
+                "summary": "Upload Profile image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.UploadedImage"
+                        }
+                    }
+                }
+            }
+        },
+        "/rating-aspects": {
+            "get": {
+                "description": "Get a list of aspects (camera, battery, performance, ...) that can be rated in a review",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rating Aspects"
+                ],
+                "summary": "Get all review rating aspects. (PUBLIC)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RatingAspect"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Register a new aspect that can be rated in a review, key must be lowercase snake_case",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rating Aspects"
+                ],
+                "summary": "Create review rating aspect (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to create a rating aspect",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.ratingAspectInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RatingAspect"
+                        }
+                    }
+                }
+            }
+        },
+        "/rating-aspects/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update label, description or position of a rating aspect by id, key can not be changed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rating Aspects"
+                ],
+                "summary": "Update review rating aspect (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "aspect id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to update a rating aspect",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.ratingAspectUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RatingAspect"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a rating aspect by id, including its ratings in every review",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rating Aspects"
+                ],
+                "summary": "Delete review rating aspect (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "aspect id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get all Reviews",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get all reviews (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only reviews with (true) / without (false) photos",
+                        "name": "with_photos",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending / approved / rejected / hidden, default all",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "This route will update review data , user ID is taken from the JWT token. the previous content, rating, pros \u0026 cons are kept as a revision (see /reviews/{id}/revisions), content \u0026 rating can not be changed anymore after REVIEW_EDIT_WINDOW_HOURS (0 = no limit). the moderation policy is applied again to the edited review, a rejected or hidden review goes back to the moderation queue",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Update Review for phone",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to update a review for phone, send variant_id 0 to remove the variant. only the sent aspects are changed, send an aspect rating 0 to remove it",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.reviewUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "This route will delete review data, based on the review ID and will only be able to delete data related to the logged in user (user ID is taken from the JWT token)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Delete review by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Review"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/comments": {
+            "get": {
+                "description": "Get all Comments data by review id.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get comments data by Review id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Review"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Creating a comment on a phone review, (user ID is taken from the JWT token)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Create Comment on a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to create a comment, user_id is taken from the authorization token",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.commentInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/photos": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Upload a photo (field \"image\") to the logged in user's review, a review has at most REVIEW_MAX_PHOTOS photos (default 5). EXIF metadata (GPS location, device, ...) is removed before the photo is stored, thumbnails are generated automatically",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Attach a photo to a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "image file (jpeg, png, gif, webp)",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewPhoto"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/photos/{photo_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a photo of the logged in user's review, the file is also removed from storage",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Delete a photo of a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "photo id",
+                        "name": "photo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get the previous versions (content, rating, pros \u0026 cons) of a review, oldest first, together with the current version. only the author of the review and admins can see the history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get the edit history of a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.RevisionsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/vote": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Vote a review helpful (true) or unhelpful (false), user ID is taken from the JWT token. one user only has one vote per review (voting again changes the vote) and can not vote their own review",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Vote a review as helpful or unhelpful",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to vote a review",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.reviewVoteInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ReviewVotes"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Remove the logged in user's helpful / unhelpful vote of a review",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Remove vote of a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ReviewVotes"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get a list of user's roles. only admin can access this route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get all  roles. (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Role"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Creating a new Role data, only admin can access this route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Create New Role (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to create a new Role",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.roleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Role"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get a role data by id. only admin can access this route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get role by ID. (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Role"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update Role data by id, only account with role admin can access this route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Update Role data. (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "tExample JSON body to update Role data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.roleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Role"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a Role by id, only admin can access this route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Delete Role by id . (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get all Users data by role id. only admin can access this route, if role has'nt have any user it will not display the user data",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get users data by Role id. (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Role"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/spec-attributes": {
+            "get": {
+                "description": "Get a list of admin-defined specification attributes, can be filtered by group",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spec Attributes"
+                ],
+                "summary": "Get all specification attribute definitions. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "attribute group",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SpecAttribute"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Register a new specification attribute (e.g. esim, satellite_sos), key must be lowercase snake_case, allowed_values is required for enum data type",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spec Attributes"
+                ],
+                "summary": "Create specification attribute definition (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to create a specification attribute",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.specAttributeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SpecAttribute"
+                        }
+                    }
+                }
+            }
+        },
+        "/spec-attributes/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update a specification attribute by id, key can not be changed and data_type can only be changed when no phone uses the attribute, allowed_values can not drop a value still used by a phone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spec Attributes"
+                ],
+                "summary": "Update specification attribute definition (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "attribute id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "example JSON body to update a specification attribute",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.specAttributeUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SpecAttribute"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a specification attribute by id, including its values on every phone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spec Attributes"
+                ],
+                "summary": "Delete specification attribute definition (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "attribute id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Get a list of phone tags with the number of phones using each tag",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get all phone tags. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search keyword",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.TagResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a tag by id and remove it from every phone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Delete phone tag (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Get a list of account with 'user' role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get all account with user role. (PUBLIC)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "update its own user data, user ID is taken from JWT Token so only acount's owner can update the user information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update User data.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "tExample JSON body to update User data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.userUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Will delete the user account itself, user ID is taken from JWT Token so only acount's owner can delete its own accout",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user's own account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to delete user's own account",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.deleteUserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/recommendations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get phones the user has not reviewed yet, recommended from the user's review ratings (item-item collaborative filtering, with spec-based fallback for users with few reviews). recommendations are recomputed periodically by a cron job (see /admin/recommendations/recompute), users without reviews get the top phones of the bayesian leaderboard (source: popular)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get phone recommendations for logged in user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of phones, default 10, max 20",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.RecommendationResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/role": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get role by user id (id is taken from JWT)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get role by User id. (ADMIN \u0026 USER)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Role"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Get user data by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get single user by ID. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a User by id, only admin can access this route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete User by id (ADMIN ONLY)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/profile": {
+            "get": {
+                "description": "Get all Users profile data by user id., if  user not create profile yet the profile will not be display",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get profiles data by User id. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/reviews": {
+            "get": {
+                "description": "Get all Users review data by user id if user not create review yet the review will not appear",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get reviews data by User id. (PUBLIC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "contentfilter.Match": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "contentfilter.Result": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contentfilter.Match"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "contentfilter.RuleSetting": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "default_action": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "maskable": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.AttributeCompareRow": {
+            "type": "object",
+            "properties": {
+                "data_type": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "controller.BrandMergeReport": {
+            "type": "object",
+            "properties": {
+                "phone_slugs_moved": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "phones_moved": {
+                    "type": "integer"
+                },
+                "redirects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reviews_moved": {
+                    "type": "integer"
+                },
+                "source_id": {
+                    "type": "integer"
+                },
+                "sub_brands_moved": {
+                    "type": "integer"
+                },
+                "target_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.BrandStats": {
+            "type": "object",
+            "properties": {
+                "avg_rating": {
+                    "type": "number"
+                },
+                "phone_count": {
+                    "type": "integer"
+                },
+                "review_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.BrandTreeNode": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "logo_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "stats": {
+                    "description": "statistik phone milik brand ini saja",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/controller.BrandStats"
+                        }
+                    ]
+                },
+                "sub_brands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.BrandTreeNode"
+                    }
+                },
+                "total_stats": {
+                    "description": "statistik brand ini ditambah seluruh sub brand di bawahnya",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/controller.BrandStats"
+                        }
+                    ]
+                }
+            }
+        },
+        "controller.CalendarBrand": {
+            "type": "object",
+            "properties": {
+                "brand_id": {
+                    "type": "integer"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "phones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.PhonesCompleteResponse"
+                    }
+                }
+            }
+        },
+        "controller.CalendarMonth": {
+            "type": "object",
+            "properties": {
+                "brands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.CalendarBrand"
+                    }
+                },
+                "month": {
+                    "description": "format YYYY-MM",
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.CalendarResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.CalendarMonth"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "unscheduled": {
+                    "description": "phone announced / rumored yg belum punya tanggal rilis",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.PhonesCompleteResponse"
+                    }
+                }
+            }
+        },
+        "controller.CatalogRow": {
+            "type": "object",
+            "properties": {
+                "brand_description": {
+                    "type": "string"
+                },
+                "brand_logo_url": {
+                    "type": "string"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "description": "YYYY-MM-DD atau RFC3339",
+                    "type": "string"
+                },
+                "specification": {
+                    "$ref": "#/definitions/controller.specificationInput"
+                }
+            }
+        },
+        "controller.ContentFilterConfig": {
+            "type": "object",
+            "properties": {
+                "custom_words": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContentFilterWord"
+                    }
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contentfilter.RuleSetting"
+                    }
+                }
+            }
+        },
+        "controller.ImportReport": {
+            "type": "object",
+            "properties": {
+                "brands_created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.ImportRowError"
+                    }
+                },
+                "phones_created": {
+                    "type": "integer"
+                },
+                "phones_updated": {
+                    "type": "integer"
+                },
+                "specs_created": {
+                    "type": "integer"
+                },
+                "specs_updated": {
+                    "type": "integer"
+                },
+                "total_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "avg_rating": {
+                    "description": "rata-rata rating mentah",
+                    "type": "number"
+                },
+                "brand_id": {
+                    "type": "integer"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "phone_id": {
+                    "type": "integer"
+                },
+                "phone_image": {
+                    "type": "string"
+                },
+                "phone_model": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "review_count": {
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "skor yg sudah disesuaikan dgn jumlah review (skala 1 - 5)",
+                    "type": "number"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.LeaderboardGroup": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.LeaderboardEntry"
+                    }
+                },
+                "key": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.LeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.LeaderboardEntry"
+                    }
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.LeaderboardGroup"
+                    }
+                },
+                "method": {
+                    "type": "string"
+                },
+                "prior_mean": {
+                    "description": "rata-rata \u0026 bobot prior yg dipakai metode bayesian",
+                    "type": "number"
+                },
+                "prior_weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.LocalPrice": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "converted": {
+                    "description": "true jika harga hasil konversi kurs (region belum punya harga sendiri / beda mata uang)",
+                    "type": "boolean"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "display": {
+                    "description": "nilai desimal dalam major unit, contoh \"1299.99\"",
+                    "type": "string"
+                },
+                "minor_unit": {
+                    "type": "integer"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.LoginInput": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.ModerationConfig": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "trusted_min_account_days": {
+                    "description": "umur akun minimal (hari) agar review langsung tampil pada mode trust",
+                    "type": "integer"
+                },
+                "trusted_min_approved": {
+                    "description": "jumlah review approved minimal agar review langsung tampil pada mode trust",
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.ModerationReviewsResponse": {
+            "type": "object",
+            "properties": {
+                "aspect_ratings": {
+                    "description": "rating per aspek (opsional)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReviewAspectRating"
+                    }
+                },
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Comment"
+                    }
+                },
+                "cons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "waktu edit konten / rating terakhir \u0026 jumlah versi lama di ReviewRevision",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "moderated_by": {
+                    "type": "integer"
+                },
+                "moderation_note": {
+                    "type": "string"
+                },
+                "moderation_reason": {
+                    "type": "string"
+                },
+                "phone_id": {
+                    "type": "integer"
+                },
+                "phone_model": {
+                    "type": "string"
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReviewPhoto"
+                    }
+                },
+                "pros": {
+                    "description": "kelebihan \u0026 kekurangan berurutan, diisi dari ProsCons untuk response",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "revision_count": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "hanya review approved yg tampil di publik \u0026 dihitung di rating",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.PhoneAttributeResponse": {
+            "type": "object",
+            "properties": {
+                "data_type": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "value": {}
+            }
+        },
+        "controller.PhoneCompareItem": {
+            "type": "object",
+            "properties": {
+                "aspect_ratings": {
+                    "description": "rata-rata rating per aspek (key aspek -\u003e rata-rata), hanya aspek yg sudah dirating",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "avg_rating": {
+                    "type": "number"
+                },
+                "brand_id": {
+                    "type": "integer"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "gallery": {
+                    "description": "hanya diisi di detail phone (GetPhoneById)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PhoneImage"
+                    }
+                },
+                "local_price": {
+                    "description": "hanya diisi jika query region / currency dikirim",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/controller.LocalPrice"
+                        }
+                    ]
+                },
+                "phone_id": {
+                    "type": "integer"
+                },
+                "phone_image": {
+                    "type": "string"
+                },
+                "phone_model": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "spec_score": {
+                    "type": "number"
+                },
+                "specification": {
+                    "$ref": "#/definitions/models.Specification"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "value_score": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.PhoneCompareResponse": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.AttributeCompareRow"
+                    }
+                },
+                "phones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.PhoneCompareItem"
+                    }
+                }
+            }
+        },
+        "controller.PhoneMergeReport": {
+            "type": "object",
+            "properties": {
+                "attributes_dropped": {
+                    "type": "integer"
+                },
+                "attributes_moved": {
+                    "type": "integer"
+                },
+                "categories_added": {
+                    "type": "integer"
+                },
+                "comments_dropped": {
+                    "type": "integer"
+                },
+                "comments_moved": {
+                    "type": "integer"
+                },
+                "images_moved": {
+                    "type": "integer"
+                },
+                "prices_dropped": {
+                    "type": "integer"
+                },
+                "prices_moved": {
+                    "type": "integer"
+                },
+                "redirects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "review_strategy": {
+                    "type": "string"
+                },
+                "reviews_dropped": {
+                    "description": "review yg kalah karena user yg sama sudah mereview phone lain",
+                    "type": "integer"
+                },
+                "reviews_moved": {
+                    "type": "integer"
+                },
+                "source_id": {
+                    "type": "integer"
+                },
+                "specifications_dropped": {
+                    "type": "integer"
+                },
+                "specifications_moved": {
+                    "type": "integer"
+                },
+                "tags_added": {
+                    "type": "integer"
+                },
+                "target_id": {
+                    "type": "integer"
+                },
+                "variants_merged": {
+                    "description": "variant source yg sama persis dgn variant target digabung ke variant target",
+                    "type": "integer"
+                },
+                "variants_moved": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.PhonePriceResponse": {
+            "type": "object",
+            "properties": {
+                "converted": {
+                    "$ref": "#/definitions/utils.Money"
+                },
+                "price": {
+                    "$ref": "#/definitions/utils.Money"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.PhonePricesResponse": {
+            "type": "object",
+            "properties": {
+                "base_price": {
+                    "$ref": "#/definitions/utils.Money"
+                },
+                "phone_id": {
+                    "type": "integer"
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.PhonePriceResponse"
+                    }
+                }
+            }
+        },
+        "controller.PhonesCompleteResponse": {
+            "type": "object",
+            "properties": {
+                "aspect_ratings": {
+                    "description": "rata-rata rating per aspek (key aspek -\u003e rata-rata), hanya aspek yg sudah dirating",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "avg_rating": {
+                    "type": "number"
+                },
+                "brand_id": {
+                    "type": "integer"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "gallery": {
+                    "description": "hanya diisi di detail phone (GetPhoneById)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PhoneImage"
+                    }
+                },
+                "local_price": {
+                    "description": "hanya diisi jika query region / currency dikirim",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/controller.LocalPrice"
+                        }
+                    ]
+                },
+                "phone_id": {
+                    "type": "integer"
+                },
+                "phone_image": {
+                    "type": "string"
+                },
+                "phone_model": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "spec_score": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "value_score": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.ProConMention": {
+            "type": "object",
+            "properties": {
+                "mentions": {
+                    "description": "jumlah review yg menyebut poin ini",
+                    "type": "integer"
+                },
+                "text": {
+                    "description": "teks yg paling sering dipakai dalam kelompok",
+                    "type": "string"
+                },
+                "variants": {
+                    "description": "teks lain yg dianggap sama",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "controller.ProsConsSummary": {
+            "type": "object",
+            "properties": {
+                "cons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.ProConMention"
+                    }
+                },
+                "phone_id": {
+                    "type": "integer"
+                },
+                "pros": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.ProConMention"
+                    }
+                },
+                "review_count": {
+                    "description": "jumlah review approved yg punya pros / cons",
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.RatingBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "rating": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.RatingStats": {
+            "type": "object",
+            "properties": {
+                "avg_rating": {
+                    "type": "number"
+                },
+                "histogram": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.RatingBucket"
+                    }
+                },
+                "median_rating": {
+                    "type": "number"
+                },
+                "phone_id": {
+                    "type": "integer"
+                },
+                "review_count": {
+                    "type": "integer"
+                },
+                "standard_deviation": {
+                    "type": "number"
+                },
+                "trend": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.RatingTrendPoint"
+                    }
+                }
+            }
+        },
+        "controller.RatingTrendPoint": {
+            "type": "object",
+            "properties": {
+                "avg_rating": {
+                    "type": "number"
+                },
+                "cumulative_avg_rating": {
+                    "description": "rata-rata semua review sampai akhir bulan ini",
+                    "type": "number"
+                },
+                "month": {
+                    "description": "bulan format YYYY-MM",
+                    "type": "string"
+                },
+                "review_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.RecommendationResponse": {
+            "type": "object",
+            "properties": {
+                "aspect_ratings": {
+                    "description": "rata-rata rating per aspek (key aspek -\u003e rata-rata), hanya aspek yg sudah dirating",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "avg_rating": {
+                    "type": "number"
+                },
+                "brand_id": {
+                    "type": "integer"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "computed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "gallery": {
+                    "description": "hanya diisi di detail phone (GetPhoneById)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PhoneImage"
+                    }
+                },
+                "local_price": {
+                    "description": "hanya diisi jika query region / currency dikirim",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/controller.LocalPrice"
+                        }
+                    ]
+                },
+                "phone_id": {
+                    "type": "integer"
+                },
+                "phone_image": {
+                    "type": "string"
+                },
+                "phone_model": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "collaborative / content / popular",
+                    "type": "string"
+                },
+                "spec_score": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "value_score": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.RegisterInput": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 5
+                },
+                "username": {
+                    "type": "string",
+                    "minLength": 5
+                }
+            }
+        },
+        "controller.ReviewVotes": {
+            "type": "object",
+            "properties": {
+                "helpful_count": {
+                    "type": "integer"
+                },
+                "helpful_score": {
+                    "type": "number"
+                },
+                "unhelpful_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.RevisionResponse": {
+            "type": "object",
+            "properties": {
+                "cons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "content": {
+                    "type": "string"
+                },
+                "edited_at": {
+                    "type": "string"
+                },
+                "editor_id": {
+                    "type": "integer"
+                },
+                "editor_username": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "pros": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rating": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.RevisionsResponse": {
+            "type": "object",
+            "properties": {
+                "cons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "content": {
+                    "type": "string"
+                },
+                "edited_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "pros": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "revision_count": {
+                    "type": "integer"
+                },
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.RevisionResponse"
+                    }
+                }
+            }
+        },
+        "controller.SimilarPhoneResponse": {
+            "type": "object",
+            "properties": {
+                "avg_rating": {
+                    "type": "number"
+                },
+                "battery_mah": {
+                    "type": "number"
+                },
+                "brand_id": {
+                    "type": "integer"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "camera_mp": {
+                    "type": "number"
+                },
+                "memory_gb": {
+                    "type": "number"
+                },
+                "operating_system": {
+                    "type": "string"
+                },
+                "phone_id": {
+                    "type": "integer"
+                },
+                "phone_image": {
+                    "type": "string"
+                },
+                "phone_model": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "score": {
+                    "description": "skor kemiripan 0 - 1, makin besar makin mirip",
+                    "type": "number"
+                },
+                "storage_gb": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.TagResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.brandInput": {
+            "type": "object",
+            "required": [
                 "logo_url",
                 "name"
             ],
@@ -2103,147 +6908,1221 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "parent_id": {
+                    "description": "id brand induk (opsional)",
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.brandMergeInput": {
+            "type": "object",
+            "required": [
+                "source_id",
+                "target_id"
+            ],
+            "properties": {
+                "source_id": {
+                    "type": "integer"
+                },
+                "target_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.brandUpdate": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "logo_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "0 untuk melepas brand dari induknya",
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.cameraModuleInput": {
+            "type": "object",
+            "required": [
+                "position",
+                "resolution_mp",
+                "role"
+            ],
+            "properties": {
+                "optical_zoom": {
+                    "type": "number"
+                },
+                "position": {
+                    "type": "string",
+                    "enum": [
+                        "rear",
+                        "front"
+                    ]
+                },
+                "resolution_mp": {
+                    "type": "number"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "main",
+                        "ultrawide",
+                        "telephoto",
+                        "macro",
+                        "depth"
+                    ]
+                }
+            }
+        },
+        "controller.categoryInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.categoryUpdate": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.changePasswordInput": {
+            "type": "object",
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.commentInput": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.contentFilterRuleInput": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "reject, mask atau moderate",
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "controller.contentFilterTestInput": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.contentFilterWordInput": {
+            "type": "object",
+            "required": [
+                "language",
+                "word"
+            ],
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "word": {
+                    "description": "akhiran * untuk mencocokkan awalan kata",
+                    "type": "string"
+                }
+            }
+        },
+        "controller.deleteUserInput": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.exchangeRateInput": {
+            "type": "object",
+            "required": [
+                "rate"
+            ],
+            "properties": {
+                "rate": {
+                    "description": "nilai 1 major unit currency dalam IDR, contoh USD 15750.5",
+                    "type": "number"
+                }
+            }
+        },
+        "controller.moderationInput": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "kode alasan, lihat /admin/moderation/config",
+                    "type": "string"
+                }
+            }
+        },
+        "controller.phoneAttributesInput": {
+            "type": "object",
+            "required": [
+                "attributes"
+            ],
+            "properties": {
+                "attributes": {
+                    "description": "key atribut -\u003e nilai, nilai null untuk menghapus atribut dari phone",
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "controller.phoneCategoriesInput": {
+            "type": "object",
+            "required": [
+                "categories"
+            ],
+            "properties": {
+                "categories": {
+                    "description": "slug kategori, menggantikan semua kategori phone sebelumnya",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "controller.phoneImageOrderInput": {
+            "type": "object",
+            "required": [
+                "image_ids"
+            ],
+            "properties": {
+                "image_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "controller.phoneImageUpdate": {
+            "type": "object",
+            "properties": {
+                "caption": {
+                    "type": "string"
+                },
+                "is_cover": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "front",
+                        "back",
+                        "color",
+                        "lifestyle",
+                        "other"
+                    ]
+                }
+            }
+        },
+        "controller.phoneInput": {
+            "type": "object",
+            "properties": {
+                "brand_id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "publish_at": {
+                    "description": "jika diisi waktu yg akan datang, phone disimpan sebagai draft dan\notomatis dipublish dgn status di atas saat publish_at tercapai",
+                    "type": "string"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "default released, phone draft hanya terlihat oleh admin",
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "rumored",
+                        "announced",
+                        "released",
+                        "discontinued"
+                    ]
+                }
+            }
+        },
+        "controller.phoneMergeInput": {
+            "type": "object",
+            "required": [
+                "source_id",
+                "target_id"
+            ],
+            "properties": {
+                "review_strategy": {
+                    "description": "keep_target (default) / keep_source / keep_newest / keep_highest",
+                    "type": "string"
+                },
+                "source_id": {
+                    "type": "integer"
+                },
+                "target_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.phonePriceInput": {
+            "type": "object"
+        },
+        "controller.phoneTagsInput": {
+            "type": "object",
+            "required": [
+                "tags"
+            ],
+            "properties": {
+                "tags": {
+                    "description": "nama tag, tag yg belum ada dibuat otomatis",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "controller.phoneUpdate": {
+            "type": "object",
+            "properties": {
+                "brand_id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "rumored",
+                        "announced",
+                        "released",
+                        "discontinued"
+                    ]
+                }
+            }
+        },
+        "controller.phoneVariantInput": {
+            "type": "object",
+            "required": [
+                "color",
+                "memory",
+                "price",
+                "storage"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "memory": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "storage": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.phoneVariantUpdate": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "memory": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "storage": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.profileInput": {
+            "type": "object",
+            "properties": {
+                "biodata": {
+                    "type": "string"
+                },
+                "birthday": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.ratingAspectInput": {
+            "type": "object",
+            "required": [
+                "key",
+                "label"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.ratingAspectUpdate": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.reviewInput": {
+            "type": "object",
+            "required": [
+                "content",
+                "rating"
+            ],
+            "properties": {
+                "aspects": {
+                    "description": "rating per aspek (opsional), key aspek -\u003e rating 1 - 5, contoh {\"camera\": 5}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "cons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "content": {
+                    "type": "string"
+                },
+                "pros": {
+                    "description": "kelebihan \u0026 kekurangan (opsional), maksimal 10 poin masing-masing",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.reviewUpdate": {
+            "type": "object",
+            "properties": {
+                "aspects": {
+                    "description": "hanya aspek yg dikirim yg diubah, rating 0 menghapus rating aspek tsb",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "cons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "content": {
+                    "type": "string"
+                },
+                "pros": {
+                    "description": "pros / cons yg dikirim menggantikan daftar lama, [] untuk mengosongkan",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.reviewVoteInput": {
+            "type": "object",
+            "required": [
+                "helpful"
+            ],
+            "properties": {
+                "helpful": {
+                    "description": "true = membantu, false = tidak membantu",
+                    "type": "boolean"
+                }
+            }
+        },
+        "controller.roleInput": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.specAttributeInput": {
+            "type": "object",
+            "required": [
+                "data_type",
+                "key",
+                "label"
+            ],
+            "properties": {
+                "allowed_values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "data_type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "number",
+                        "boolean",
+                        "enum"
+                    ]
+                },
+                "group": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.specAttributeUpdate": {
+            "type": "object",
+            "properties": {
+                "allowed_values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "data_type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "number",
+                        "boolean",
+                        "enum"
+                    ]
+                },
+                "group": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.specificationInput": {
+            "type": "object",
+            "required": [
+                "battery_mah",
+                "camera_modules",
+                "memory_gb",
+                "network",
+                "operating_system",
+                "storage_gb"
+            ],
+            "properties": {
+                "additional_feature": {
+                    "type": "string"
+                },
+                "battery_mah": {
+                    "type": "integer"
+                },
+                "bluetooth": {
+                    "type": "string"
+                },
+                "camera_modules": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/controller.cameraModuleInput"
+                    }
+                },
+                "charging_watt": {
+                    "type": "integer"
+                },
+                "chipset": {
+                    "type": "string"
+                },
+                "display_refresh_rate_hz": {
+                    "type": "integer"
+                },
+                "display_resolution_height_px": {
+                    "type": "integer"
+                },
+                "display_resolution_width_px": {
+                    "type": "integer"
+                },
+                "display_size_inch": {
+                    "type": "number"
+                },
+                "display_type": {
+                    "type": "string"
+                },
+                "height_mm": {
+                    "type": "number"
+                },
+                "ip_rating": {
+                    "type": "string"
+                },
+                "memory_gb": {
+                    "type": "integer"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "nfc": {
+                    "type": "boolean"
+                },
+                "operating_system": {
+                    "type": "string"
+                },
+                "storage_gb": {
+                    "type": "integer"
+                },
+                "thickness_mm": {
+                    "type": "number"
+                },
+                "usb": {
+                    "type": "string"
+                },
+                "weight_gram": {
+                    "type": "number"
+                },
+                "width_mm": {
+                    "type": "number"
+                },
+                "wifi": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.specificationUpdate": {
+            "type": "object",
+            "properties": {
+                "additional_feature": {
+                    "type": "string"
+                },
+                "battery_mah": {
+                    "type": "integer"
+                },
+                "bluetooth": {
+                    "type": "string"
+                },
+                "camera_modules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.cameraModuleInput"
+                    }
+                },
+                "charging_watt": {
+                    "type": "integer"
+                },
+                "chipset": {
+                    "type": "string"
+                },
+                "display_refresh_rate_hz": {
+                    "type": "integer"
+                },
+                "display_resolution_height_px": {
+                    "type": "integer"
+                },
+                "display_resolution_width_px": {
+                    "type": "integer"
+                },
+                "display_size_inch": {
+                    "type": "number"
+                },
+                "display_type": {
+                    "type": "string"
+                },
+                "height_mm": {
+                    "type": "number"
+                },
+                "ip_rating": {
+                    "type": "string"
+                },
+                "memory_gb": {
+                    "type": "integer"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "nfc": {
+                    "type": "boolean"
+                },
+                "operating_system": {
+                    "type": "string"
+                },
+                "storage_gb": {
+                    "type": "integer"
+                },
+                "thickness_mm": {
+                    "type": "number"
+                },
+                "usb": {
+                    "type": "string"
+                },
+                "weight_gram": {
+                    "type": "number"
+                },
+                "width_mm": {
+                    "type": "number"
+                },
+                "wifi": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.userUpdate": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Brand": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "logo_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "brand induk, contoh Redmi \u0026 POCO -\u003e Xiaomi",
+                    "type": "integer"
+                },
+                "phones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Phone"
+                    }
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "sub_brands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Brand"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "controller.changePasswordInput": {
+        "models.CameraModule": {
             "type": "object",
             "properties": {
-                "current_password": {
+                "created_at": {
                     "type": "string"
                 },
-                "new_password": {
+                "id": {
+                    "type": "integer"
+                },
+                "optical_zoom": {
+                    "type": "number"
+                },
+                "position": {
+                    "description": "rear / front",
+                    "type": "string"
+                },
+                "resolution_mp": {
+                    "type": "number"
+                },
+                "role": {
+                    "description": "main / ultrawide / telephoto / macro / depth",
+                    "type": "string"
+                },
+                "specification_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "controller.commentInput": {
+        "models.Category": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Comment": {
             "type": "object",
-            "required": [
-                "content"
-            ],
             "properties": {
                 "content": {
                     "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "waktu edit terakhir \u0026 jumlah versi lama di CommentRevision",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "moderated_by": {
+                    "type": "integer"
+                },
+                "moderation_note": {
+                    "type": "string"
+                },
+                "moderation_reason": {
+                    "type": "string"
+                },
+                "review_id": {
+                    "type": "integer"
+                },
+                "revision_count": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "komentar yg ditahan filter konten berstatus pending sampai disetujui admin",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "controller.deleteUserInput": {
+        "models.ContentFilterWord": {
             "type": "object",
             "properties": {
-                "password": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "word": {
                     "type": "string"
                 }
             }
         },
-        "controller.phoneInput": {
+        "models.ExchangeRate": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Phone": {
             "type": "object",
             "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SpecAttributeValue"
+                    }
+                },
                 "brand_id": {
                     "type": "integer"
                 },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
                 "image_url": {
                     "type": "string"
                 },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PhoneImage"
+                    }
+                },
                 "model": {
                     "type": "string"
                 },
                 "price": {
+                    "description": "harga dasar dalam IDR, harga per region di PhonePrice",
                     "type": "integer"
                 },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PhonePrice"
+                    }
+                },
+                "publish_at": {
+                    "description": "jadwal publish phone draft",
+                    "type": "string"
+                },
                 "release_date": {
                     "type": "string"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Review"
+                    }
+                },
+                "scheduled_status": {
+                    "description": "status phone saat publish_at tercapai",
+                    "type": "string"
+                },
+                "slug": {
+                    "description": "contoh samsung-galaxy-s24-ultra",
+                    "type": "string"
+                },
+                "spec_score": {
+                    "description": "skor specification 0 - 100, dihitung package valuescore",
+                    "type": "number"
+                },
+                "specification": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Specification"
+                    }
+                },
+                "status": {
+                    "description": "draft / rumored / announced / released / discontinued",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "value_score": {
+                    "description": "spec_score per 1 juta rupiah",
+                    "type": "number"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PhoneVariant"
+                    }
                 }
             }
         },
-        "controller.profileInput": {
+        "models.PhoneImage": {
             "type": "object",
             "properties": {
-                "biodata": {
+                "caption": {
                     "type": "string"
                 },
-                "birthday": {
+                "created_at": {
                     "type": "string"
                 },
-                "full_name": {
+                "id": {
+                    "type": "integer"
+                },
+                "is_cover": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "description": "front / back / color / lifestyle / other",
                     "type": "string"
                 },
-                "image_url": {
+                "phone_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "thumbnails": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "controller.reviewInput": {
+        "models.PhonePrice": {
             "type": "object",
-            "required": [
-                "content",
-                "rating"
-            ],
             "properties": {
-                "content": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "rating": {
-                    "type": "integer",
-                    "maximum": 5,
-                    "minimum": 1
+                "currency": {
+                    "description": "ISO 4217, contoh IDR / USD",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "phone_id": {
+                    "type": "integer"
+                },
+                "region": {
+                    "description": "ISO 3166-1 alpha-2, contoh ID / MY / SG / US",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "controller.roleInput": {
+        "models.PhoneVariant": {
             "type": "object",
             "properties": {
-                "name": {
+                "color": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "memory": {
+                    "type": "integer"
+                },
+                "phone_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "storage": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "controller.specificationInput": {
+        "models.Profile": {
             "type": "object",
-            "required": [
-                "battery",
-                "camera",
-                "memory",
-                "network",
-                "operating_system",
-                "storage"
-            ],
             "properties": {
-                "additional_feature": {
+                "biodata": {
+                    "type": "string"
+                },
+                "birthday": {
                     "type": "string"
                 },
-                "battery": {
+                "created_at": {
                     "type": "string"
                 },
-                "camera": {
-                    "type": "integer"
+                "full_name": {
+                    "type": "string"
                 },
-                "memory": {
+                "id": {
                     "type": "integer"
                 },
-                "network": {
+                "image_url": {
                     "type": "string"
                 },
-                "operating_system": {
+                "updated_at": {
                     "type": "string"
                 },
-                "storage": {
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "controller.userUpdate": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.Brand": {
+        "models.RatingAspect": {
             "type": "object",
             "properties": {
                 "created_at": {
@@ -2255,38 +8134,93 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "logo_url": {
+                "key": {
                     "type": "string"
                 },
-                "name": {
+                "label": {
                     "type": "string"
                 },
-                "phones": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Phone"
-                    }
+                "position": {
+                    "type": "integer"
                 },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "models.Comment": {
+        "models.Review": {
             "type": "object",
             "properties": {
+                "aspect_ratings": {
+                    "description": "rating per aspek (opsional)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReviewAspectRating"
+                    }
+                },
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Comment"
+                    }
+                },
+                "cons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "content": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
+                "edited_at": {
+                    "description": "waktu edit konten / rating terakhir \u0026 jumlah versi lama di ReviewRevision",
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "review_id": {
+                "moderated_at": {
+                    "type": "string"
+                },
+                "moderated_by": {
+                    "type": "integer"
+                },
+                "moderation_note": {
+                    "type": "string"
+                },
+                "moderation_reason": {
+                    "type": "string"
+                },
+                "phone_id": {
+                    "type": "integer"
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReviewPhoto"
+                    }
+                },
+                "pros": {
+                    "description": "kelebihan \u0026 kekurangan berurutan, diisi dari ProsCons untuk response",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "revision_count": {
                     "type": "integer"
                 },
+                "status": {
+                    "description": "hanya review approved yg tampil di publik \u0026 dihitung di rating",
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -2295,13 +8229,19 @@ const docTemplate = `{
                 },
                 "user_id": {
                     "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Phone": {
+        "models.ReviewAspectRating": {
             "type": "object",
             "properties": {
-                "brand_id": {
+                "aspect": {
+                    "$ref": "#/definitions/models.RatingAspect"
+                },
+                "aspect_id": {
                     "type": "integer"
                 },
                 "created_at": {
@@ -2310,119 +8250,133 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "image_url": {
-                    "type": "string"
+                "rating": {
+                    "type": "integer"
                 },
-                "model": {
+                "review_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReviewPhoto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "price": {
+                "id": {
                     "type": "integer"
                 },
-                "release_date": {
-                    "type": "string"
+                "position": {
+                    "type": "integer"
                 },
-                "reviews": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Review"
-                    }
+                "review_id": {
+                    "type": "integer"
                 },
-                "specification": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Specification"
+                "thumbnails": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     }
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "models.Profile": {
+        "models.Role": {
             "type": "object",
             "properties": {
-                "biodata": {
-                    "type": "string"
-                },
-                "birthday": {
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "full_name": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "image_url": {
+                "name": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
                 }
             }
         },
-        "models.Review": {
+        "models.SpecAttribute": {
             "type": "object",
             "properties": {
-                "comments": {
+                "allowed_values": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Comment"
+                        "type": "string"
                     }
                 },
-                "content": {
+                "created_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "data_type": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "group": {
+                    "type": "string"
                 },
-                "phone_id": {
+                "id": {
                     "type": "integer"
                 },
-                "rating": {
-                    "type": "integer"
+                "key": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "label": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/models.User"
+                "unit": {
+                    "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "models.Role": {
+        "models.SpecAttributeValue": {
             "type": "object",
             "properties": {
+                "attribute": {
+                    "$ref": "#/definitions/models.SpecAttribute"
+                },
+                "attribute_id": {
+                    "type": "integer"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "phone_id": {
+                    "type": "integer"
                 },
                 "updated_at": {
                     "type": "string"
                 },
-                "users": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.User"
-                    }
+                "value_bool": {
+                    "type": "boolean"
+                },
+                "value_number": {
+                    "type": "number"
+                },
+                "value_string": {
+                    "type": "string"
                 }
             }
         },
@@ -2432,33 +8386,106 @@ const docTemplate = `{
                 "additional_feature": {
                     "type": "string"
                 },
-                "battery": {
+                "battery_mah": {
+                    "description": "battery, battery_mah null jika tidak diketahui (data lama yg tidak bisa di-parse)",
+                    "type": "integer"
+                },
+                "bluetooth": {
                     "type": "string"
                 },
-                "camera": {
+                "camera_modules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CameraModule"
+                    }
+                },
+                "charging_watt": {
                     "type": "integer"
                 },
+                "chipset": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "display_refresh_rate_hz": {
+                    "type": "integer"
+                },
+                "display_resolution_height_px": {
+                    "type": "integer"
+                },
+                "display_resolution_width_px": {
+                    "type": "integer"
+                },
+                "display_size_inch": {
+                    "description": "display",
+                    "type": "number"
+                },
+                "display_type": {
+                    "type": "string"
+                },
+                "height_mm": {
+                    "description": "body",
+                    "type": "number"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "memory": {
+                "ip_rating": {
+                    "type": "string"
+                },
+                "memory_gb": {
                     "type": "integer"
                 },
                 "network": {
+                    "description": "connectivity, network berisi generasi jaringan tertinggi (2G/3G/4G/5G)",
                     "type": "string"
                 },
+                "nfc": {
+                    "type": "boolean"
+                },
                 "operating_system": {
+                    "description": "platform",
                     "type": "string"
                 },
                 "phone_id": {
                     "type": "integer"
                 },
-                "storage": {
+                "storage_gb": {
+                    "type": "integer"
+                },
+                "thickness_mm": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "usb": {
+                    "type": "string"
+                },
+                "weight_gram": {
+                    "type": "number"
+                },
+                "width_mm": {
+                    "type": "number"
+                },
+                "wifi": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Tag": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
+                "name": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 }
@@ -2495,6 +8522,64 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "storage.UploadedImage": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "thumbnails": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "utils.Money": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "display": {
+                    "description": "nilai desimal dalam major unit, contoh \"1299.99\"",
+                    "type": "string"
+                },
+                "minor_unit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "valuescore.Config": {
+            "type": "object",
+            "properties": {
+                "benchmark_attribute": {
+                    "type": "string"
+                },
+                "weights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
         }
     }
 }`
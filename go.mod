@@ -7,6 +7,7 @@ require github.com/gin-gonic/gin v1.10.0
 require (
 	github.com/gin-contrib/cors v1.7.2
 	github.com/golang-jwt/jwt v3.2.2+incompatible
+	golang.org/x/image v0.18.0
 	gorm.io/driver/mysql v1.5.7
 )
 
@@ -1,11 +1,15 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"final-project/lib"
 	"final-project/models"
+	"final-project/slug"
 	"final-project/utils"
 	"final-project/utils/token"
 
@@ -91,3 +95,80 @@ func RoleMiddleware(db *gorm.DB) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// SlugMiddleware terima slug di param :id route baca, slug diganti dgn id
+// data. slug lama & id data yg sudah digabung di-redirect permanen (301) ke url
+// dgn slug terbaru
+func SlugMiddleware(db *gorm.DB, kind string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value := c.Param("id")
+		if value == "" {
+			c.Next()
+			return
+		}
+
+		var id uint
+		var current string
+		var err error
+		if _, convErr := strconv.Atoi(value); convErr == nil {
+			// id data yg sudah digabung ke data lain di-redirect ke data tujuan
+			id, current, err = slug.ResolveMerged(db, kind, value)
+			if err != nil {
+				c.Next()
+				return
+			}
+		} else {
+			id, current, err = slug.Resolve(db, kind, value)
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound,
+				utils.ResponseJSON(lib.ErrMsgNotFound(kind), http.StatusNotFound, nil))
+			return
+		}
+
+		if current != value {
+			c.Redirect(http.StatusMovedPermanently, slugRedirectLocation(c.Request.URL, value, current))
+			c.Abort()
+			return
+		}
+
+		for i := range c.Params {
+			if c.Params[i].Key == "id" {
+				c.Params[i].Value = strconv.Itoa(int(id))
+			}
+		}
+		c.Next()
+	}
+}
+
+// slugRedirectLocation url request dgn segmen path value pertama diganti slug
+// terbaru, query string tetap dibawa
+func slugRedirectLocation(u *url.URL, value, current string) string {
+	segments := strings.Split(u.Path, "/")
+	for i := range segments {
+		if segments[i] == value {
+			segments[i] = current
+			break
+		}
+	}
+	location := strings.Join(segments, "/")
+	if u.RawQuery != "" {
+		location += "?" + u.RawQuery
+	}
+	return location
+}
+
+// CronMiddleware route yg dipanggil cron job (contoh Vercel Cron), request
+// harus membawa header "Authorization: Bearer <CRON_SECRET>". route ditutup
+// jika CRON_SECRET kosong
+func CronMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := utils.GetEnv("CRON_SECRET", "")
+		if secret == "" || subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), []byte("Bearer "+secret)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized,
+				utils.ResponseJSON("cron secret tidak valid", http.StatusUnauthorized, nil))
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestSlugRedirectLocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		value   string
+		current string
+		want    string
+	}{
+		{"slug lama", "/api/phones/galaxy-s23", "galaxy-s23", "galaxy-s23-fe", "/api/phones/galaxy-s23-fe"},
+		{"id digabung", "/api/phones/12/reviews", "12", "pixel-8", "/api/phones/pixel-8/reviews"},
+		{"query tetap dibawa", "/api/brands/samsung-old/phones?page=2&limit=5", "samsung-old", "samsung", "/api/brands/samsung/phones?page=2&limit=5"},
+		{"hanya segmen pertama", "/api/phones/12/compare/12", "12", "pixel-8", "/api/phones/pixel-8/compare/12"},
+		{"bukan bagian segmen", "/api/phones/pixel-80/pixel-8", "pixel-8", "pixel-8a", "/api/phones/pixel-80/pixel-8a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := slugRedirectLocation(u, tt.value, tt.current); got != tt.want {
+				t.Errorf("slugRedirectLocation() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,8 +8,11 @@ type Brand struct {
 	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
 	LogoURL     string    `gorm:"not null" json:"logo_url"`
 	Name        string    `gorm:"unique;not null" json:"name"`
+	Slug        string    `gorm:"size:191;uniqueIndex" json:"slug"`
 	Description string    `json:"description"`
+	ParentID    *uint     `gorm:"index" json:"parent_id"` // brand induk, contoh Redmi & POCO -> Xiaomi
 	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 	Phones      []Phone   `gorm:"foreignKey:BrandID;" json:"phones,omitempty"`
+	SubBrands   []Brand   `gorm:"foreignKey:ParentID;constraint:onDelete:SET NULL" json:"sub_brands,omitempty"`
 }
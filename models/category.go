@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+// Category kategori phone yg dikelola admin (flagship, mid-range, gaming, dst)
+type Category struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Slug        string    `gorm:"unique;not null" json:"slug"`
+	Name        string    `gorm:"not null" json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// Tag label bebas untuk phone, dibuat otomatis saat di-assign ke phone
+type Tag struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string    `gorm:"unique;not null" json:"name"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
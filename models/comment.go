@@ -11,5 +11,17 @@ type Comment struct {
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 	UserID    uint      `gorm:"not null" json:"user_id"`
 	ReviewID  uint      `gorm:"not null" json:"review_id"`
-	User      User      `json:"user"`
+	// komentar yg ditahan filter konten berstatus pending sampai disetujui admin
+	Status           string     `gorm:"size:20;not null;default:'approved';index" json:"status"`
+	ModerationReason string     `gorm:"size:30" json:"moderation_reason,omitempty"`
+	ModerationNote   string     `json:"moderation_note,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+	ModeratedBy      *uint      `json:"moderated_by,omitempty"`
+	// hash konten yg dinormalisasi untuk deteksi konten duplikat
+	ContentHash string `gorm:"size:64;index" json:"-"`
+	// waktu edit terakhir & jumlah versi lama di CommentRevision
+	EditedAt      *time.Time        `json:"edited_at"`
+	RevisionCount uint              `gorm:"not null;default:0" json:"revision_count"`
+	Revisions     []CommentRevision `gorm:"foreignKey:CommentID;constraint:onDelete:CASCADE" json:"-"`
+	User          User              `json:"user"`
 }
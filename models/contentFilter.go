@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// ContentFilterRule pengaturan rule filter konten yg diubah admin saat runtime,
+// rule tanpa baris di tabel ini memakai pengaturan default
+type ContentFilterRule struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Key       string    `gorm:"column:rule_key;size:50;unique;not null" json:"key"`
+	Enabled   bool      `gorm:"not null" json:"enabled"`
+	Action    string    `gorm:"size:20;not null" json:"action"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// ContentFilterWord kata kasar tambahan dari admin, akhiran * untuk mencocokkan
+// awalan kata (contoh fuck* juga cocok dgn fucking)
+type ContentFilterWord struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Word      string    `gorm:"size:100;uniqueIndex:idx_filter_word;not null" json:"word"`
+	Language  string    `gorm:"size:5;uniqueIndex:idx_filter_word;not null" json:"language"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
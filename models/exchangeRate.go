@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// ExchangeRate kurs mata uang terhadap mata uang dasar (utils.BaseCurrency),
+// Rate = nilai 1 major unit Currency dalam mata uang dasar
+type ExchangeRate struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Currency  string    `gorm:"size:3;not null;uniqueIndex" json:"currency"`
+	Rate      float64   `gorm:"type:decimal(24,8);not null" json:"rate"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
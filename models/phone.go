@@ -4,17 +4,43 @@ import (
 	"time"
 )
 
+const (
+	PhoneStatusDraft        = "draft"
+	PhoneStatusRumored      = "rumored"
+	PhoneStatusAnnounced    = "announced"
+	PhoneStatusReleased     = "released"
+	PhoneStatusDiscontinued = "discontinued"
+)
+
+// PhoneStatuses semua status phone yg valid
+var PhoneStatuses = []string{
+	PhoneStatusDraft, PhoneStatusRumored, PhoneStatusAnnounced, PhoneStatusReleased, PhoneStatusDiscontinued,
+}
+
 type Phone struct {
-	ID             uint            `gorm:"primaryKey;autoIncrement" json:"id"`
-	ImageURL       string          `gorm:"not null" json:"image_url"`
-	Model          string          `gorm:"not null" json:"model"`
-	Price          uint            `gorm:"not null" json:"price"`
-	ReleaseDate    time.Time       `gorm:"not null" json:"release_date"`
-	CreatedAt      time.Time       `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt      time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
-	BrandID        uint            `gorm:"not null" json:"brand_id"`
-	Reviews        []Review        `gorm:"foreignKey:PhoneID;constraint:onDelete:CASCADE" json:"reviews,omitempty"`
-	Specifications []Specification `gorm:"foreignKey:PhoneID;constraint:onDelete:CASCADE" json:"specification,omitempty"`
+	ID              uint                 `gorm:"primaryKey;autoIncrement" json:"id"`
+	ImageURL        string               `gorm:"not null" json:"image_url"`
+	Model           string               `gorm:"not null" json:"model"`
+	Slug            string               `gorm:"size:191;uniqueIndex" json:"slug"` // contoh samsung-galaxy-s24-ultra
+	Price           uint                 `gorm:"not null" json:"price"`            // harga dasar dalam IDR, harga per region di PhonePrice
+	ReleaseDate     time.Time            `gorm:"not null" json:"release_date"`
+	CreatedAt       time.Time            `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time            `gorm:"autoUpdateTime" json:"updated_at"`
+	BrandID         uint                 `gorm:"not null" json:"brand_id"`
+	Status          string               `gorm:"not null;default:'released';index" json:"status"` // draft / rumored / announced / released / discontinued
+	PublishAt       *time.Time           `json:"publish_at"`                                      // jadwal publish phone draft
+	ScheduledStatus string               `json:"scheduled_status,omitempty"`                      // status phone saat publish_at tercapai
+	SpecScore       *float64             `json:"spec_score"`                                      // skor specification 0 - 100, dihitung package valuescore
+	ValueScore      *float64             `gorm:"index" json:"value_score"`                        // spec_score per 1 juta rupiah
+	Reviews         []Review             `gorm:"foreignKey:PhoneID;constraint:onDelete:CASCADE" json:"reviews,omitempty"`
+	Specifications  []Specification      `gorm:"foreignKey:PhoneID;constraint:onDelete:CASCADE" json:"specification,omitempty"`
+	Variants        []PhoneVariant       `gorm:"foreignKey:PhoneID;constraint:onDelete:CASCADE" json:"variants,omitempty"`
+	Attributes      []SpecAttributeValue `gorm:"foreignKey:PhoneID;constraint:onDelete:CASCADE" json:"attributes,omitempty"`
+	Images          []PhoneImage         `gorm:"foreignKey:PhoneID;constraint:onDelete:CASCADE" json:"images,omitempty"`
+	Prices          []PhonePrice         `gorm:"foreignKey:PhoneID;constraint:onDelete:CASCADE" json:"prices,omitempty"`
+	Categories      []Category           `gorm:"many2many:phone_categories;constraint:onDelete:CASCADE" json:"categories,omitempty"`
+	Tags            []Tag                `gorm:"many2many:phone_tags;constraint:onDelete:CASCADE" json:"tags,omitempty"`
+	Feature         *PhoneFeature        `gorm:"foreignKey:PhoneID;constraint:onDelete:CASCADE" json:"-"`
 }
 
 type PhoneWithBrand struct {
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// PhoneFeature fitur phone untuk menghitung kemiripan, nilai *_norm sudah
+// dinormalisasi (0 - 1, skala log) sehingga bisa langsung dibandingkan.
+// diperbarui package similarity saat specification / harga phone berubah
+type PhoneFeature struct {
+	PhoneID         uint     `gorm:"primaryKey;autoIncrement:false" json:"phone_id"`
+	Price           uint     `gorm:"not null;index" json:"price"`
+	MemoryGB        float64  `gorm:"column:memory_gb" json:"memory_gb"`
+	StorageGB       float64  `gorm:"column:storage_gb" json:"storage_gb"`
+	CameraMP        float64  `gorm:"column:camera_mp" json:"camera_mp"`
+	BatteryMah      *float64 `json:"battery_mah"`
+	OperatingSystem string   `json:"operating_system"`
+	// os tanpa versi (android, ios, ...), kosong jika tidak ada specification
+	OSFamily string `gorm:"column:os_family;size:50" json:"os_family"`
+	HasSpec  bool   `gorm:"not null" json:"has_spec"`
+	// null jika nilai tidak diketahui
+	PriceNorm   *float64  `json:"price_norm"`
+	MemoryNorm  *float64  `json:"memory_norm"`
+	StorageNorm *float64  `json:"storage_norm"`
+	CameraNorm  *float64  `json:"camera_norm"`
+	BatteryNorm *float64  `json:"battery_norm"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
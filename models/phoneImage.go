@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+type PhoneImage struct {
+	ID         uint              `gorm:"primaryKey;autoIncrement" json:"id"`
+	URL        string            `gorm:"not null" json:"url"`
+	Thumbnails map[string]string `gorm:"serializer:json" json:"thumbnails,omitempty"`
+	Caption    string            `json:"caption"`
+	Kind       string            `gorm:"not null;default:'other'" json:"kind"` // front / back / color / lifestyle / other
+	Position   uint              `gorm:"not null" json:"position"`
+	IsCover    bool              `gorm:"not null;default:false" json:"is_cover"`
+	CreatedAt  time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+	PhoneID    uint              `gorm:"not null" json:"phone_id"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// PhonePrice harga phone di satu region, Amount dalam minor unit mata uang
+// (contoh sen untuk USD)
+type PhonePrice struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Region    string    `gorm:"size:2;not null;uniqueIndex:idx_phone_price_region" json:"region"` // ISO 3166-1 alpha-2, contoh ID / MY / SG / US
+	Currency  string    `gorm:"size:3;not null" json:"currency"`                                  // ISO 4217, contoh IDR / USD
+	Amount    int64     `gorm:"not null" json:"amount"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	PhoneID   uint      `gorm:"not null;uniqueIndex:idx_phone_price_region" json:"phone_id"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+type PhoneVariant struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Memory      uint      `gorm:"not null" json:"memory"`
+	Storage     uint      `gorm:"not null" json:"storage"`
+	Color       string    `gorm:"not null" json:"color"`
+	Price       uint      `gorm:"not null" json:"price"`
+	IsAvailable bool      `gorm:"not null;default:true" json:"is_available"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	PhoneID     uint      `gorm:"not null" json:"phone_id"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// RatingAspect aspek penilaian review yg dikelola admin (camera, battery, dst)
+type RatingAspect struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Key         string    `gorm:"column:aspect_key;size:50;unique;not null" json:"key"`
+	Label       string    `gorm:"not null" json:"label"`
+	Description string    `json:"description"`
+	Position    uint      `gorm:"not null;default:0" json:"position"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// ReviewAspectRating rating 1 - 5 satu aspek dalam review (opsional)
+type ReviewAspectRating struct {
+	ID        uint         `gorm:"primaryKey;autoIncrement" json:"id"`
+	Rating    uint         `gorm:"not null" json:"rating"`
+	CreatedAt time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+	ReviewID  uint         `gorm:"not null;uniqueIndex:idx_review_aspect" json:"review_id"`
+	AspectID  uint         `gorm:"not null;uniqueIndex:idx_review_aspect" json:"aspect_id"`
+	Aspect    RatingAspect `gorm:"foreignKey:AspectID;constraint:onDelete:CASCADE" json:"aspect"`
+}
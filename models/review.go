@@ -4,6 +4,23 @@ import (
 	"time"
 )
 
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+	ReviewStatusHidden   = "hidden"
+)
+
+// ReviewStatuses semua status review yg valid
+var ReviewStatuses = []string{
+	ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected, ReviewStatusHidden,
+}
+
+// ModerationReasons kode alasan review ditolak / disembunyikan
+var ModerationReasons = []string{
+	"spam", "abusive", "off_topic", "personal_info", "fake", "duplicate", "other",
+}
+
 type Review struct {
 	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
 	Rating    uint      `gorm:"not null" json:"rating"`
@@ -11,7 +28,28 @@ type Review struct {
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 	UserID    uint      `gorm:"not null" json:"user_id"`
-	PhoneID   uint      `gorm:"not null" json:"phone_id"`
-	Comments  []Comment `gorm:"foreignKey:ReviewID;constraint:onDelete:CASCADE" json:"comments,omitempty"`
-	User      User      `json:"user"`
+	PhoneID   uint      `gorm:"not null;index" json:"phone_id"`
+	VariantID *uint     `json:"variant_id"`
+	// hanya review approved yg tampil di publik & dihitung di rating
+	Status           string     `gorm:"size:20;not null;default:'approved';index" json:"status"`
+	ModerationReason string     `gorm:"size:30" json:"moderation_reason,omitempty"`
+	ModerationNote   string     `json:"moderation_note,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+	ModeratedBy      *uint      `json:"moderated_by,omitempty"`
+	// hash konten yg dinormalisasi untuk deteksi konten duplikat
+	ContentHash string `gorm:"size:64;index" json:"-"`
+	// waktu edit konten / rating terakhir & jumlah versi lama di ReviewRevision
+	EditedAt      *time.Time `json:"edited_at"`
+	RevisionCount uint       `gorm:"not null;default:0" json:"revision_count"`
+	Comments      []Comment  `gorm:"foreignKey:ReviewID;constraint:onDelete:CASCADE" json:"comments,omitempty"`
+	User          User       `json:"user"`
+	// rating per aspek (opsional)
+	AspectRatings []ReviewAspectRating `gorm:"foreignKey:ReviewID;constraint:onDelete:CASCADE" json:"aspect_ratings,omitempty"`
+	Votes         []ReviewVote         `gorm:"foreignKey:ReviewID;constraint:onDelete:CASCADE" json:"-"`
+	Photos        []ReviewPhoto        `gorm:"foreignKey:ReviewID;constraint:onDelete:CASCADE" json:"photos,omitempty"`
+	Revisions     []ReviewRevision     `gorm:"foreignKey:ReviewID;constraint:onDelete:CASCADE" json:"-"`
+	ProsCons      []ReviewProCon       `gorm:"foreignKey:ReviewID;constraint:onDelete:CASCADE" json:"-"`
+	// kelebihan & kekurangan berurutan, diisi dari ProsCons untuk response
+	Pros []string `gorm:"-" json:"pros,omitempty"`
+	Cons []string `gorm:"-" json:"cons,omitempty"`
 }
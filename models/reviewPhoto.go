@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// ReviewPhoto foto yg dilampirkan reviewer pada review
+type ReviewPhoto struct {
+	ID         uint              `gorm:"primaryKey;autoIncrement" json:"id"`
+	URL        string            `gorm:"not null" json:"url"`
+	Thumbnails map[string]string `gorm:"serializer:json" json:"thumbnails,omitempty"`
+	Position   uint              `gorm:"not null" json:"position"`
+	CreatedAt  time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+	ReviewID   uint              `gorm:"not null;index" json:"review_id"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ReviewProConPro = "pro"
+	ReviewProConCon = "con"
+)
+
+// ReviewProCon satu poin kelebihan / kekurangan dalam review, urut sesuai position
+type ReviewProCon struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReviewID  uint      `gorm:"not null;index" json:"review_id"`
+	Kind      string    `gorm:"size:3;not null" json:"kind"`
+	Text      string    `gorm:"not null" json:"text"`
+	Position  uint      `gorm:"not null;default:0" json:"position"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
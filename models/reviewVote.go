@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// ReviewVote penilaian user terhadap review (membantu / tidak membantu),
+// satu user hanya bisa memberi satu vote per review
+type ReviewVote struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Helpful   bool      `gorm:"not null" json:"helpful"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ReviewID  uint      `gorm:"not null;uniqueIndex:idx_review_vote_user" json:"review_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_review_vote_user" json:"user_id"`
+}
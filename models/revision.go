@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// ReviewRevision versi review sebelum diedit
+type ReviewRevision struct {
+	ID       uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReviewID uint   `gorm:"not null;index" json:"review_id"`
+	Rating   uint   `gorm:"not null" json:"rating"`
+	Content  string `gorm:"not null" json:"content"`
+	// pros & cons sebelum diedit, null untuk revisi lama yg belum menyimpannya
+	Pros []string `gorm:"serializer:json" json:"pros"`
+	Cons []string `gorm:"serializer:json" json:"cons"`
+	// user yg mengedit & waktu edit yg menggantikan versi ini
+	EditorID  uint      `gorm:"not null" json:"editor_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"edited_at"`
+}
+
+// CommentRevision versi komentar sebelum diedit
+type CommentRevision struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CommentID uint      `gorm:"not null;index" json:"comment_id"`
+	Content   string    `gorm:"not null" json:"content"`
+	EditorID  uint      `gorm:"not null" json:"editor_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"edited_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// SlugHistory slug lama phone / brand yg sudah diganti, dipakai untuk
+// redirect url lama ke slug terbaru
+type SlugHistory struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Kind      string    `gorm:"size:20;not null;uniqueIndex:idx_slug_history_kind_slug" json:"kind"` // phone / brand
+	Slug      string    `gorm:"size:191;not null;uniqueIndex:idx_slug_history_kind_slug" json:"slug"`
+	TargetID  uint      `gorm:"not null;index" json:"target_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
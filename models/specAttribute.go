@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeNumber  = "number"
+	AttributeTypeBoolean = "boolean"
+	AttributeTypeEnum    = "enum"
+)
+
+// SpecAttribute definisi atribut specification tambahan yg dikelola admin
+type SpecAttribute struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Key           string    `gorm:"column:attribute_key;unique;not null" json:"key"`
+	Label         string    `gorm:"not null" json:"label"`
+	DataType      string    `gorm:"not null" json:"data_type"`
+	Unit          string    `json:"unit"`
+	AllowedValues []string  `gorm:"serializer:json" json:"allowed_values"`
+	Group         string    `gorm:"column:attribute_group" json:"group"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// SpecAttributeValue nilai atribut per phone, hanya satu kolom value yg terisi
+// sesuai data type atributnya
+type SpecAttributeValue struct {
+	ID          uint          `gorm:"primaryKey;autoIncrement" json:"id"`
+	ValueString string        `json:"value_string,omitempty"`
+	ValueNumber *float64      `json:"value_number,omitempty"`
+	ValueBool   *bool         `json:"value_bool,omitempty"`
+	CreatedAt   time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
+	PhoneID     uint          `gorm:"not null;uniqueIndex:idx_phone_attribute" json:"phone_id"`
+	AttributeID uint          `gorm:"not null;uniqueIndex:idx_phone_attribute" json:"attribute_id"`
+	Attribute   SpecAttribute `gorm:"foreignKey:AttributeID;constraint:onDelete:CASCADE" json:"attribute"`
+}
+
+// TypedValue nilai atribut sesuai data type-nya
+func (v SpecAttributeValue) TypedValue() any {
+	switch v.Attribute.DataType {
+	case AttributeTypeNumber:
+		if v.ValueNumber != nil {
+			return *v.ValueNumber
+		}
+		return nil
+	case AttributeTypeBoolean:
+		if v.ValueBool != nil {
+			return *v.ValueBool
+		}
+		return nil
+	default:
+		return v.ValueString
+	}
+}
@@ -5,15 +5,48 @@ import (
 )
 
 type Specification struct {
-	ID                uint      `gorm:"primaryKey;autoIncrement" json:"id"`
-	Network           string    `gorm:"not null" json:"network"`
-	OperatingSystem   string    `gorm:"not null" json:"operating_system"`
-	Storage           uint      `gorm:"not null" json:"storage"`
-	Memory            uint      `gorm:"not null" json:"memory"`
-	Camera            uint      `gorm:"not null" json:"camera"`
-	Battery           string    `gorm:"not null" json:"battery"`
-	AdditionalFeature string    `json:"additional_feature"`
-	CreatedAt         time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt         time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	PhoneID           uint      `gorm:"not null" json:"phone_id"`
+	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
+	// display
+	DisplaySizeInch           float64 `json:"display_size_inch"`
+	DisplayResolutionWidthPx  uint    `json:"display_resolution_width_px"`
+	DisplayResolutionHeightPx uint    `json:"display_resolution_height_px"`
+	DisplayRefreshRateHz      uint    `json:"display_refresh_rate_hz"`
+	DisplayType               string  `json:"display_type"`
+	// platform
+	OperatingSystem string `gorm:"not null" json:"operating_system"`
+	Chipset         string `json:"chipset"`
+	MemoryGB        uint   `gorm:"column:memory_gb;not null" json:"memory_gb"`
+	StorageGB       uint   `gorm:"column:storage_gb;not null" json:"storage_gb"`
+	// battery, battery_mah null jika tidak diketahui (data lama yg tidak bisa di-parse)
+	BatteryMah   *uint `json:"battery_mah"`
+	ChargingWatt uint  `json:"charging_watt"`
+	// body
+	HeightMm    float64 `json:"height_mm"`
+	WidthMm     float64 `json:"width_mm"`
+	ThicknessMm float64 `json:"thickness_mm"`
+	WeightGram  float64 `json:"weight_gram"`
+	IPRating    string  `gorm:"column:ip_rating" json:"ip_rating"`
+	// connectivity, network berisi generasi jaringan tertinggi (2G/3G/4G/5G)
+	Network   string `gorm:"not null" json:"network"`
+	Wifi      string `json:"wifi"`
+	Bluetooth string `json:"bluetooth"`
+	NFC       bool   `gorm:"column:nfc" json:"nfc"`
+	USB       string `gorm:"column:usb" json:"usb"`
+
+	AdditionalFeature string         `json:"additional_feature"`
+	CameraModules     []CameraModule `gorm:"foreignKey:SpecificationID;constraint:onDelete:CASCADE" json:"camera_modules"`
+	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	PhoneID           uint           `gorm:"not null;index" json:"phone_id"`
+}
+
+type CameraModule struct {
+	ID              uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Position        string    `gorm:"not null" json:"position"` // rear / front
+	Role            string    `gorm:"not null" json:"role"`     // main / ultrawide / telephoto / macro / depth
+	ResolutionMP    float64   `gorm:"column:resolution_mp;not null" json:"resolution_mp"`
+	OpticalZoom     float64   `json:"optical_zoom"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	SpecificationID uint      `gorm:"not null;index" json:"specification_id"`
 }
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// UserRecommendation cache hasil rekomendasi phone per user, diisi ulang
+// secara berkala oleh worker rekomendasi
+type UserRecommendation struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	PhoneID   uint      `gorm:"not null" json:"phone_id"`
+	Score     float64   `gorm:"not null" json:"score"`
+	Source    string    `gorm:"not null" json:"source"` // collaborative / content
+	Position  uint      `gorm:"not null" json:"position"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ValueScoreComponent nilai tertinggi & bobot komponen value score saat semua
+// phone terakhir dihitung ulang. selama keduanya tidak berubah hanya phone yg
+// datanya berubah yg perlu dihitung ulang
+type ValueScoreComponent struct {
+	Name      string    `gorm:"primaryKey;size:20" json:"name"`
+	MaxValue  float64   `gorm:"not null" json:"max_value"`
+	Weight    float64   `gorm:"not null" json:"weight"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,332 @@
+package recommendation
+
+import (
+	"final-project/models"
+	"final-project/similarity"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	SourceCollaborative = "collaborative"
+	SourceContent       = "content"
+	SourcePopular       = "popular"
+
+	// jumlah rekomendasi yg disimpan per user
+	MaxPerUser = 20
+
+	// jumlah tetangga terdekat per phone yg dipakai
+	neighborsPerItem = 50
+	// penyusutan similarity untuk pasangan phone yg jarang direview user yg sama
+	similarityShrinkage = 5.0
+	// penyusutan rata-rata rating user ke rata-rata global (user dgn sedikit review)
+	userMeanShrinkage = 3.0
+	// batas rating yg dianggap "suka" untuk rekomendasi berbasis konten
+	likedRating = 4
+)
+
+type review struct {
+	UserID  uint
+	PhoneID uint
+	Rating  float64
+}
+
+type neighbor struct {
+	PhoneID    uint
+	Similarity float64
+}
+
+type scored struct {
+	PhoneID uint
+	Score   float64
+	Source  string
+}
+
+// Compute menghitung ulang rekomendasi semua user yg sudah pernah review dan
+// menyimpannya ke tabel user_recommendations
+func Compute(db *gorm.DB) error {
+	startedAt := time.Now()
+
+	var reviews []review
+	if err := db.Model(&models.Review{}).Select("user_id, phone_id, rating").
+		Where("status = ?", models.ReviewStatusApproved).
+		Scan(&reviews).Error; err != nil {
+		return err
+	}
+
+	byUser := map[uint]map[uint]float64{}
+	total := 0.0
+	for _, r := range reviews {
+		if byUser[r.UserID] == nil {
+			byUser[r.UserID] = map[uint]float64{}
+		}
+		byUser[r.UserID][r.PhoneID] = r.Rating
+		total += r.Rating
+	}
+	if len(reviews) == 0 {
+		return db.Where("created_at < ?", startedAt).Delete(&models.UserRecommendation{}).Error
+	}
+	globalMean := total / float64(len(reviews))
+
+	// rating dikurangi rata-rata user (adjusted cosine)
+	centered := map[uint]map[uint]float64{}
+	for userID, ratings := range byUser {
+		sum := 0.0
+		for _, rating := range ratings {
+			sum += rating
+		}
+		mean := (sum + userMeanShrinkage*globalMean) / (float64(len(ratings)) + userMeanShrinkage)
+		centered[userID] = map[uint]float64{}
+		for phoneID, rating := range ratings {
+			centered[userID][phoneID] = rating - mean
+		}
+	}
+
+	neighbors := itemNeighbors(centered)
+
+	// phone draft tetap dipakai untuk similarity tapi tidak direkomendasikan
+	var draftIDs []uint
+	if err := db.Model(&models.Phone{}).Where("status = ?", models.PhoneStatusDraft).
+		Pluck("id", &draftIDs).Error; err != nil {
+		return err
+	}
+	drafts := make(map[uint]bool, len(draftIDs))
+	for _, id := range draftIDs {
+		drafts[id] = true
+	}
+
+	var content *contentIndex
+
+	for userID, ratings := range centered {
+		recommendations := collaborative(ratings, neighbors, drafts)
+
+		// cold start: user dgn sedikit review belum punya cukup tetangga,
+		// sisa slot diisi phone yg spec-nya mirip dgn phone yg disukai user
+		if len(recommendations) < MaxPerUser {
+			if content == nil {
+				index, err := loadContentIndex(db)
+				if err != nil {
+					return err
+				}
+				content = index
+			}
+			recommendations = append(recommendations, content.recommend(byUser[userID], recommendations, MaxPerUser-len(recommendations))...)
+		}
+
+		if err := save(db, userID, recommendations); err != nil {
+			return err
+		}
+	}
+
+	// hapus cache user yg tidak dihitung ulang (user / review sudah dihapus)
+	return db.Where("created_at < ?", startedAt).Delete(&models.UserRecommendation{}).Error
+}
+
+// itemNeighbors similarity antar phone dari rating user yg sama, hanya
+// tetangga dgn similarity positif terbesar yg disimpan
+func itemNeighbors(centered map[uint]map[uint]float64) map[uint][]neighbor {
+	type pair struct{ a, b uint }
+
+	dot := map[pair]float64{}
+	support := map[pair]int{}
+	norm := map[uint]float64{}
+
+	for _, ratings := range centered {
+		phoneIDs := make([]uint, 0, len(ratings))
+		for phoneID, value := range ratings {
+			phoneIDs = append(phoneIDs, phoneID)
+			norm[phoneID] += value * value
+		}
+		for i := 0; i < len(phoneIDs); i++ {
+			for j := i + 1; j < len(phoneIDs); j++ {
+				a, b := phoneIDs[i], phoneIDs[j]
+				if a > b {
+					a, b = b, a
+				}
+				p := pair{a, b}
+				dot[p] += ratings[a] * ratings[b]
+				support[p]++
+			}
+		}
+	}
+
+	neighbors := map[uint][]neighbor{}
+	for p, value := range dot {
+		denominator := math.Sqrt(norm[p.a]) * math.Sqrt(norm[p.b])
+		if denominator == 0 {
+			continue
+		}
+		n := float64(support[p])
+		similarity := value / denominator * n / (n + similarityShrinkage)
+		if similarity <= 0 {
+			continue
+		}
+		neighbors[p.a] = append(neighbors[p.a], neighbor{p.b, similarity})
+		neighbors[p.b] = append(neighbors[p.b], neighbor{p.a, similarity})
+	}
+
+	for phoneID, list := range neighbors {
+		sort.Slice(list, func(i, j int) bool { return list[i].Similarity > list[j].Similarity })
+		if len(list) > neighborsPerItem {
+			neighbors[phoneID] = list[:neighborsPerItem]
+		}
+	}
+
+	return neighbors
+}
+
+// collaborative prediksi selisih rating user terhadap rata-ratanya untuk phone
+// yg belum direview dan bukan draft, hanya phone dgn prediksi di atas
+// rata-rata yg diambil
+func collaborative(ratings map[uint]float64, neighbors map[uint][]neighbor, drafts map[uint]bool) []scored {
+	numerator := map[uint]float64{}
+	denominator := map[uint]float64{}
+
+	for phoneID, value := range ratings {
+		for _, n := range neighbors[phoneID] {
+			if _, reviewed := ratings[n.PhoneID]; reviewed || drafts[n.PhoneID] {
+				continue
+			}
+			numerator[n.PhoneID] += n.Similarity * value
+			denominator[n.PhoneID] += n.Similarity
+		}
+	}
+
+	result := []scored{}
+	for phoneID, den := range denominator {
+		score := numerator[phoneID] / den
+		if score <= 0 {
+			continue
+		}
+		result = append(result, scored{phoneID, score, SourceCollaborative})
+	}
+
+	return top(result, MaxPerUser)
+}
+
+func save(db *gorm.DB, userID uint, recommendations []scored) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserRecommendation{}).Error; err != nil {
+			return err
+		}
+		if len(recommendations) == 0 {
+			return nil
+		}
+
+		rows := make([]models.UserRecommendation, len(recommendations))
+		for i, r := range recommendations {
+			rows[i] = models.UserRecommendation{
+				UserID:   userID,
+				PhoneID:  r.PhoneID,
+				Score:    math.Round(r.Score*10000) / 10000,
+				Source:   r.Source,
+				Position: uint(i) + 1,
+			}
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+func top(list []scored, n int) []scored {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Score == list[j].Score {
+			return list[i].PhoneID < list[j].PhoneID
+		}
+		return list[i].Score > list[j].Score
+	})
+	if len(list) > n {
+		return list[:n]
+	}
+	return list
+}
+
+// contentIndex fitur semua phone (phone_features, lihat package similarity)
+// untuk rekomendasi berbasis konten, tetangga tiap phone dihitung sekali lalu
+// dipakai ulang antar user
+type contentIndex struct {
+	phones    []models.PhoneFeature
+	byID      map[uint]int
+	neighbors map[uint][]neighbor
+}
+
+func loadContentIndex(db *gorm.DB) (*contentIndex, error) {
+	var phones []models.PhoneFeature
+	if err := db.Model(&models.PhoneFeature{}).
+		Joins("JOIN phones on phones.id = phone_features.phone_id").
+		Where("phones.status <> ?", models.PhoneStatusDraft).
+		Order("phone_features.phone_id ASC").
+		Find(&phones).Error; err != nil {
+		return nil, err
+	}
+
+	index := &contentIndex{phones: phones, byID: map[uint]int{}, neighbors: map[uint][]neighbor{}}
+	for i, phone := range phones {
+		index.byID[phone.PhoneID] = i
+	}
+	return index, nil
+}
+
+// recommend phone yg mirip dgn phone yg disukai user (rating >= likedRating),
+// phone yg sudah direview / sudah direkomendasikan dilewati
+func (index *contentIndex) recommend(ratings map[uint]float64, exclude []scored, n int) []scored {
+	skip := map[uint]bool{}
+	for phoneID := range ratings {
+		skip[phoneID] = true
+	}
+	for _, r := range exclude {
+		skip[r.PhoneID] = true
+	}
+
+	best := map[uint]float64{}
+	for phoneID, rating := range ratings {
+		if rating < likedRating {
+			continue
+		}
+		for _, neighbor := range index.neighborsOf(phoneID) {
+			if skip[neighbor.PhoneID] {
+				continue
+			}
+			// bobot lebih besar untuk phone yg diberi rating lebih tinggi
+			score := neighbor.Similarity * rating / 5
+			if score > best[neighbor.PhoneID] {
+				best[neighbor.PhoneID] = score
+			}
+		}
+	}
+
+	result := []scored{}
+	for phoneID, score := range best {
+		result = append(result, scored{phoneID, score, SourceContent})
+	}
+	return top(result, n)
+}
+
+func (index *contentIndex) neighborsOf(phoneID uint) []neighbor {
+	if list, ok := index.neighbors[phoneID]; ok {
+		return list
+	}
+
+	i, ok := index.byID[phoneID]
+	if !ok {
+		return nil
+	}
+	target := index.phones[i]
+
+	list := []neighbor{}
+	for _, phone := range index.phones {
+		if phone.PhoneID == phoneID {
+			continue
+		}
+		list = append(list, neighbor{phone.PhoneID, similarity.Score(target, phone, similarity.DefaultWeights)})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Similarity > list[j].Similarity })
+	if len(list) > neighborsPerItem {
+		list = list[:neighborsPerItem]
+	}
+
+	index.neighbors[phoneID] = list
+	return list
+}
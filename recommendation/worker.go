@@ -0,0 +1,32 @@
+package recommendation
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrAlreadyRunning perhitungan sebelumnya di instance ini belum selesai
+var ErrAlreadyRunning = errors.New("rekomendasi sedang dihitung")
+
+var running sync.Mutex
+
+// Run menjalankan Compute, dipanggil dari cron (/cron/recommendations) atau
+// admin. tidak memakai ticker karena instance serverless bisa dibekukan
+// kapan saja di antara request
+func Run(db *gorm.DB) error {
+	if !running.TryLock() {
+		return ErrAlreadyRunning
+	}
+	defer running.Unlock()
+
+	start := time.Now()
+	if err := Compute(db); err != nil {
+		return err
+	}
+	log.Println("rekomendasi selesai dihitung dalam", time.Since(start).Round(time.Millisecond))
+	return nil
+}
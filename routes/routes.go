@@ -3,6 +3,8 @@ package routes
 import (
 	"final-project/controller"
 	"final-project/middleware"
+	"final-project/slug"
+	"final-project/storage"
 	"final-project/utils"
 	"time"
 
@@ -33,9 +35,17 @@ func SetupRouter(db *gorm.DB, r *gin.Engine) {
 
 	r.Use(cors.New(corsConfig))
 
-	// set db to gin context
+	// storage file upload, file di local storage disajikan langsung oleh router
+	store := storage.New()
+	if local, ok := store.(*storage.LocalStorage); ok {
+		r.Static("/uploads", local.BaseDir)
+	}
+	r.MaxMultipartMemory = storage.MaxImageSize()
+
+	// set db & storage to gin context
 	r.Use(func(c *gin.Context) {
 		c.Set("db", db)
+		c.Set("storage", store)
 	})
 
 	// auth routes
@@ -61,6 +71,7 @@ func SetupRouter(db *gorm.DB, r *gin.Engine) {
 	userMiddlewareRoutes.Use(middleware.JwtAuthMiddleware())
 	// ⬇ For registered account only (user/admin)
 	userMiddlewareRoutes.GET("/role", controller.GetUserRole)
+	userMiddlewareRoutes.GET("/me/recommendations", controller.GetMyRecommendations)
 	userMiddlewareRoutes.PUT("", controller.UpdateUser)
 	userMiddlewareRoutes.DELETE("", controller.DeleteMyAccount)
 	// ⬇ For ADMIN ONLY
@@ -78,6 +89,39 @@ func SetupRouter(db *gorm.DB, r *gin.Engine) {
 	adminMiddlewareRoutes.GET("/:id/reviews", controller.GetAdminReviewByID)
 	adminMiddlewareRoutes.POST("/register", controller.RegisterAdmin)
 
+	// admin tools routes (ADMIN ONLY)
+	adminToolsRoutes := r.Group("/admin")
+	adminToolsRoutes.Use(middleware.JwtAuthMiddleware())
+	adminToolsRoutes.Use(middleware.RoleMiddleware(db))
+	// bulk import & export katalog (brand, phone, specification)
+	adminToolsRoutes.POST("/import", controller.ImportCatalog)
+	adminToolsRoutes.GET("/export", controller.ExportCatalog)
+	adminToolsRoutes.GET("/value-score", controller.GetValueScoreConfig)
+	adminToolsRoutes.POST("/value-score/recompute", controller.RecomputeValueScore)
+	adminToolsRoutes.POST("/recommendations/recompute", controller.RecomputeRecommendations)
+	adminToolsRoutes.POST("/phones/merge", controller.MergePhones)
+	adminToolsRoutes.POST("/phones/publish-scheduled", controller.PublishScheduledPhones)
+	adminToolsRoutes.POST("/brands/merge", controller.MergeBrands)
+	adminToolsRoutes.GET("/moderation/config", controller.GetModerationConfig)
+	adminToolsRoutes.GET("/moderation/reviews", controller.GetModerationQueue)
+	adminToolsRoutes.POST("/moderation/reviews/:id/approve", controller.ApproveReview)
+	adminToolsRoutes.POST("/moderation/reviews/:id/reject", controller.RejectReview)
+	adminToolsRoutes.POST("/moderation/reviews/:id/hide", controller.HideReview)
+	adminToolsRoutes.GET("/moderation/comments", controller.GetCommentModerationQueue)
+	adminToolsRoutes.POST("/moderation/comments/:id/approve", controller.ApproveComment)
+	adminToolsRoutes.POST("/moderation/comments/:id/reject", controller.RejectComment)
+	adminToolsRoutes.GET("/content-filter", controller.GetContentFilterConfig)
+	adminToolsRoutes.PUT("/content-filter/rules/:key", controller.UpdateContentFilterRule)
+	adminToolsRoutes.POST("/content-filter/words", controller.CreateContentFilterWord)
+	adminToolsRoutes.DELETE("/content-filter/words/:id", controller.DeleteContentFilterWord)
+	adminToolsRoutes.POST("/content-filter/test", controller.TestContentFilter)
+
+	// cron job routes, dipanggil terjadwal (lihat vercel.json) dgn CRON_SECRET
+	cronRoutes := r.Group("/cron")
+	cronRoutes.Use(middleware.CronMiddleware())
+	cronRoutes.GET("/recommendations", controller.RecomputeRecommendations)
+	cronRoutes.GET("/publish", controller.PublishScheduledPhones)
+
 	// profile routes
 	profileMiddlewareRoutes := r.Group("/profiles")
 	profileMiddlewareRoutes.Use(middleware.JwtAuthMiddleware())
@@ -85,6 +129,7 @@ func SetupRouter(db *gorm.DB, r *gin.Engine) {
 	// ID user diambil dari token
 	profileMiddlewareRoutes.POST("", controller.CreateProfile)
 	profileMiddlewareRoutes.PUT("", controller.UpdateProfile)
+	profileMiddlewareRoutes.POST("/image", controller.UploadProfileImage)
 
 	// role routes
 	roleMiddlewareRoutes := r.Group("/roles")
@@ -101,23 +146,40 @@ func SetupRouter(db *gorm.DB, r *gin.Engine) {
 	// brands route
 	brandsMiddlewareRoutes := r.Group("/brands")
 	// ⬇ BRANDS PUBLIC ROUTES
+	// :id bisa berupa id atau slug brand
+	brandSlug := middleware.SlugMiddleware(db, slug.KindBrand)
 	r.GET("/brands", controller.GetAllBrandData)
-	r.GET("/brands/:id", controller.GetBrandById)
-	r.GET("/brands/:id/phones", controller.GetPhonesDataByBrandId)
+	r.GET("/brands/:id", brandSlug, controller.GetBrandById)
+	r.GET("/brands/:id/phones", brandSlug, controller.GetPhonesDataByBrandId)
+	r.GET("/brands/tree", controller.GetBrandTree)
+	r.GET("/brands/:id/tree", brandSlug, controller.GetBrandSubtree)
 	brandsMiddlewareRoutes.Use(middleware.JwtAuthMiddleware())
 	// ⬇ ADMIN ONLY
 	brandsMiddlewareRoutes.Use(middleware.RoleMiddleware(db))
 	brandsMiddlewareRoutes.POST("", controller.CreateBrand)
 	brandsMiddlewareRoutes.PUT("/:id", controller.UpdateBrand)
 	brandsMiddlewareRoutes.DELETE("/:id", controller.DeleteBrandByID)
+	brandsMiddlewareRoutes.POST("/:id/logo", controller.UploadBrandLogo)
 
 	// phones route
 	phonesMiddlewareRoutes := r.Group("/phones")
 	// ⬇ PUBLIC ROUTES
-	r.GET("/phones/:id", controller.GetPhoneById)
+	// :id bisa berupa id atau slug phone
+	phoneSlug := middleware.SlugMiddleware(db, slug.KindPhone)
+	r.GET("/phones/:id", phoneSlug, controller.GetPhoneById)
 	r.GET("/phones", controller.GetAllPhoneData)
-	r.GET("/phones/:id/specification", controller.GetPhonesSpecByPhoneId)
-	r.GET("/phones/:id/reviews", controller.GetReviewsDataByPhoneId)
+	r.GET("/phones/:id/specification", phoneSlug, controller.GetPhonesSpecByPhoneId)
+	r.GET("/phones/:id/reviews", phoneSlug, controller.GetReviewsDataByPhoneId)
+	r.GET("/phones/:id/pros-cons", phoneSlug, controller.GetPhoneProsCons)
+	r.GET("/phones/:id/rating-stats", phoneSlug, controller.GetPhoneRatingStats)
+	r.GET("/phones/:id/variants", phoneSlug, controller.GetPhoneVariants)
+	r.GET("/phones/:id/attributes", phoneSlug, controller.GetPhoneAttributes)
+	r.GET("/phones/:id/images", phoneSlug, controller.GetPhoneImages)
+	r.GET("/phones/:id/similar", phoneSlug, controller.GetSimilarPhones)
+	r.GET("/phones/:id/prices", phoneSlug, controller.GetPhonePrices)
+	r.GET("/phones/compare", controller.ComparePhones)
+	r.GET("/phones/calendar", controller.GetReleaseCalendar)
+	r.GET("/phones/calendar.ics", controller.GetReleaseCalendarFeed)
 	phonesMiddlewareRoutes.Use(middleware.JwtAuthMiddleware())
 	// ⬇ logged in account only (user/admin)
 	phonesMiddlewareRoutes.POST("/:id/reviews", controller.CreateReview)
@@ -126,9 +188,85 @@ func SetupRouter(db *gorm.DB, r *gin.Engine) {
 	phonesMiddlewareRoutes.POST("", controller.CreatePhoneData)
 	phonesMiddlewareRoutes.PUT("/:id", controller.UpdatePhoneData)
 	phonesMiddlewareRoutes.DELETE("/:id", controller.DeletePhoneData)
+	phonesMiddlewareRoutes.POST("/:id/image", controller.UploadPhoneImage)
+	// phone image gallery
+	phonesMiddlewareRoutes.POST("/:id/images", controller.CreatePhoneImage)
+	phonesMiddlewareRoutes.PUT("/:id/images/order", controller.ReorderPhoneImages)
+	phonesMiddlewareRoutes.PUT("/:id/images/:image_id", controller.UpdatePhoneImage)
+	phonesMiddlewareRoutes.DELETE("/:id/images/:image_id", controller.DeletePhoneImage)
 	// create & update phone specification
 	phonesMiddlewareRoutes.POST("/:id/specification", controller.CreateSpecification)
 	phonesMiddlewareRoutes.PUT("/:id/specification", controller.UpdateSpecification)
+	// create, update & delete phone variant
+	phonesMiddlewareRoutes.POST("/:id/variants", controller.CreatePhoneVariant)
+	phonesMiddlewareRoutes.PUT("/:id/variants/:variant_id", controller.UpdatePhoneVariant)
+	phonesMiddlewareRoutes.DELETE("/:id/variants/:variant_id", controller.DeletePhoneVariant)
+	// set phone specification attributes
+	phonesMiddlewareRoutes.PUT("/:id/attributes", controller.SetPhoneAttributes)
+	// set phone categories & tags
+	phonesMiddlewareRoutes.PUT("/:id/categories", controller.SetPhoneCategories)
+	phonesMiddlewareRoutes.PUT("/:id/tags", controller.SetPhoneTags)
+	// set & delete regional price
+	phonesMiddlewareRoutes.PUT("/:id/prices/:region", controller.SetPhonePrice)
+	phonesMiddlewareRoutes.DELETE("/:id/prices/:region", controller.DeletePhonePrice)
+
+	// exchange rate routes
+	exchangeRatesMiddlewareRoutes := r.Group("/exchange-rates")
+	// ⬇ PUBLIC ROUTES
+	r.GET("/exchange-rates", controller.GetExchangeRates)
+	exchangeRatesMiddlewareRoutes.Use(middleware.JwtAuthMiddleware())
+	// ⬇ ADMIN ONLY
+	exchangeRatesMiddlewareRoutes.Use(middleware.RoleMiddleware(db))
+	exchangeRatesMiddlewareRoutes.PUT("/:currency", controller.SetExchangeRate)
+	exchangeRatesMiddlewareRoutes.DELETE("/:currency", controller.DeleteExchangeRate)
+
+	// leaderboard routes
+	// ⬇ PUBLIC ROUTES
+	r.GET("/leaderboards", controller.GetLeaderboard)
+	r.GET("/leaderboards/:dimension", controller.GetGroupedLeaderboard)
+
+	// category routes
+	categoriesMiddlewareRoutes := r.Group("/categories")
+	// ⬇ PUBLIC ROUTES
+	r.GET("/categories", controller.GetAllCategories)
+	r.GET("/categories/:slug/phones", controller.GetPhonesByCategory)
+	categoriesMiddlewareRoutes.Use(middleware.JwtAuthMiddleware())
+	// ⬇ ADMIN ONLY
+	categoriesMiddlewareRoutes.Use(middleware.RoleMiddleware(db))
+	categoriesMiddlewareRoutes.POST("", controller.CreateCategory)
+	categoriesMiddlewareRoutes.PUT("/:id", controller.UpdateCategory)
+	categoriesMiddlewareRoutes.DELETE("/:id", controller.DeleteCategory)
+
+	// tag routes
+	tagsMiddlewareRoutes := r.Group("/tags")
+	// ⬇ PUBLIC ROUTES
+	r.GET("/tags", controller.GetAllTags)
+	tagsMiddlewareRoutes.Use(middleware.JwtAuthMiddleware())
+	// ⬇ ADMIN ONLY
+	tagsMiddlewareRoutes.Use(middleware.RoleMiddleware(db))
+	tagsMiddlewareRoutes.DELETE("/:id", controller.DeleteTag)
+
+	// spec attribute definition routes
+	specAttributesMiddlewareRoutes := r.Group("/spec-attributes")
+	// ⬇ PUBLIC ROUTES
+	r.GET("/spec-attributes", controller.GetAllSpecAttributes)
+	specAttributesMiddlewareRoutes.Use(middleware.JwtAuthMiddleware())
+	// ⬇ ADMIN ONLY
+	specAttributesMiddlewareRoutes.Use(middleware.RoleMiddleware(db))
+	specAttributesMiddlewareRoutes.POST("", controller.CreateSpecAttribute)
+	specAttributesMiddlewareRoutes.PUT("/:id", controller.UpdateSpecAttribute)
+	specAttributesMiddlewareRoutes.DELETE("/:id", controller.DeleteSpecAttribute)
+
+	// rating aspect definition routes
+	ratingAspectsMiddlewareRoutes := r.Group("/rating-aspects")
+	// ⬇ PUBLIC ROUTES
+	r.GET("/rating-aspects", controller.GetAllRatingAspects)
+	ratingAspectsMiddlewareRoutes.Use(middleware.JwtAuthMiddleware())
+	// ⬇ ADMIN ONLY
+	ratingAspectsMiddlewareRoutes.Use(middleware.RoleMiddleware(db))
+	ratingAspectsMiddlewareRoutes.POST("", controller.CreateRatingAspect)
+	ratingAspectsMiddlewareRoutes.PUT("/:id", controller.UpdateRatingAspect)
+	ratingAspectsMiddlewareRoutes.DELETE("/:id", controller.DeleteRatingAspect)
 
 	reviewsMiddlewareRoutes := r.Group("/reviews")
 	// public comments route
@@ -138,6 +276,11 @@ func SetupRouter(db *gorm.DB, r *gin.Engine) {
 	reviewsMiddlewareRoutes.POST("/:id/comments", controller.CreateComment)
 	// reviewsMiddlewareRoutes.PUT("/:id/comments/:com_id", controller.UpdateComment)
 	reviewsMiddlewareRoutes.PUT("/:id", controller.UpdateReview)
+	reviewsMiddlewareRoutes.PUT("/:id/vote", controller.VoteReview)
+	reviewsMiddlewareRoutes.DELETE("/:id/vote", controller.DeleteReviewVote)
+	reviewsMiddlewareRoutes.POST("/:id/photos", controller.CreateReviewPhoto)
+	reviewsMiddlewareRoutes.DELETE("/:id/photos/:photo_id", controller.DeleteReviewPhoto)
+	reviewsMiddlewareRoutes.GET("/:id/revisions", controller.GetReviewRevisions)
 	reviewsMiddlewareRoutes.Use(middleware.RoleMiddleware(db))
 	reviewsMiddlewareRoutes.GET("", controller.GetAllReviews)
 
@@ -145,6 +288,7 @@ func SetupRouter(db *gorm.DB, r *gin.Engine) {
 	commentMiddlewareRoutes.Use(middleware.JwtAuthMiddleware())
 	commentMiddlewareRoutes.DELETE("/:id", controller.DeleteCommentByID)
 	commentMiddlewareRoutes.PUT("/:id", controller.UpdateComment)
+	commentMiddlewareRoutes.GET("/:id/revisions", controller.GetCommentRevisions)
 	commentMiddlewareRoutes.Use(middleware.RoleMiddleware(db))
 	commentMiddlewareRoutes.GET("", controller.GetAllCommentData)
 	commentMiddlewareRoutes.DELETE("/:id/admin", controller.DeleteCommentForAdmin)
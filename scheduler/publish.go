@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"final-project/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PublishDuePhones ubah status phone draft terjadwal menjadi scheduled_status,
+// mengembalikan jumlah phone yg dipublish. dipanggil dari cron (/cron/publish)
+// atau admin, tidak memakai ticker karena instance serverless bisa dibekukan
+// kapan saja di antara request
+func PublishDuePhones(db *gorm.DB, now time.Time) (int64, error) {
+	var published int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Phone{}).
+			Where("status = ? AND scheduled_status <> '' AND publish_at <= ?", models.PhoneStatusDraft, now).
+			Updates(map[string]any{
+				"status":     gorm.Expr("scheduled_status"),
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		published = result.RowsAffected
+
+		// dipisah dari update status karena urutan SET di mysql memakai nilai yg sudah diubah
+		return tx.Model(&models.Phone{}).
+			Where("status <> ? AND scheduled_status <> ''", models.PhoneStatusDraft).
+			Update("scheduled_status", "").Error
+	})
+	return published, err
+}
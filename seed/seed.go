@@ -44,4 +44,33 @@ func Load(db *gorm.DB) {
 		})
 	}
 
+	// insert initial phone categories, hanya jika belum ada kategori sama sekali
+	// agar kategori yg dihapus admin tidak dibuat ulang
+	var categoryCount int64
+	db.Model(&models.Category{}).Count(&categoryCount)
+	if categoryCount == 0 {
+		category_data := []models.Category{
+			{Slug: "flagship", Name: "Flagship", Description: "Phone kelas atas dengan spesifikasi terbaik"},
+			{Slug: "mid-range", Name: "Mid-range", Description: "Phone kelas menengah"},
+			{Slug: "gaming", Name: "Gaming", Description: "Phone yg dioptimalkan untuk bermain game"},
+			{Slug: "foldable", Name: "Foldable", Description: "Phone dengan layar lipat"},
+			{Slug: "rugged", Name: "Rugged", Description: "Phone dengan bodi tahan banting"},
+		}
+		db.Create(&category_data)
+	}
+
+	// insert initial rating aspect, hanya jika belum ada aspek sama sekali
+	var aspectCount int64
+	db.Model(&models.RatingAspect{}).Count(&aspectCount)
+	if aspectCount == 0 {
+		aspect_data := []models.RatingAspect{
+			{Key: "camera", Label: "Camera", Description: "Kualitas foto & video", Position: 1},
+			{Key: "battery", Label: "Battery", Description: "Daya tahan baterai & kecepatan charging", Position: 2},
+			{Key: "performance", Label: "Performance", Description: "Kecepatan aplikasi & game", Position: 3},
+			{Key: "display", Label: "Display", Description: "Kualitas layar", Position: 4},
+			{Key: "build", Label: "Build", Description: "Kualitas material & bodi", Position: 5},
+			{Key: "value", Label: "Value", Description: "Kesesuaian harga dgn kualitas", Position: 6},
+		}
+		db.Create(&aspect_data)
+	}
 }
@@ -0,0 +1,103 @@
+package similarity
+
+import (
+	"final-project/models"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Features fitur bernilai angka yg dibandingkan dari nilai *_norm
+var Features = []string{"memory", "storage", "camera", "battery", "price"}
+
+// DefaultWeights bobot bawaan tiap fitur & kesamaan os
+var DefaultWeights = map[string]float64{
+	"memory":  1,
+	"storage": 1,
+	"camera":  1,
+	"battery": 1,
+	"os":      1,
+	"price":   2,
+}
+
+// Norms nilai ternormalisasi tiap fitur, nil jika tidak diketahui
+func Norms(feature models.PhoneFeature) map[string]*float64 {
+	return map[string]*float64{
+		"memory":  feature.MemoryNorm,
+		"storage": feature.StorageNorm,
+		"camera":  feature.CameraNorm,
+		"battery": feature.BatteryNorm,
+		"price":   feature.PriceNorm,
+	}
+}
+
+// Weights bobot yg dipakai untuk membandingkan phone lain dgn target, fitur
+// target yg tidak diketahui (contoh phone tanpa specification) diberi bobot 0
+func Weights(target models.PhoneFeature, weights map[string]float64) map[string]float64 {
+	result := map[string]float64{}
+	norms := Norms(target)
+	for name, weight := range weights {
+		if value, isFeature := norms[name]; isFeature && value == nil {
+			weight = 0
+		}
+		if name == "os" && target.OSFamily == "" {
+			weight = 0
+		}
+		result[name] = weight
+	}
+	return result
+}
+
+// ScoreTerms ekspresi sql "bobot * kemiripan" tiap fitur & os terhadap target
+// untuk kandidat dgn alias tabel phone_features c, weights hasil Weights.
+// sama dgn Score tapi dihitung di database
+func ScoreTerms(target models.PhoneFeature, weights map[string]float64) ([]string, []any) {
+	terms := []string{}
+	args := []any{}
+	norms := Norms(target)
+	for _, name := range Features {
+		if weights[name] == 0 {
+			continue
+		}
+		// nilai kandidat yg tidak diketahui dianggap tidak mirip
+		terms = append(terms, fmt.Sprintf("%s * COALESCE(1 - ABS(c.%s_norm - ?), 0)", formatWeight(weights[name]), name))
+		args = append(args, *norms[name])
+	}
+	if weights["os"] > 0 {
+		terms = append(terms, formatWeight(weights["os"])+" * (CASE WHEN c.os_family = ? THEN 1 ELSE 0 END)")
+		args = append(args, target.OSFamily)
+	}
+	return terms, args
+}
+
+// Score kemiripan phone dgn target (0 - 1), rata-rata berbobot kemiripan tiap
+// fitur & kesamaan os. fitur target yg tidak diketahui tidak dibandingkan
+func Score(target, phone models.PhoneFeature, weights map[string]float64) float64 {
+	weights = Weights(target, weights)
+	targetNorms, phoneNorms := Norms(target), Norms(phone)
+
+	score, total := 0.0, 0.0
+	for _, name := range Features {
+		if weights[name] == 0 {
+			continue
+		}
+		total += weights[name]
+		if phoneNorms[name] != nil {
+			score += weights[name] * (1 - math.Abs(*targetNorms[name]-*phoneNorms[name]))
+		}
+	}
+	if weights["os"] > 0 {
+		total += weights["os"]
+		if phone.OSFamily == target.OSFamily {
+			score += weights["os"]
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return score / total
+}
+
+func formatWeight(weight float64) string {
+	return strconv.FormatFloat(weight, 'f', -1, 64)
+}
@@ -0,0 +1,118 @@
+package similarity
+
+import (
+	"final-project/models"
+	"math"
+	"testing"
+)
+
+func norm(value float64) *float64 {
+	return &value
+}
+
+func TestScore(t *testing.T) {
+	target := models.PhoneFeature{
+		PhoneID: 1, OSFamily: "android",
+		PriceNorm: norm(0.5), MemoryNorm: norm(0.5), StorageNorm: norm(0.5), CameraNorm: norm(0.5), BatteryNorm: norm(0.5),
+	}
+
+	tests := []struct {
+		name   string
+		target models.PhoneFeature
+		phone  models.PhoneFeature
+		want   float64
+	}{
+		{"phone identik", target, target, 1},
+		{
+			name:   "os berbeda",
+			target: target,
+			phone: models.PhoneFeature{
+				OSFamily:  "ios",
+				PriceNorm: norm(0.5), MemoryNorm: norm(0.5), StorageNorm: norm(0.5), CameraNorm: norm(0.5), BatteryNorm: norm(0.5),
+			},
+			// os bobot 1 dari total 7
+			want: 6.0 / 7,
+		},
+		{
+			name:   "fitur kandidat tidak diketahui dianggap tidak mirip",
+			target: target,
+			phone:  models.PhoneFeature{OSFamily: "android", PriceNorm: norm(0.5)},
+			want:   3.0 / 7,
+		},
+		{
+			name:   "fitur target tidak diketahui tidak dibandingkan",
+			target: models.PhoneFeature{PriceNorm: norm(0.2)},
+			phone:  models.PhoneFeature{OSFamily: "android", PriceNorm: norm(0.7), MemoryNorm: norm(1)},
+			want:   0.5,
+		},
+		{"target tanpa fitur", models.PhoneFeature{}, target, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Score(tt.target, tt.phone, DefaultWeights)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreTerms(t *testing.T) {
+	target := models.PhoneFeature{OSFamily: "android", PriceNorm: norm(0.25), MemoryNorm: norm(0.5)}
+	terms, args := ScoreTerms(target, Weights(target, DefaultWeights))
+
+	want := []string{
+		"1 * COALESCE(1 - ABS(c.memory_norm - ?), 0)",
+		"2 * COALESCE(1 - ABS(c.price_norm - ?), 0)",
+		"1 * (CASE WHEN c.os_family = ? THEN 1 ELSE 0 END)",
+	}
+	if len(terms) != len(want) {
+		t.Fatalf("ScoreTerms() = %v, want %v", terms, want)
+	}
+	for i := range want {
+		if terms[i] != want[i] {
+			t.Errorf("term %d = %q, want %q", i, terms[i], want[i])
+		}
+	}
+	if len(args) != 3 || args[0] != 0.5 || args[1] != 0.25 || args[2] != "android" {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		want  *float64
+	}{
+		{"tidak diketahui", 0, nil},
+		{"batas bawah", 1, norm(0)},
+		{"di bawah batas dipotong", 0.5, norm(0)},
+		{"batas atas", 64, norm(1)},
+		{"di atas batas dipotong", 128, norm(1)},
+		{"tengah skala log", 8, norm(0.5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalize("memory", tt.value)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("normalize(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOSFamily(t *testing.T) {
+	tests := map[string]string{
+		"Android 14":  "android",
+		"  iOS 17.1 ": "ios",
+		"HarmonyOS":   "harmonyos",
+		"":            "",
+	}
+	for input, want := range tests {
+		if got := OSFamily(input); got != want {
+			t.Errorf("OSFamily(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package similarity
+
+import (
+	"final-project/models"
+	"math"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// batas bawah & atas tiap fitur sebelum dinormalisasi dgn skala log, nilai
+// di luar batas dipotong. batas tetap (bukan nilai terbesar katalog) agar
+// perubahan satu phone tidak mengubah vektor phone lain
+var bounds = map[string][2]float64{
+	"price":   {500_000, 50_000_000},
+	"memory":  {1, 64},
+	"storage": {8, 4096},
+	"camera":  {2, 400},
+	"battery": {1000, 30000},
+}
+
+type rawFeature struct {
+	PhoneID         uint
+	Price           uint
+	MemoryGB        float64
+	StorageGB       float64
+	CameraMP        float64
+	BatteryMah      *float64
+	OperatingSystem string
+	HasSpec         bool
+}
+
+// Refresh hitung ulang fitur phone dgn id tsb, dipanggil setelah phone,
+// specification atau harganya berubah. phone yg sudah dihapus diabaikan
+func Refresh(db *gorm.DB, phoneIDs ...uint) error {
+	if len(phoneIDs) == 0 {
+		return nil
+	}
+
+	var rows []rawFeature
+	if err := featureQuery(db, phoneIDs).Where("phones.id IN ?", phoneIDs).Scan(&rows).Error; err != nil {
+		return err
+	}
+	return save(db, rows)
+}
+
+// Backfill hitung fitur phone yg belum punya fitur (contoh phone lama
+// sebelum tabel phone_features ada)
+func Backfill(db *gorm.DB) error {
+	var rows []rawFeature
+	if err := featureQuery(db, nil).
+		Where("NOT EXISTS (SELECT 1 FROM phone_features WHERE phone_features.phone_id = phones.id)").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+	return save(db, rows)
+}
+
+func save(db *gorm.DB, rows []rawFeature) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	features := make([]models.PhoneFeature, 0, len(rows))
+	for _, row := range rows {
+		feature := models.PhoneFeature{
+			PhoneID:         row.PhoneID,
+			Price:           row.Price,
+			MemoryGB:        row.MemoryGB,
+			StorageGB:       row.StorageGB,
+			CameraMP:        row.CameraMP,
+			BatteryMah:      row.BatteryMah,
+			OperatingSystem: row.OperatingSystem,
+			OSFamily:        OSFamily(row.OperatingSystem),
+			HasSpec:         row.HasSpec,
+			PriceNorm:       normalize("price", float64(row.Price)),
+		}
+		if row.HasSpec {
+			feature.MemoryNorm = normalize("memory", row.MemoryGB)
+			feature.StorageNorm = normalize("storage", row.StorageGB)
+			feature.CameraNorm = normalize("camera", row.CameraMP)
+			if row.BatteryMah != nil {
+				feature.BatteryNorm = normalize("battery", *row.BatteryMah)
+			}
+		}
+		features = append(features, feature)
+	}
+
+	return db.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(&features, 500).Error
+}
+
+// featureQuery satu baris per phone dari specification pertamanya, kamera
+// diambil dari resolusi kamera belakang terbesar. phoneIDs membatasi subquery
+// ke phone tsb saja (nil untuk semua phone)
+func featureQuery(db *gorm.DB, phoneIDs []uint) *gorm.DB {
+	firstSpec := db.Model(&models.Specification{}).
+		Select("phone_id, MIN(id) as spec_id").
+		Group("phone_id")
+	rearCamera := db.Model(&models.CameraModule{}).
+		Select("specification_id, MAX(resolution_mp) as camera_mp").
+		Where("position = ?", "rear").
+		Group("specification_id")
+	if phoneIDs != nil {
+		firstSpec = firstSpec.Where("phone_id IN ?", phoneIDs)
+		rearCamera = rearCamera.Where("specification_id IN (?)",
+			db.Model(&models.Specification{}).Select("id").Where("phone_id IN ?", phoneIDs))
+	}
+
+	return db.Table("phones").
+		Select(`phones.id as phone_id,
+				phones.price,
+				COALESCE(specifications.memory_gb, 0) as memory_gb,
+				COALESCE(specifications.storage_gb, 0) as storage_gb,
+				COALESCE(rear_camera.camera_mp, 0) as camera_mp,
+				specifications.battery_mah,
+				COALESCE(specifications.operating_system, '') as operating_system,
+				CASE WHEN specifications.id IS NULL THEN 0 ELSE 1 END as has_spec`).
+		Joins("LEFT JOIN (?) as first_spec on first_spec.phone_id = phones.id", firstSpec).
+		Joins("LEFT JOIN specifications on specifications.id = first_spec.spec_id").
+		Joins("LEFT JOIN (?) as rear_camera on rear_camera.specification_id = specifications.id", rearCamera)
+}
+
+// normalize nilai ke 0 - 1 dgn skala log di antara batas fitur,
+// nil jika nilai tidak diketahui (0)
+func normalize(name string, value float64) *float64 {
+	if value <= 0 {
+		return nil
+	}
+	low, high := math.Log(bounds[name][0]), math.Log(bounds[name][1])
+	result := (math.Log(value) - low) / (high - low)
+	result = math.Round(math.Min(math.Max(result, 0), 1)*10000) / 10000
+	return &result
+}
+
+// OSFamily nama os tanpa versi dalam huruf kecil, contoh "Android 14" -> "android"
+func OSFamily(operatingSystem string) string {
+	fields := strings.Fields(strings.ToLower(operatingSystem))
+	if len(fields) == 0 {
+		return ""
+	}
+	family := fields[0]
+	if len(family) > 50 {
+		family = family[:50]
+	}
+	return family
+}
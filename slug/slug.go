@@ -0,0 +1,223 @@
+package slug
+
+import (
+	"errors"
+	"final-project/models"
+	"final-project/utils"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+const (
+	KindPhone = "phone"
+	KindBrand = "brand"
+)
+
+var tables = map[string]string{
+	KindPhone: "phones",
+	KindBrand: "brands",
+}
+
+// slug yg bentrok dgn route statis (contoh /phones/compare)
+var reserved = map[string][]string{
+	KindPhone: {"compare", "calendar"},
+	KindBrand: {"tree"},
+}
+
+// Generate buat slug unik dari teks, exceptID id data yg sedang diupdate
+// (0 untuk data baru). slug yg sudah dipakai diberi akhiran -2, -3, dst
+func Generate(db *gorm.DB, kind, text string, exceptID uint) (string, error) {
+	base := baseSlug(kind, text)
+	candidate := base
+	for i := 2; ; i++ {
+		taken, err := isTaken(db, kind, candidate, exceptID)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// baseSlug slug dari teks sebelum diberi akhiran angka
+func baseSlug(kind, text string) string {
+	base := utils.Slugify(text)
+	if base == "" {
+		return kind
+	}
+	// slug angka saja tidak bisa dibedakan dari id
+	if _, err := strconv.Atoi(base); err == nil {
+		return kind + "-" + base
+	}
+	return base
+}
+
+// slug lama milik data lain juga dianggap terpakai agar redirect tetap benar
+func isTaken(db *gorm.DB, kind, slug string, exceptID uint) (bool, error) {
+	if slices.Contains(reserved[kind], slug) {
+		return true, nil
+	}
+
+	var count int64
+	if err := db.Table(tables[kind]).Where("slug = ? AND id <> ?", slug, exceptID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	if err := db.Model(&models.SlugHistory{}).
+		Where("kind = ? AND slug = ? AND target_id <> ?", kind, slug, exceptID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Rename simpan slug lama ke history agar url lama bisa di-redirect ke slug baru
+func Rename(db *gorm.DB, kind string, id uint, oldSlug, newSlug string) error {
+	if oldSlug == newSlug {
+		return nil
+	}
+
+	// slug baru bisa saja slug lama data ini sendiri
+	if err := db.Where("kind = ? AND slug = ?", kind, newSlug).Delete(&models.SlugHistory{}).Error; err != nil {
+		return err
+	}
+	if oldSlug == "" {
+		return nil
+	}
+
+	return db.Create(&models.SlugHistory{Kind: kind, Slug: oldSlug, TargetID: id}).Error
+}
+
+// Forget hapus history slug data yg dihapus
+func Forget(db *gorm.DB, kind string, id uint) error {
+	return db.Where("kind = ? AND target_id = ?", kind, id).Delete(&models.SlugHistory{}).Error
+}
+
+// Merge alihkan slug & id data yg digabung (source) ke data tujuan (target),
+// termasuk slug lama source & id data lain yg sebelumnya digabung ke source
+func Merge(db *gorm.DB, kind string, sourceID uint, sourceSlug string, targetID uint) error {
+	if err := db.Model(&models.SlugHistory{}).
+		Where("kind = ? AND target_id = ?", kind, sourceID).
+		Update("target_id", targetID).Error; err != nil {
+		return err
+	}
+
+	// id source disimpan sebagai slug angka, slug asli tidak pernah berupa angka saja
+	redirects := []string{strconv.Itoa(int(sourceID))}
+	if sourceSlug != "" {
+		redirects = append(redirects, sourceSlug)
+	}
+	for _, value := range redirects {
+		if err := db.Create(&models.SlugHistory{Kind: kind, Slug: value, TargetID: targetID}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResolveMerged cari id data tujuan dari id data yg sudah digabung, current
+// berisi slug terbaru data tujuan. dipanggil untuk setiap request dgn id
+// angka, id yg tidak pernah digabung (kasus umum) mengembalikan
+// gorm.ErrRecordNotFound tanpa dicatat sebagai error oleh logger gorm
+func ResolveMerged(db *gorm.DB, kind string, id string) (uint, string, error) {
+	var history models.SlugHistory
+	result := db.Where("kind = ? AND slug = ?", kind, id).Limit(1).Find(&history)
+	if result.Error != nil {
+		return 0, "", result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, "", gorm.ErrRecordNotFound
+	}
+
+	var row struct {
+		ID   uint
+		Slug string
+	}
+	result = db.Table(tables[kind]).Select("id, slug").Where("id = ?", history.TargetID).Limit(1).Find(&row)
+	if result.Error != nil {
+		return 0, "", result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, "", gorm.ErrRecordNotFound
+	}
+	return row.ID, row.Slug, nil
+}
+
+// Resolve cari id data dari slug terbaru atau slug lama, current berisi
+// slug terbaru (berbeda dgn value jika value slug lama)
+func Resolve(db *gorm.DB, kind, value string) (uint, string, error) {
+	var row struct {
+		ID   uint
+		Slug string
+	}
+
+	err := db.Table(tables[kind]).Select("id, slug").Where("slug = ?", value).Take(&row).Error
+	if err == nil {
+		return row.ID, row.Slug, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, "", err
+	}
+
+	var history models.SlugHistory
+	if err := db.Where("kind = ? AND slug = ?", kind, value).First(&history).Error; err != nil {
+		return 0, "", err
+	}
+	if err := db.Table(tables[kind]).Select("id, slug").Where("id = ?", history.TargetID).Take(&row).Error; err != nil {
+		return 0, "", err
+	}
+	return row.ID, row.Slug, nil
+}
+
+// Backfill isi slug brand & phone lama yg belum punya slug
+func Backfill(db *gorm.DB) error {
+	var brands []models.Brand
+	if err := db.Select("id", "name").
+		Where("slug IS NULL OR slug = ''").
+		Order("id ASC").
+		Find(&brands).Error; err != nil {
+		return err
+	}
+	for _, brand := range brands {
+		value, err := Generate(db, KindBrand, brand.Name, brand.ID)
+		if err != nil {
+			return err
+		}
+		if err := db.Model(&brand).UpdateColumn("slug", value).Error; err != nil {
+			return err
+		}
+	}
+
+	var phones []struct {
+		ID        uint
+		BrandName string
+		Model     string
+	}
+	if err := db.Table("phones").
+		Select("phones.id, brands.name as brand_name, phones.model").
+		Joins("JOIN brands on brands.id = phones.brand_id").
+		Where("phones.slug IS NULL OR phones.slug = ''").
+		Order("phones.id ASC").
+		Scan(&phones).Error; err != nil {
+		return err
+	}
+	for _, phone := range phones {
+		value, err := Generate(db, KindPhone, phone.BrandName+" "+phone.Model, phone.ID)
+		if err != nil {
+			return err
+		}
+		if err := db.Model(&models.Phone{}).Where("id = ?", phone.ID).UpdateColumn("slug", value).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
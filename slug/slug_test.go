@@ -0,0 +1,26 @@
+package slug
+
+import "testing"
+
+func TestBaseSlug(t *testing.T) {
+	tests := []struct {
+		name string
+		kind string
+		text string
+		want string
+	}{
+		{"nama biasa", KindPhone, "Galaxy S24 Ultra", "galaxy-s24-ultra"},
+		{"karakter khusus", KindBrand, "  Sony (Xperia)! ", "sony-xperia"},
+		{"teks kosong", KindPhone, "!!!", "phone"},
+		{"angka saja", KindPhone, "2024", "phone-2024"},
+		{"angka brand", KindBrand, "3310", "brand-3310"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := baseSlug(tt.kind, tt.text); got != tt.want {
+				t.Errorf("baseSlug(%q, %q) = %q, want %q", tt.kind, tt.text, got, tt.want)
+			}
+		})
+	}
+}
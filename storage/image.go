@@ -0,0 +1,248 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"final-project/utils"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	// decoder format gambar yg diterima
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+var (
+	ErrImageTooLarge      = errors.New("ukuran gambar melebihi batas maksimal")
+	ErrImageUnsupported   = errors.New("format gambar harus jpeg, png, gif atau webp")
+	ErrImageTooManyPixels = errors.New("resolusi gambar melebihi batas maksimal")
+)
+
+// MaxImagePixels batas width x height gambar yg boleh di-decode, file kecil
+// (decompression bomb) bisa berisi gambar raksasa yg menghabiskan memori
+const MaxImagePixels = 50_000_000
+
+// ukuran thumbnail (sisi terpanjang dalam px), selalu disimpan sebagai jpeg
+var ThumbnailSizes = map[string]int{
+	"small":  150,
+	"medium": 400,
+	"large":  800,
+}
+
+var imageExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+type UploadedImage struct {
+	URL         string            `json:"url"`
+	ContentType string            `json:"content_type"`
+	Size        int64             `json:"size"`
+	Width       int               `json:"width"`
+	Height      int               `json:"height"`
+	Thumbnails  map[string]string `json:"thumbnails"`
+}
+
+// MaxImageSize batas ukuran upload gambar dalam byte (env UPLOAD_MAX_SIZE_MB)
+func MaxImageSize() int64 {
+	mb, err := strconv.Atoi(utils.GetEnv("UPLOAD_MAX_SIZE_MB", "5"))
+	if err != nil || mb <= 0 {
+		mb = 5
+	}
+	return int64(mb) << 20
+}
+
+// SaveImage validasi file gambar dari form multipart, menyimpan file asli
+// (tanpa metadata EXIF) & thumbnail-nya ke store di bawah folder dir. key berasal
+// dari hash isi file sehingga url yg dihasilkan stabil untuk file yg sama
+func SaveImage(ctx context.Context, store Storage, dir string, file *multipart.FileHeader) (*UploadedImage, error) {
+	if file.Size > MaxImageSize() {
+		return nil, ErrImageTooLarge
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	// baca maksimal batas + 1 byte untuk mendeteksi file yg melebihi batas
+	data, err := io.ReadAll(io.LimitReader(src, MaxImageSize()+1))
+	if err != nil {
+		return nil, err
+	}
+
+	return SaveImageBytes(ctx, store, dir, data)
+}
+
+// SaveImageBytes sama seperti SaveImage untuk isi file yg sudah dibaca
+func SaveImageBytes(ctx context.Context, store Storage, dir string, data []byte) (*UploadedImage, error) {
+	if int64(len(data)) > MaxImageSize() {
+		return nil, ErrImageTooLarge
+	}
+
+	// tipe file dideteksi dari isi, bukan dari header / ekstensi yg dikirim client
+	contentType := http.DetectContentType(data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, ErrImageUnsupported
+	}
+
+	// resolusi dicek dari header sebelum gambar di-decode
+	if err := checkImagePixels(data); err != nil {
+		return nil, err
+	}
+
+	// metadata (lokasi GPS, perangkat, dst) tidak ikut disimpan
+	data, err := StripMetadata(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrImageUnsupported
+	}
+
+	sum := sha256.Sum256(data)
+	prefix := path.Join(dir, hex.EncodeToString(sum[:])[:20])
+
+	url, err := store.Put(ctx, prefix+"/original."+ext, bytes.NewReader(data), int64(len(data)), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	uploaded := &UploadedImage{
+		URL:         url,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+		Thumbnails:  map[string]string{},
+	}
+
+	for name, size := range ThumbnailSizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resize(img, size), &jpeg.Options{Quality: 85}); err != nil {
+			return nil, err
+		}
+		thumbURL, err := store.Put(ctx, prefix+"/"+name+".jpg", &buf, int64(buf.Len()), "image/jpeg")
+		if err != nil {
+			return nil, err
+		}
+		uploaded.Thumbnails[name] = thumbURL
+	}
+
+	return uploaded, nil
+}
+
+// ThumbnailURLs url thumbnail dari url gambar hasil SaveImage,
+// nil jika url berasal dari luar (external url)
+func ThumbnailURLs(store Storage, url string) map[string]string {
+	prefix, ok := imagePrefix(store, url)
+	if !ok {
+		return nil
+	}
+
+	thumbnails := map[string]string{}
+	for name := range ThumbnailSizes {
+		thumbnails[name] = store.URL(prefix + "/" + name + ".jpg")
+	}
+	return thumbnails
+}
+
+// DeleteImage menghapus gambar hasil SaveImage beserta thumbnail-nya,
+// url dari luar (external url) diabaikan
+func DeleteImage(ctx context.Context, store Storage, url string) error {
+	prefix, ok := imagePrefix(store, url)
+	if !ok {
+		return nil
+	}
+
+	key, _ := store.Key(url)
+	if err := store.Delete(ctx, key); err != nil {
+		return err
+	}
+	for name := range ThumbnailSizes {
+		if err := store.Delete(ctx, prefix+"/"+name+".jpg"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkImagePixels tolak gambar yg resolusinya melebihi MaxImagePixels
+// hanya dari header, tanpa decode seluruh gambar
+func checkImagePixels(data []byte) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return ErrImageUnsupported
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return ErrImageUnsupported
+	}
+	if int64(config.Width)*int64(config.Height) > MaxImagePixels {
+		return ErrImageTooManyPixels
+	}
+	return nil
+}
+
+func imagePrefix(store Storage, url string) (string, bool) {
+	key, ok := store.Key(url)
+	if !ok || !strings.HasPrefix(path.Base(key), "original.") {
+		return "", false
+	}
+	return path.Dir(key), true
+}
+
+// resize mengecilkan gambar agar sisi terpanjang maksimal size px (tidak
+// memperbesar), latar transparan diganti putih karena thumbnail berformat jpeg
+func resize(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w > size || h > size {
+		if w >= h {
+			w, h = size, max(1, h*size/w)
+		} else {
+			w, h = max(1, w*size/h), size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+	return dst
+}
+
+// IsImageError true jika err disebabkan file dari client (bukan error server)
+func IsImageError(err error) bool {
+	return errors.Is(err, ErrImageTooLarge) || errors.Is(err, ErrImageUnsupported) ||
+		errors.Is(err, ErrImageTooManyPixels)
+}
+
+// ImageErrorMessage pesan error upload untuk client
+func ImageErrorMessage(err error) string {
+	if errors.Is(err, ErrImageTooLarge) {
+		return fmt.Sprintf("%s (%d MB)", err.Error(), MaxImageSize()>>20)
+	}
+	if errors.Is(err, ErrImageTooManyPixels) {
+		return fmt.Sprintf("%s (%d megapixel)", err.Error(), MaxImagePixels/1_000_000)
+	}
+	return err.Error()
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSaveImageBytesThumbnails(t *testing.T) {
+	dir := t.TempDir()
+	store := &LocalStorage{BaseDir: dir, BaseURL: "http://localhost/uploads"}
+
+	uploaded, err := SaveImageBytes(context.Background(), store, "phones", testPNG(t, 1000, 500))
+	if err != nil {
+		t.Fatalf("SaveImageBytes: %v", err)
+	}
+	if uploaded.Width != 1000 || uploaded.Height != 500 || uploaded.ContentType != "image/png" {
+		t.Errorf("uploaded = %+v", uploaded)
+	}
+
+	key, ok := store.Key(uploaded.URL)
+	if !ok || !strings.HasPrefix(key, "phones/") || filepath.Base(key) != "original.png" {
+		t.Fatalf("key original = %q", key)
+	}
+	prefix := filepath.Dir(key)
+
+	// url thumbnail hasil upload harus sama dgn yg diturunkan dari url gambar
+	derived := ThumbnailURLs(store, uploaded.URL)
+	if len(derived) != len(ThumbnailSizes) {
+		t.Fatalf("ThumbnailURLs = %v", derived)
+	}
+	for name, size := range ThumbnailSizes {
+		want := store.URL(prefix + "/" + name + ".jpg")
+		if uploaded.Thumbnails[name] != want || derived[name] != want {
+			t.Errorf("thumbnail %s = %q / %q, want %q", name, uploaded.Thumbnails[name], derived[name], want)
+		}
+
+		file, err := os.Open(filepath.Join(dir, prefix, name+".jpg"))
+		if err != nil {
+			t.Fatalf("thumbnail %s tidak tersimpan: %v", name, err)
+		}
+		config, format, err := image.DecodeConfig(file)
+		file.Close()
+		if err != nil || format != "jpeg" || config.Width != size || config.Height != size/2 {
+			t.Errorf("thumbnail %s = %s %dx%d (%v)", name, format, config.Width, config.Height, err)
+		}
+	}
+
+	if err := DeleteImage(context.Background(), store, uploaded.URL); err != nil {
+		t.Fatalf("DeleteImage: %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Join(dir, prefix))
+	if len(entries) != 0 {
+		t.Errorf("file tersisa setelah DeleteImage: %d", len(entries))
+	}
+}
+
+func TestThumbnailURLsExternal(t *testing.T) {
+	store := &LocalStorage{BaseDir: t.TempDir(), BaseURL: "http://localhost/uploads"}
+	if thumbnails := ThumbnailURLs(store, "https://example.com/phone.png"); thumbnails != nil {
+		t.Errorf("url luar harus nil, got %v", thumbnails)
+	}
+	// file yg bukan original hasil SaveImage tidak punya thumbnail
+	if thumbnails := ThumbnailURLs(store, "http://localhost/uploads/phones/abc/small.jpg"); thumbnails != nil {
+		t.Errorf("thumbnail dari thumbnail harus nil, got %v", thumbnails)
+	}
+}
+
+func TestSaveImageBytesRejectsTooManyPixels(t *testing.T) {
+	// header png dgn resolusi raksasa, isi gambar tidak perlu valid karena
+	// harus ditolak sebelum di-decode
+	data := testPNG(t, 1, 1)
+	binary.BigEndian.PutUint32(data[16:], 100_000)
+	binary.BigEndian.PutUint32(data[20:], 100_000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+	store := &LocalStorage{BaseDir: t.TempDir(), BaseURL: "http://localhost/uploads"}
+	_, err := SaveImageBytes(context.Background(), store, "profiles", data)
+	if !errors.Is(err, ErrImageTooManyPixels) {
+		t.Errorf("err = %v, want ErrImageTooManyPixels", err)
+	}
+	if !IsImageError(err) {
+		t.Errorf("IsImageError harus true")
+	}
+}
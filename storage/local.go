@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage menyimpan file di filesystem lokal, file disajikan oleh
+// router lewat BaseURL (lihat routes.SetupRouter)
+type LocalStorage struct {
+	BaseDir string
+	BaseURL string
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+
+	// tulis ke file sementara dulu agar file yg setengah jadi tidak pernah tersaji
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+
+	return s.URL(key), nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return joinURL(s.BaseURL, key)
+}
+
+func (s *LocalStorage) Key(url string) (string, bool) {
+	return trimURL(s.BaseURL, url)
+}
+
+// path mencegah key keluar dari BaseDir (contoh "../../etc/passwd")
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if strings.Contains(clean, "..") || clean == "/" {
+		return "", errors.New("key file tidak valid")
+	}
+	return filepath.Join(s.BaseDir, filepath.FromSlash(clean)), nil
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStoragePutAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	store := &LocalStorage{BaseDir: dir, BaseURL: "http://localhost:8080/uploads/"}
+
+	url, err := store.Put(context.Background(), "phones/abc/original.jpg", strings.NewReader("data"), 4, "image/jpeg")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if url != "http://localhost:8080/uploads/phones/abc/original.jpg" {
+		t.Errorf("Put url = %q", url)
+	}
+
+	path := filepath.Join(dir, "phones", "abc", "original.jpg")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("file tidak tersimpan: %v", err)
+	}
+	if string(content) != "data" {
+		t.Errorf("isi file = %q", content)
+	}
+
+	// file sementara tidak boleh tertinggal
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("jumlah file = %d, want 1", len(entries))
+	}
+
+	if err := store.Delete(context.Background(), "phones/abc/original.jpg"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("file masih ada setelah Delete")
+	}
+	// hapus file yg tidak ada bukan error
+	if err := store.Delete(context.Background(), "phones/abc/original.jpg"); err != nil {
+		t.Errorf("Delete file yg tidak ada: %v", err)
+	}
+}
+
+func TestLocalStoragePathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "uploads")
+	store := &LocalStorage{BaseDir: base, BaseURL: "http://localhost/uploads"}
+
+	// ".." dibersihkan sehingga file tetap berada di dalam BaseDir
+	for _, key := range []string{"../secret.txt", "phones/../../secret.txt"} {
+		if _, err := store.Put(context.Background(), key, strings.NewReader("x"), 1, "text/plain"); err != nil {
+			t.Fatalf("Put(%q): %v", key, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "secret.txt")); !os.IsNotExist(err) {
+		t.Errorf("file tertulis di luar BaseDir")
+	}
+	if _, err := os.Stat(filepath.Join(base, "secret.txt")); err != nil {
+		t.Errorf("file tidak tersimpan di BaseDir: %v", err)
+	}
+
+	for _, key := range []string{"/", ""} {
+		if _, err := store.Put(context.Background(), key, strings.NewReader("x"), 1, "text/plain"); err == nil {
+			t.Errorf("Put(%q) harus error", key)
+		}
+	}
+}
+
+func TestLocalStorageKey(t *testing.T) {
+	store := &LocalStorage{BaseDir: t.TempDir(), BaseURL: "http://localhost/uploads/"}
+
+	tests := []struct {
+		url string
+		key string
+		ok  bool
+	}{
+		{"http://localhost/uploads/phones/abc/original.png", "phones/abc/original.png", true},
+		{"http://localhost/uploads-other/phones/abc.png", "", false},
+		{"https://example.com/uploads/phones/abc.png", "", false},
+	}
+	for _, tt := range tests {
+		key, ok := store.Key(tt.url)
+		if key != tt.key || ok != tt.ok {
+			t.Errorf("Key(%q) = %q, %v, want %q, %v", tt.url, key, ok, tt.key, tt.ok)
+		}
+	}
+
+	if key, _ := store.Key(store.URL("brands/logo.png")); key != "brands/logo.png" {
+		t.Errorf("Key(URL(key)) = %q", key)
+	}
+}
@@ -0,0 +1,235 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+)
+
+// StripMetadata hapus metadata gambar (EXIF, XMP, IPTC, komentar) yg bisa
+// berisi lokasi GPS, perangkat & waktu pengambilan foto. jpeg dgn orientasi
+// EXIF diputar dulu agar tampilannya tetap sama setelah EXIF dihapus
+func StripMetadata(data []byte, contentType string) ([]byte, error) {
+	switch contentType {
+	case "image/jpeg":
+		return stripJPEG(data)
+	case "image/png":
+		return stripPNG(data), nil
+	case "image/webp":
+		return stripWebP(data), nil
+	default:
+		// gif tidak menyimpan EXIF
+		return data, nil
+	}
+}
+
+// segment APPn yg dipertahankan: JFIF (APP0), profil warna ICC (APP2) & Adobe (APP14)
+var jpegKeptSegments = map[byte]bool{0xE0: true, 0xE2: true, 0xEE: true}
+
+func stripJPEG(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return data, nil
+	}
+
+	var out bytes.Buffer
+	out.Write(data[:2])
+	orientation := 1
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			// struktur tidak dikenali, sisa file disalin apa adanya
+			out.Write(data[i:])
+			return finishJPEG(out.Bytes(), orientation)
+		}
+		marker := data[i+1]
+		// padding 0xFF sebelum marker
+		if marker == 0xFF {
+			i++
+			continue
+		}
+		// marker tanpa panjang
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			out.Write(data[i : i+2])
+			i += 2
+			continue
+		}
+		// start of scan, data gambar sampai akhir file
+		if marker == 0xDA {
+			out.Write(data[i:])
+			return finishJPEG(out.Bytes(), orientation)
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			out.Write(data[i:])
+			return finishJPEG(out.Bytes(), orientation)
+		}
+
+		isMetadata := (marker >= 0xE0 && marker <= 0xEF && !jpegKeptSegments[marker]) || marker == 0xFE
+		if marker == 0xE1 {
+			if value, ok := exifOrientation(data[i+4 : end]); ok {
+				orientation = value
+			}
+		}
+		if !isMetadata {
+			out.Write(data[i:end])
+		}
+		i = end
+	}
+	out.Write(data[i:])
+	return finishJPEG(out.Bytes(), orientation)
+}
+
+// finishJPEG putar gambar sesuai orientasi EXIF yg sudah dihapus
+func finishJPEG(data []byte, orientation int) ([]byte, error) {
+	if orientation < 2 || orientation > 8 {
+		return data, nil
+	}
+
+	if err := checkImagePixels(data); err != nil {
+		return nil, err
+	}
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrImageUnsupported
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, orient(img, orientation), &jpeg.Options{Quality: 92}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// exifOrientation baca tag orientasi (0x0112) dari IFD0 segment APP1 EXIF
+func exifOrientation(segment []byte) (int, bool) {
+	if len(segment) < 14 || string(segment[:6]) != "Exif\x00\x00" {
+		return 0, false
+	}
+	tiff := segment[6:]
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, false
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset+2 > len(tiff) {
+		return 0, false
+	}
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 0, false
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8 : entry+10])), true
+		}
+	}
+	return 0, false
+}
+
+// orient ubah gambar sesuai nilai orientasi EXIF (2 - 8)
+func orient(src image.Image, orientation int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			dst.Set(x, y, src.At(bounds.Min.X+sx, bounds.Min.Y+sy))
+		}
+	}
+	return dst
+}
+
+// chunk png yg berisi metadata
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+func stripPNG(data []byte) []byte {
+	if len(data) < 8 {
+		return data
+	}
+
+	var out bytes.Buffer
+	out.Write(data[:8])
+	i := 8
+	for i+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[i : i+4]))
+		end := i + 12 + length
+		if end > len(data) {
+			break
+		}
+		if !pngMetadataChunks[string(data[i+4:i+8])] {
+			out.Write(data[i:end])
+		}
+		i = end
+	}
+	out.Write(data[i:])
+	return out.Bytes()
+}
+
+func stripWebP(data []byte) []byte {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return data
+	}
+
+	var body bytes.Buffer
+	i := 12
+	for i+8 <= len(data) {
+		fourCC := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4 : i+8]))
+		end := i + 8 + size + size%2
+		if end > len(data) {
+			end = len(data)
+		}
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte{}, data[i:end]...)
+			if len(chunk) > 8 {
+				// hapus flag EXIF (0x08) & XMP (0x04)
+				chunk[8] &^= 0x08 | 0x04
+			}
+			body.Write(chunk)
+		default:
+			body.Write(data[i:end])
+		}
+		i = end
+	}
+
+	out := make([]byte, 12, 12+body.Len())
+	copy(out, data[:12])
+	binary.LittleEndian.PutUint32(out[4:8], uint32(4+body.Len()))
+	return append(out, body.Bytes()...)
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeS3 server S3 lokal sederhana (path-style) untuk test
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+	auth    []string
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	fake := &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+
+		fake.auth = append(fake.auth, r.Header.Get("Authorization"))
+		if r.Header.Get("X-Amz-Date") == "" || r.Header.Get("X-Amz-Content-Sha256") != "UNSIGNED-PAYLOAD" {
+			http.Error(w, "missing amz headers", http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			fake.objects[r.URL.Path] = body
+			fake.types[r.URL.Path] = r.Header.Get("Content-Type")
+		case http.MethodDelete:
+			delete(fake.objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func TestS3StoragePutAndDelete(t *testing.T) {
+	fake, server := newFakeS3(t)
+	store := &S3Storage{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    "phones-bucket",
+		AccessKey: "AKIDTEST",
+		SecretKey: "secret",
+		Client:    server.Client(),
+	}
+
+	url, err := store.Put(context.Background(), "phones/abc/original.png", strings.NewReader("png"), 3, "image/png")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if url != server.URL+"/phones-bucket/phones/abc/original.png" {
+		t.Errorf("Put url = %q", url)
+	}
+	if got := string(fake.objects["/phones-bucket/phones/abc/original.png"]); got != "png" {
+		t.Errorf("isi object = %q", got)
+	}
+	if got := fake.types["/phones-bucket/phones/abc/original.png"]; got != "image/png" {
+		t.Errorf("content type = %q", got)
+	}
+
+	auth := fake.auth[0]
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDTEST/") ||
+		!strings.Contains(auth, "/us-east-1/s3/aws4_request") ||
+		!strings.Contains(auth, "SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date") {
+		t.Errorf("Authorization = %q", auth)
+	}
+
+	if err := store.Delete(context.Background(), "phones/abc/original.png"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := fake.objects["/phones-bucket/phones/abc/original.png"]; ok {
+		t.Errorf("object masih ada setelah Delete")
+	}
+}
+
+func TestS3StorageErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	store := &S3Storage{Endpoint: server.URL, Region: "us-east-1", Bucket: "b", Client: server.Client()}
+	_, err := store.Put(context.Background(), "a.png", strings.NewReader("x"), 1, "image/png")
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Put error = %v, want AccessDenied", err)
+	}
+}
+
+func TestS3StorageKey(t *testing.T) {
+	store := &S3Storage{Endpoint: "http://minio:9000", Bucket: "phones"}
+	if key, ok := store.Key("http://minio:9000/phones/brands/a/original.png"); !ok || key != "brands/a/original.png" {
+		t.Errorf("Key = %q, %v", key, ok)
+	}
+	if _, ok := store.Key("http://minio:9000/other/brands/a/original.png"); ok {
+		t.Errorf("Key bucket lain harus false")
+	}
+
+	// PublicURL (contoh CDN) dipakai untuk URL & Key
+	store.PublicURL = "https://cdn.example.com/"
+	if url := store.URL("brands/a/original.png"); url != "https://cdn.example.com/brands/a/original.png" {
+		t.Errorf("URL = %q", url)
+	}
+	if key, ok := store.Key("https://cdn.example.com/brands/a/original.png"); !ok || key != "brands/a/original.png" {
+		t.Errorf("Key = %q, %v", key, ok)
+	}
+	if _, ok := store.Key("http://minio:9000/phones/brands/a/original.png"); ok {
+		t.Errorf("Key endpoint harus false jika PublicURL diisi")
+	}
+}
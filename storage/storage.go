@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"final-project/utils"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Storage tempat penyimpanan file upload (gambar phone, logo brand, foto profile)
+type Storage interface {
+	// Put menyimpan file dengan key (path relatif, contoh "phones/abc.jpg")
+	// dan mengembalikan url publik file tsb
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error)
+	Delete(ctx context.Context, key string) error
+	// URL url publik dari key, tidak mengecek apakah file ada
+	URL(key string) string
+	// Key kebalikan dari URL, false jika url bukan milik storage ini
+	Key(url string) (string, bool)
+}
+
+// New membuat storage sesuai env STORAGE_DRIVER (local || s3)
+func New() Storage {
+	switch utils.GetEnv("STORAGE_DRIVER", "local") {
+	case "s3":
+		return &S3Storage{
+			Endpoint:  utils.GetEnv("S3_ENDPOINT", "https://s3.amazonaws.com"),
+			Region:    utils.GetEnv("S3_REGION", "us-east-1"),
+			Bucket:    utils.GetEnv("S3_BUCKET", ""),
+			AccessKey: utils.GetEnv("S3_ACCESS_KEY", ""),
+			SecretKey: utils.GetEnv("S3_SECRET_KEY", ""),
+			PublicURL: utils.GetEnv("STORAGE_BASE_URL", ""),
+			Client:    http.DefaultClient,
+		}
+	case "local":
+		return &LocalStorage{
+			BaseDir: utils.GetEnv("STORAGE_LOCAL_DIR", "./uploads"),
+			BaseURL: utils.StorageBaseURL(),
+		}
+	default:
+		log.Fatal("STORAGE_DRIVER tidak dikenali, gunakan local atau s3")
+		return nil
+	}
+}
+
+func joinURL(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(key, "/")
+}
+
+func trimURL(base, url string) (string, bool) {
+	prefix := strings.TrimRight(base, "/") + "/"
+	if base == "" || !strings.HasPrefix(url, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(url, prefix), true
+}
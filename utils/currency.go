@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// BaseCurrency mata uang harga utama phone (Phone.Price) & acuan kurs
+const BaseCurrency = "IDR"
+
+// CurrencyMinorUnits jumlah digit minor unit tiap mata uang (ISO 4217)
+var CurrencyMinorUnits = map[string]int{
+	"IDR": 2,
+	"MYR": 2,
+	"SGD": 2,
+	"USD": 2,
+	"THB": 2,
+	"PHP": 2,
+	"VND": 0,
+	"INR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"CNY": 2,
+	"GBP": 2,
+	"EUR": 2,
+	"AUD": 2,
+}
+
+// RegionCurrencies mata uang bawaan tiap region (ISO 3166-1 alpha-2)
+var RegionCurrencies = map[string]string{
+	"ID": "IDR",
+	"MY": "MYR",
+	"SG": "SGD",
+	"US": "USD",
+	"TH": "THB",
+	"PH": "PHP",
+	"VN": "VND",
+	"IN": "INR",
+	"JP": "JPY",
+	"KR": "KRW",
+	"CN": "CNY",
+	"GB": "GBP",
+	"DE": "EUR",
+	"FR": "EUR",
+	"AU": "AUD",
+}
+
+// Money nilai uang dalam minor unit (contoh sen), Amount 129999 USD = 1299.99
+type Money struct {
+	Currency  string `json:"currency"`
+	Amount    int64  `json:"amount"`
+	MinorUnit int    `json:"minor_unit"`
+	// nilai desimal dalam major unit, contoh "1299.99"
+	Display string `json:"display"`
+}
+
+// NewMoney buat Money dari amount minor unit
+func NewMoney(currency string, amount int64) Money {
+	return Money{
+		Currency:  currency,
+		Amount:    amount,
+		MinorUnit: CurrencyMinorUnits[currency],
+		Display:   FormatMinorUnits(amount, currency),
+	}
+}
+
+// ParseMinorUnits parse angka desimal major unit ke minor unit sesuai mata uang,
+// contoh ("1299.99", "USD") -> 129999. digit desimal melebihi minor unit ditolak
+func ParseMinorUnits(value, currency string) (int64, error) {
+	digits, ok := CurrencyMinorUnits[currency]
+	if !ok {
+		return 0, errors.New("currency tidak dikenal")
+	}
+
+	value = strings.TrimSpace(value)
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" || strings.HasPrefix(whole, "-") || strings.HasPrefix(whole, "+") {
+		return 0, errors.New("harga harus berupa angka positif")
+	}
+	if len(fraction) > digits {
+		return 0, errors.New("harga " + currency + " maksimal " + strconv.Itoa(digits) + " digit desimal")
+	}
+	fraction += strings.Repeat("0", digits-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, errors.New("harga harus berupa angka positif")
+	}
+	return amount, nil
+}
+
+// FormatMinorUnits format minor unit ke angka desimal major unit, contoh (129999, "USD") -> "1299.99"
+func FormatMinorUnits(amount int64, currency string) string {
+	digits := CurrencyMinorUnits[currency]
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	text := strconv.FormatInt(amount, 10)
+	if digits == 0 {
+		return sign + text
+	}
+	if len(text) <= digits {
+		text = strings.Repeat("0", digits-len(text)+1) + text
+	}
+	return sign + text[:len(text)-digits] + "." + text[len(text)-digits:]
+}
+
+// ConvertMinorUnits konversi amount minor unit antar mata uang, rate adalah
+// nilai 1 major unit mata uang dalam BaseCurrency
+func ConvertMinorUnits(amount int64, from string, fromRate float64, to string, toRate float64) int64 {
+	major := float64(amount) / math.Pow10(CurrencyMinorUnits[from])
+	converted := major * fromRate / toRate
+	return int64(math.Round(converted * math.Pow10(CurrencyMinorUnits[to])))
+}
@@ -8,3 +8,8 @@ func GetEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// StorageBaseURL base url publik file upload
+func StorageBaseURL() string {
+	return GetEnv("STORAGE_BASE_URL", "http://"+GetEnv("API_HOST", "localhost:8080")+"/uploads")
+}
@@ -9,6 +9,11 @@ import (
 )
 
 func IsValidUrl(str string) bool {
+	// url file hasil upload ke storage sendiri selalu valid
+	if strings.HasPrefix(str, strings.TrimRight(StorageBaseURL(), "/")+"/") {
+		return true
+	}
+
 	u, err := url.Parse(str)
 	if err != nil || u.Scheme == "" || u.Host == "" {
 		return false
@@ -41,6 +46,8 @@ func CustomBindError(err error) string {
 				errorMessages = append(errorMessages, fmt.Sprintf("Field %s harus memiliki nilai minimal %s", err.Field(), err.Param()))
 			case "max":
 				errorMessages = append(errorMessages, fmt.Sprintf("Field %s harus memiliki nilai maksimal %s", err.Field(), err.Param()))
+			case "oneof":
+				errorMessages = append(errorMessages, fmt.Sprintf("Field %s harus salah satu dari: %s", err.Field(), err.Param()))
 			default:
 				errorMessages = append(errorMessages, fmt.Sprintf("Field %s invalid", err.Field()))
 			}
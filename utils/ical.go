@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"strings"
+)
+
+var icalTextReplacer = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// ICalText escape teks untuk nilai property iCalendar (RFC 5545)
+func ICalText(text string) string {
+	return icalTextReplacer.Replace(text)
+}
+
+// ICalLine satu baris content iCalendar, dipotong per 75 byte dan diakhiri CRLF
+func ICalLine(name, value string) string {
+	line := name + ":" + value
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		// baris lanjutan diawali spasi yg ikut dihitung
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+
+	return b.String()
+}
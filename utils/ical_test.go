@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestICalText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Galaxy S25", "Galaxy S25"},
+		{"a, b; c", `a\, b\; c`},
+		{`C:\path`, `C:\\path`},
+		{"baris 1\nbaris 2\r\nbaris 3", `baris 1\nbaris 2\nbaris 3`},
+	}
+	for _, tt := range tests {
+		if got := ICalText(tt.text); got != tt.want {
+			t.Errorf("ICalText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestICalLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		property string
+		value    string
+		want     string
+	}{
+		{"pendek", "VERSION", "2.0", "VERSION:2.0\r\n"},
+		{"tepat 75 byte", "SUMMARY", strings.Repeat("a", 67), "SUMMARY:" + strings.Repeat("a", 67) + "\r\n"},
+		{
+			"dipotong",
+			"SUMMARY",
+			strings.Repeat("a", 100),
+			"SUMMARY:" + strings.Repeat("a", 67) + "\r\n " + strings.Repeat("a", 33) + "\r\n",
+		},
+		{
+			// karakter multibyte tidak boleh terpotong di tengah
+			"multibyte",
+			"SUMMARY",
+			strings.Repeat("a", 66) + "é",
+			"SUMMARY:" + strings.Repeat("a", 66) + "\r\n é\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ICalLine(tt.property, tt.value)
+			if got != tt.want {
+				t.Errorf("ICalLine() = %q, want %q", got, tt.want)
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n") {
+				if len(line) > 75 {
+					t.Errorf("baris %q lebih dari 75 byte", line)
+				}
+			}
+		})
+	}
+}
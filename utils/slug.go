@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var nonSlugCharRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify mengubah teks menjadi slug huruf kecil dipisah "-",
+// contoh "Mid Range!" -> "mid-range"
+func Slugify(text string) string {
+	slug := nonSlugCharRegex.ReplaceAllString(strings.ToLower(text), "-")
+	return strings.Trim(slug, "-")
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	batteryMahRegex   = regexp.MustCompile(`(?i)(\d[\d.,]*)\s*mah`)
+	chargingWattRegex = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*w(?:att)?\b`)
+	plainNumberRegex  = regexp.MustCompile(`^\s*(\d+)\s*$`)
+	ipRatingRegex     = regexp.MustCompile(`^IP[0-6X][0-9X]$`)
+)
+
+// ParseBatteryMah mengambil kapasitas baterai (mAh) dari teks bebas,
+// contoh "5000 mAh, 45W" -> 5000. return 0 jika tidak ditemukan
+func ParseBatteryMah(text string) uint {
+	if m := batteryMahRegex.FindStringSubmatch(text); m != nil {
+		// "5.000 mAh" / "5,000 mAh" -> 5000
+		digits := strings.NewReplacer(".", "", ",", "").Replace(m[1])
+		if v, err := strconv.ParseUint(digits, 10, 32); err == nil {
+			return uint(v)
+		}
+	}
+	if m := plainNumberRegex.FindStringSubmatch(text); m != nil {
+		if v, err := strconv.ParseUint(m[1], 10, 32); err == nil {
+			return uint(v)
+		}
+	}
+	return 0
+}
+
+// ParseChargingWatt mengambil daya charging (watt) dari teks bebas,
+// contoh "5000 mAh, 45W fast charging" -> 45. return 0 jika tidak ditemukan
+func ParseChargingWatt(text string) uint {
+	m := chargingWattRegex.FindStringSubmatch(text)
+	if m == nil {
+		return 0
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0
+	}
+	return uint(v)
+}
+
+// ParseNetworkGeneration mengubah teks network bebas menjadi generasi
+// jaringan tertinggi, contoh "GSM / HSPA / LTE" -> "4G". return "" jika tidak dikenali
+func ParseNetworkGeneration(text string) string {
+	t := strings.ToLower(text)
+	switch {
+	case strings.Contains(t, "5g"):
+		return "5G"
+	case strings.Contains(t, "4g") || strings.Contains(t, "lte"):
+		return "4G"
+	case strings.Contains(t, "3g") || strings.Contains(t, "hspa") ||
+		strings.Contains(t, "umts") || strings.Contains(t, "wcdma") || strings.Contains(t, "cdma"):
+		return "3G"
+	case strings.Contains(t, "2g") || strings.Contains(t, "gsm") ||
+		strings.Contains(t, "edge") || strings.Contains(t, "gprs"):
+		return "2G"
+	}
+	return ""
+}
+
+// IsValidIPRating validasi format IP rating, contoh "IP68" atau "IPX4"
+func IsValidIPRating(rating string) bool {
+	return ipRatingRegex.MatchString(strings.ToUpper(rating))
+}
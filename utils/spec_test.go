@@ -0,0 +1,79 @@
+package utils
+
+import "testing"
+
+func TestParseBatteryMah(t *testing.T) {
+	tests := []struct {
+		text string
+		want uint
+	}{
+		{"5000 mAh, 45W", 5000},
+		{"Li-Po 4500mAh", 4500},
+		{"5.000 mAh", 5000},
+		{"5,000 MAH", 5000},
+		{"4000", 4000},
+		{"non-removable", 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := ParseBatteryMah(tt.text); got != tt.want {
+			t.Errorf("ParseBatteryMah(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseChargingWatt(t *testing.T) {
+	tests := []struct {
+		text string
+		want uint
+	}{
+		{"5000 mAh, 45W fast charging", 45},
+		{"fast charging 33 watt", 33},
+		{"charging 22.5W", 22},
+		{"5000 mAh", 0},
+		{"wireless", 0},
+	}
+	for _, tt := range tests {
+		if got := ParseChargingWatt(tt.text); got != tt.want {
+			t.Errorf("ParseChargingWatt(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseNetworkGeneration(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"GSM / HSPA / LTE / 5G", "5G"},
+		{"GSM / HSPA / LTE", "4G"},
+		{"4G", "4G"},
+		{"GSM / WCDMA", "3G"},
+		{"GSM / EDGE", "2G"},
+		{"wifi only", ""},
+	}
+	for _, tt := range tests {
+		if got := ParseNetworkGeneration(tt.text); got != tt.want {
+			t.Errorf("ParseNetworkGeneration(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestIsValidIPRating(t *testing.T) {
+	tests := []struct {
+		rating string
+		want   bool
+	}{
+		{"IP68", true},
+		{"ipx4", true},
+		{"IP5X", true},
+		{"IP78", false},
+		{"IP6", false},
+		{"water resistant", false},
+	}
+	for _, tt := range tests {
+		if got := IsValidIPRating(tt.rating); got != tt.want {
+			t.Errorf("IsValidIPRating(%q) = %v, want %v", tt.rating, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,373 @@
+package valuescore
+
+import (
+	"final-project/models"
+	"final-project/utils"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ComponentMemory    = "memory"
+	ComponentStorage   = "storage"
+	ComponentCamera    = "camera"
+	ComponentBattery   = "battery"
+	ComponentBenchmark = "benchmark"
+
+	// harga dalam satuan juta IDR, value_score = spec score per 1 juta rupiah
+	priceUnit = 1_000_000
+)
+
+// Components semua komponen spec score
+var Components = []string{ComponentMemory, ComponentStorage, ComponentCamera, ComponentBattery, ComponentBenchmark}
+
+// Config bobot tiap komponen & key atribut benchmark (contoh antutu)
+type Config struct {
+	Weights            map[string]float64 `json:"weights"`
+	BenchmarkAttribute string             `json:"benchmark_attribute"`
+}
+
+// recompute & refresh tidak boleh berjalan bersamaan agar hasil tidak saling timpa
+var mu sync.Mutex
+
+// LoadConfig baca konfigurasi dari env VALUE_SCORE_WEIGHTS & VALUE_SCORE_BENCHMARK_ATTRIBUTE
+func LoadConfig() (Config, error) {
+	config := Config{
+		Weights:            map[string]float64{},
+		BenchmarkAttribute: utils.GetEnv("VALUE_SCORE_BENCHMARK_ATTRIBUTE", "antutu"),
+	}
+
+	weights := utils.GetEnv("VALUE_SCORE_WEIGHTS", "memory=1,storage=1,camera=1,battery=1,benchmark=2")
+	for _, pair := range strings.Split(weights, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(pair, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !isComponent(name) {
+			return config, fmt.Errorf("VALUE_SCORE_WEIGHTS: komponen %q tidak dikenal", name)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return config, fmt.Errorf("VALUE_SCORE_WEIGHTS: bobot %q harus berupa angka positif", name)
+		}
+		config.Weights[name] = weight
+	}
+
+	return config, nil
+}
+
+func isComponent(name string) bool {
+	for _, component := range Components {
+		if component == name {
+			return true
+		}
+	}
+	return false
+}
+
+type phoneSpec struct {
+	PhoneID uint
+	Price   uint
+	// nilai tiap komponen, benchmark tidak ada jika phone tidak punya atribut benchmark,
+	// battery tidak ada jika battery_mah tidak diketahui
+	values map[string]float64
+}
+
+// Recompute hitung ulang spec_score & value_score semua phone. tiap komponen
+// dinormalisasi terhadap nilai tertinggi semua phone (0 - 1), spec_score rata-rata
+// berbobot komponen yg tersedia (0 - 100), value_score spec_score per 1 juta harga.
+// phone tanpa specification atau harga dikosongkan
+func Recompute(db *gorm.DB) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	return recomputeAll(db, config)
+}
+
+// Refresh hitung ulang score phone dgn id tsb setelah specification, harga
+// atau atribut benchmark-nya berubah. semua phone hanya dihitung ulang jika
+// nilai tertinggi atau bobot salah satu komponen berubah (contoh phone dgn
+// spec tertinggi ditambah / dihapus, atau VALUE_SCORE_WEIGHTS diganti)
+func Refresh(db *gorm.DB, phoneIDs ...uint) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	maxValues, err := loadMaxValues(db, config)
+	if err != nil {
+		return err
+	}
+	var saved []models.ValueScoreComponent
+	if err := db.Find(&saved).Error; err != nil {
+		return err
+	}
+	if componentsChanged(saved, maxValues, config.Weights) {
+		return recomputeAll(db, config)
+	}
+
+	if len(phoneIDs) == 0 {
+		return nil
+	}
+	specs, err := loadSpecs(db, config, phoneIDs)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// phone yg tidak punya specification tidak punya score
+		if err := tx.Model(&models.Phone{}).
+			Where("id IN ?", phoneIDs).
+			Where("spec_score IS NOT NULL OR value_score IS NOT NULL").
+			UpdateColumns(map[string]any{"spec_score": nil, "value_score": nil}).Error; err != nil {
+			return err
+		}
+		return saveScores(tx, specs, maxValues, config.Weights)
+	})
+}
+
+// recomputeAll hitung ulang semua phone & simpan nilai tertinggi tiap komponen
+func recomputeAll(db *gorm.DB, config Config) error {
+	specs, err := loadSpecs(db, config, nil)
+	if err != nil {
+		return err
+	}
+
+	maxValues := map[string]float64{}
+	for _, spec := range specs {
+		for name, value := range spec.values {
+			maxValues[name] = math.Max(maxValues[name], value)
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// phone yg tidak punya specification tidak punya score
+		if err := tx.Model(&models.Phone{}).
+			Where("id NOT IN (?)", tx.Model(&models.Specification{}).Select("phone_id")).
+			Where("spec_score IS NOT NULL OR value_score IS NOT NULL").
+			UpdateColumns(map[string]any{"spec_score": nil, "value_score": nil}).Error; err != nil {
+			return err
+		}
+
+		if err := saveScores(tx, specs, maxValues, config.Weights); err != nil {
+			return err
+		}
+
+		components := make([]models.ValueScoreComponent, 0, len(Components))
+		for _, name := range Components {
+			components = append(components, models.ValueScoreComponent{
+				Name:     name,
+				MaxValue: maxValues[name],
+				Weight:   config.Weights[name],
+			})
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&components).Error
+	})
+}
+
+func saveScores(tx *gorm.DB, specs []phoneSpec, maxValues map[string]float64, weights map[string]float64) error {
+	for _, spec := range specs {
+		specScore := score(spec, maxValues, weights)
+		columns := map[string]any{"spec_score": nil, "value_score": nil}
+		if specScore != nil {
+			columns["spec_score"] = *specScore
+			if spec.Price > 0 {
+				columns["value_score"] = round(*specScore / (float64(spec.Price) / priceUnit))
+			}
+		}
+		if err := tx.Model(&models.Phone{}).Where("id = ?", spec.PhoneID).UpdateColumns(columns).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// componentsChanged true jika nilai tertinggi atau bobot komponen berbeda dgn
+// saat semua phone terakhir dihitung ulang
+func componentsChanged(saved []models.ValueScoreComponent, maxValues map[string]float64, weights map[string]float64) bool {
+	byName := map[string]models.ValueScoreComponent{}
+	for _, component := range saved {
+		byName[component.Name] = component
+	}
+	for _, name := range Components {
+		component, ok := byName[name]
+		if !ok || component.Weight != weights[name] || math.Abs(component.MaxValue-maxValues[name]) > 1e-9 {
+			return true
+		}
+	}
+	return false
+}
+
+func score(spec phoneSpec, maxValues map[string]float64, weights map[string]float64) *float64 {
+	total, totalWeight := 0.0, 0.0
+	for _, name := range Components {
+		value, ok := spec.values[name]
+		weight := weights[name]
+		if !ok || weight == 0 {
+			continue
+		}
+		if maxValues[name] > 0 {
+			total += weight * value / maxValues[name]
+		}
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return nil
+	}
+	result := round(total / totalWeight * 100)
+	return &result
+}
+
+type specRow struct {
+	PhoneID    uint
+	Price      uint
+	MemoryGB   float64
+	StorageGB  float64
+	BatteryMah *float64
+	CameraMP   float64
+}
+
+// specQuery nilai komponen dari specification pertama tiap phone, phoneIDs
+// membatasi ke phone tsb saja (nil untuk semua phone)
+func specQuery(db *gorm.DB, phoneIDs []uint) *gorm.DB {
+	query := db.Table("phones").
+		Select(`phones.id as phone_id, phones.price,
+				specifications.memory_gb, specifications.storage_gb, specifications.battery_mah,
+				COALESCE(MAX(camera_modules.resolution_mp), 0) as camera_mp`).
+		Joins(`JOIN specifications on specifications.id =
+				(SELECT MIN(s.id) FROM specifications s WHERE s.phone_id = phones.id)`).
+		Joins("LEFT JOIN camera_modules on camera_modules.specification_id = specifications.id AND camera_modules.position = ?", "rear").
+		Group("phones.id, phones.price, specifications.memory_gb, specifications.storage_gb, specifications.battery_mah")
+	if phoneIDs != nil {
+		query = query.Where("phones.id IN ?", phoneIDs)
+	}
+	return query
+}
+
+// benchmarkAttribute atribut angka yg dipakai sebagai komponen benchmark,
+// nil jika tidak ada
+func benchmarkAttribute(db *gorm.DB, config Config) (*models.SpecAttribute, error) {
+	if config.BenchmarkAttribute == "" {
+		return nil, nil
+	}
+	var attribute models.SpecAttribute
+	result := db.Where("attribute_key = ?", config.BenchmarkAttribute).Limit(1).Find(&attribute)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 || attribute.DataType != models.AttributeTypeNumber {
+		return nil, nil
+	}
+	return &attribute, nil
+}
+
+// loadMaxValues nilai tertinggi tiap komponen dari semua phone yg punya
+// specification, dihitung di database tanpa memuat data tiap phone
+func loadMaxValues(db *gorm.DB, config Config) (map[string]float64, error) {
+	var row struct {
+		Memory  float64
+		Storage float64
+		Camera  float64
+		Battery float64
+	}
+	if err := db.Table("(?) as specs", specQuery(db, nil)).
+		Select(`COALESCE(MAX(memory_gb), 0) as memory,
+				COALESCE(MAX(storage_gb), 0) as storage,
+				COALESCE(MAX(camera_mp), 0) as camera,
+				COALESCE(MAX(battery_mah), 0) as battery`).
+		Scan(&row).Error; err != nil {
+		return nil, err
+	}
+	maxValues := map[string]float64{
+		ComponentMemory:  row.Memory,
+		ComponentStorage: row.Storage,
+		ComponentCamera:  row.Camera,
+		ComponentBattery: row.Battery,
+	}
+
+	attribute, err := benchmarkAttribute(db, config)
+	if err != nil {
+		return nil, err
+	}
+	if attribute != nil {
+		var benchmark float64
+		if err := db.Model(&models.SpecAttributeValue{}).
+			Select("COALESCE(MAX(value_number), 0)").
+			Where("attribute_id = ? AND value_number IS NOT NULL", attribute.ID).
+			Where("phone_id IN (?)", db.Model(&models.Specification{}).Select("phone_id")).
+			Scan(&benchmark).Error; err != nil {
+			return nil, err
+		}
+		maxValues[ComponentBenchmark] = math.Max(benchmark, 0)
+	}
+	return maxValues, nil
+}
+
+// loadSpecs ambil nilai komponen dari specification pertama tiap phone,
+// phoneIDs membatasi ke phone tsb saja (nil untuk semua phone)
+func loadSpecs(db *gorm.DB, config Config, phoneIDs []uint) ([]phoneSpec, error) {
+	var rows []specRow
+	if err := specQuery(db, phoneIDs).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	benchmarks := map[uint]float64{}
+	attribute, err := benchmarkAttribute(db, config)
+	if err != nil {
+		return nil, err
+	}
+	if attribute != nil {
+		query := db.Where("attribute_id = ? AND value_number IS NOT NULL", attribute.ID)
+		if phoneIDs != nil {
+			query = query.Where("phone_id IN ?", phoneIDs)
+		}
+		var values []models.SpecAttributeValue
+		if err := query.Find(&values).Error; err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			benchmarks[value.PhoneID] = math.Max(*value.ValueNumber, 0)
+		}
+	}
+
+	specs := make([]phoneSpec, 0, len(rows))
+	for _, row := range rows {
+		spec := phoneSpec{
+			PhoneID: row.PhoneID,
+			Price:   row.Price,
+			values: map[string]float64{
+				ComponentMemory:  row.MemoryGB,
+				ComponentStorage: row.StorageGB,
+				ComponentCamera:  row.CameraMP,
+			},
+		}
+		// battery yg tidak diketahui tidak ikut dihitung
+		if row.BatteryMah != nil {
+			spec.values[ComponentBattery] = *row.BatteryMah
+		}
+		if benchmark, ok := benchmarks[row.PhoneID]; ok {
+			spec.values[ComponentBenchmark] = benchmark
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}